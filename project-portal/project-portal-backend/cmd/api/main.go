@@ -13,11 +13,15 @@ import (
 	"carbon-scribe/project-portal/project-portal-backend/internal/auth"
 	"carbon-scribe/project-portal/project-portal-backend/internal/collaboration"
 	"carbon-scribe/project-portal/project-portal-backend/internal/config"
+	"carbon-scribe/project-portal/project-portal-backend/internal/database"
 	"carbon-scribe/project-portal/project-portal-backend/internal/health"
 	"carbon-scribe/project-portal/project-portal-backend/internal/integration"
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
 	"carbon-scribe/project-portal/project-portal-backend/internal/reports"
+	"carbon-scribe/project-portal/project-portal-backend/internal/retention"
 	"carbon-scribe/project-portal/project-portal-backend/internal/search"
 	"carbon-scribe/project-portal/project-portal-backend/pkg/elastic"
+	"carbon-scribe/project-portal/project-portal-backend/pkg/eventbus"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -45,6 +49,11 @@ func main() {
 	}
 	log.Println("✅ Database connection established")
 
+	// Apply SQL migrations before anything touches the schema
+	if err := runSQLMigrations(db, cfg.MigrationsPath); err != nil {
+		log.Fatalf("❌ Failed to apply database migrations: %v", err)
+	}
+
 	// Run all migrations
 	if err := runAllMigrations(db); err != nil {
 		log.Printf("⚠️ Migration warnings: %v", err)
@@ -69,24 +78,69 @@ func main() {
 	searchService := search.NewService(searchRepo)
 	searchHandler := search.NewHandler(searchService)
 
-	authHandler := &auth.Handler{}
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("❌ Failed to get underlying DB: %v", err)
+	}
+	authHandler := auth.NewHandler(auth.NewRepository(sqlDB))
+
+	// Domain events fan out from producers to every subscribing module
+	eventBus := eventbus.NewBus(eventbus.DefaultQueueSize)
 
 	collabRepo := collaboration.NewRepository(db)
 	collabService := collaboration.NewService(collabRepo)
+	collabService.SetEventPublisher(eventBus)
 	collabHandler := collaboration.NewHandler(collabService)
 
 	healthRepo := health.NewRepository(db)
 	healthService := health.NewService(healthRepo)
 	healthHandler := health.NewHandler(healthService)
+	probes := health.NewProbes(health.DefaultReadinessTimeout, readinessDependencies(db, esClient)...)
 
 	integrationRepo := integration.NewRepository(db)
 	integrationService := integration.NewService(integrationRepo)
+	oauthProviders := make(map[string]integration.OAuthProviderConfig, len(cfg.OAuth.Providers))
+	for name, provider := range cfg.OAuth.Providers {
+		oauthProviders[name] = integration.OAuthProviderConfig(provider)
+	}
+	integrationService.ConfigureOAuth(cfg.OAuth.RedirectURL, oauthProviders)
+	eventBus.Subscribe("integrations", integrationService.HandleEvent)
+	deliveryCtx, stopDeliveries := context.WithCancel(context.Background())
+	integrationService.StartDeliveryWorker(deliveryCtx, integration.DeliveryWorkerInterval)
 	integrationHandler := integration.NewHandler(integrationService)
 
 	reportsRepo := reports.NewRepository(db)
-	reportsService := reports.NewService(reportsRepo, nil) // Exporter can be added later
+	reportsService := reports.NewService(reportsRepo, reports.NewExporter())
+	reportFiles, err := reports.NewDiskStore(cfg.Reports.StorageDir)
+	if err != nil {
+		log.Fatalf("❌ Failed to prepare report storage: %v", err)
+	}
+	if cfg.Reports.DownloadSecret == "" {
+		log.Printf("⚠️ REPORTS_DOWNLOAD_SECRET is not set; report download links will stop working on restart")
+	}
+	reportsService.ConfigureDownloads(reportFiles, reports.NewDownloadSigner(cfg.Reports.DownloadSecret, cfg.Reports.DownloadURLTTL))
+	reportsService.ConfigureQueryPlans(cfg.Reports.QueryPlans)
 	reportsHandler := reports.NewHandler(reportsService)
 
+	// Executions a crashed worker left in processing would otherwise never finish
+	if swept, err := reportsService.SweepStuckExecutions(context.Background(), reports.DefaultStuckExecutionAge); err != nil {
+		log.Printf("⚠️ Failed to sweep stuck report executions: %v", err)
+	} else if swept > 0 {
+		log.Printf("✅ Marked %d orphaned report executions as failed", swept)
+	}
+
+	reportsScheduler := reports.NewScheduler(reportsRepo, reportsService, reports.DefaultSchedulerInterval)
+	if err := reportsScheduler.Start(context.Background()); err != nil {
+		log.Printf("⚠️ Failed to start report scheduler: %v", err)
+	}
+
+	// Purge executions and delivery logs past their retention window, sparing legal holds
+	retentionJob := retention.NewJob(cfg.Retention.Windows, cfg.Retention.DryRun)
+	retentionJob.Register("report_executions", reportsService.PurgeExecutions)
+	retentionJob.Register("webhook_deliveries", integrationService.PurgeDeliveries)
+	retentionCtx, stopRetention := context.WithCancel(context.Background())
+	retentionJob.Start(retentionCtx, cfg.Retention.Interval)
+
 	// Setup Gin
 	if !cfg.Debug {
 		gin.SetMode(gin.ReleaseMode)
@@ -96,6 +150,14 @@ func main() {
 
 	// Add CORS middleware
 	router.Use(corsMiddleware())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.BodyLimit(cfg.MaxBodyBytes, largeBodyRoutes))
+
+	// Handlers report failures with c.Error; this writes them as a uniform JSON body
+	router.Use(middleware.ErrorHandler())
+
+	// Orchestrator probes: /livez restarts a wedged process, /readyz gates traffic
+	probes.RegisterRoutes(router)
 
 	// Health check endpoint
 	router.GET("/health", func(c *gin.Context) {
@@ -115,6 +177,8 @@ func main() {
 			"version": "1.0.0",
 			"endpoints": gin.H{
 				"health":        "/health",
+				"livez":         "/livez",
+				"readyz":        "/readyz",
 				"auth":          "/api/auth/*",
 				"collaboration": "/api/collaboration/*",
 				"integration":   "/api/integration/*",
@@ -137,7 +201,7 @@ func main() {
 	v1 := router.Group("/api/v1")
 	{
 		// Register reports routes under v1
-		reportsHandler.RegisterRoutes(v1)
+		reportsHandler.RegisterRoutes(v1, auth.AuthMiddleware())
 
 		// Register health routes under v1
 		healthHandler.RegisterRoutes(v1)
@@ -169,6 +233,7 @@ func main() {
 		fmt.Printf("🚀 Server starting on port %s\n", cfg.Port)
 		fmt.Printf("📡 Listening on http://localhost:%s\n", cfg.Port)
 		fmt.Printf("📊 Health check: http://localhost:%s/health\n", cfg.Port)
+		fmt.Printf("🩺 Probes: http://localhost:%s/livez, http://localhost:%s/readyz\n", cfg.Port, cfg.Port)
 		fmt.Println("🔗 Available endpoints:")
 		fmt.Println("   - Authentication: /api/auth/*")
 		fmt.Println("   - Collaboration: /api/collaboration/*")
@@ -195,6 +260,21 @@ func main() {
 		log.Fatalf("❌ Server forced to shutdown: %v", err)
 	}
 
+	// Stop dispatching schedules first so no execution starts while the service drains
+	if err := reportsScheduler.Stop(ctx); err != nil {
+		log.Printf("⚠️ Scheduled reports still running at shutdown: %v", err)
+	}
+
+	// Let in-flight report executions finish until the deadline, then cancel them
+	if err := reportsService.Shutdown(ctx); err != nil {
+		log.Printf("⚠️ Report executions cancelled at shutdown: %v", err)
+	}
+
+	// Let queued events finish before exiting
+	stopDeliveries()
+	stopRetention()
+	eventBus.Close()
+
 	fmt.Println("✅ Server exited gracefully")
 }
 
@@ -219,9 +299,7 @@ func initDatabase(config *config.Config) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to get underlying DB: %w", err)
 	}
 
-	sqlDB.SetMaxOpenConns(25)
-	sqlDB.SetMaxIdleConns(5)
-	sqlDB.SetConnMaxLifetime(5 * time.Minute)
+	database.ConfigurePool(sqlDB, config.Database)
 
 	// Test connection
 	if err := sqlDB.Ping(); err != nil {
@@ -231,6 +309,57 @@ func initDatabase(config *config.Config) (*gorm.DB, error) {
 	return db, nil
 }
 
+// readinessDependencies lists what /readyz checks. Search degrades without
+// Elasticsearch, so it is reported but does not fail readiness.
+func readinessDependencies(db *gorm.DB, esClient *elastic.Client) []health.Dependency {
+	return []health.Dependency{
+		{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				sqlDB, err := db.DB()
+				if err != nil {
+					return err
+				}
+				return sqlDB.PingContext(ctx)
+			},
+		},
+		{
+			Name:     "elasticsearch",
+			Optional: true,
+			Check: func(ctx context.Context) error {
+				if esClient == nil {
+					return fmt.Errorf("client not configured")
+				}
+				return esClient.Health(ctx)
+			},
+		},
+	}
+}
+
+// runSQLMigrations applies pending files from the migrations directory
+func runSQLMigrations(db *gorm.DB, path string) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying DB: %w", err)
+	}
+
+	source, err := database.MigrationSource(path)
+	if err != nil {
+		return err
+	}
+	migrator, err := database.NewMigrator(sqlDB, source)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		return err
+	}
+	log.Printf("✅ SQL migrations up to date (%d applied)", applied)
+	return nil
+}
+
 // runAllMigrations runs migrations for all modules
 func runAllMigrations(db *gorm.DB) error {
 	// Auto-migrate all models from all modules
@@ -255,16 +384,20 @@ func runAllMigrations(db *gorm.DB) error {
 		&integration.IntegrationConnection{},
 		&integration.WebhookConfig{},
 		&integration.WebhookDelivery{},
+		&integration.WebhookDeadLetter{},
 		&integration.EventSubscription{},
 		&integration.OAuthToken{},
+		&integration.OAuthState{},
 		&integration.IntegrationHealth{},
 
 		// Report models
 		&reports.ReportDefinition{},
+		&reports.ReportVersion{},
 		&reports.ReportSchedule{},
 		&reports.ReportExecution{},
 		&reports.BenchmarkDataset{},
 		&reports.DashboardWidget{},
+		&reports.DashboardView{},
 	)
 
 	if err != nil {
@@ -299,6 +432,13 @@ func runAllMigrations(db *gorm.DB) error {
 	return nil
 }
 
+// largeBodyRoutes accept bodies beyond the default cap
+var largeBodyRoutes = map[string]int64{
+	"/api/v1/reports/benchmarks":              10 << 20, // Benchmark datasets embed their data points
+	"/api/v1/reports/benchmarks/:benchmarkId": 10 << 20,
+	"/api/v1/integrations/webhooks/incoming":  5 << 20,
+}
+
 // corsMiddleware adds CORS headers
 func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -309,7 +449,8 @@ func corsMiddleware() gin.HandlerFunc {
 
 		c.Writer.Header().Set("Access-Control-Allow-Origin", allowedOrigins)
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-User-ID")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-Request-ID")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 
 		if c.Request.Method == "OPTIONS" {
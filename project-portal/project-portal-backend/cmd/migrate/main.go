@@ -0,0 +1,114 @@
+// Command migrate applies or rolls back the SQL migrations outside of the API process.
+//
+//	migrate up          apply every pending migration
+//	migrate down [n]    roll back the latest n migrations (default 1)
+//	migrate status      list applied and pending migrations
+//	migrate force <v>   record v as applied after repairing a failed migration
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/database"
+
+	"github.com/joho/godotenv"
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	_ = godotenv.Load()
+
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL environment variable is required")
+	}
+
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	source, err := database.MigrationSource(os.Getenv("MIGRATIONS_PATH"))
+	if err != nil {
+		log.Fatalf("Failed to open migrations: %v", err)
+	}
+	migrator, err := database.NewMigrator(db, source)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	switch os.Args[1] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		log.Printf("Applied %d migration(s)", applied)
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps < 1 {
+				log.Fatalf("Invalid step count %q", os.Args[2])
+			}
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		if err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+		log.Printf("Rolled back %d migration(s)", rolledBack)
+	case "force":
+		if len(os.Args) < 3 {
+			usage()
+		}
+		version, err := strconv.ParseInt(os.Args[2], 10, 64)
+		if err != nil || version < 1 {
+			log.Fatalf("Invalid version %q", os.Args[2])
+		}
+		if err := migrator.Force(ctx, version); err != nil {
+			log.Fatalf("Force failed: %v", err)
+		}
+		log.Printf("Recorded version %d as applied", version)
+	case "status":
+		_, dirty, err := migrator.Version(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		applied, err := migrator.Applied(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		for i, m := range applied {
+			state := "applied"
+			if dirty && i == len(applied)-1 {
+				state = "dirty"
+			}
+			fmt.Printf("%-7s  %03d_%s\n", state, m.Version, m.Name)
+		}
+		for _, m := range pending {
+			fmt.Printf("pending  %03d_%s\n", m.Version, m.Name)
+		}
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate up | down [n] | status | force <version>")
+	os.Exit(2)
+}
@@ -0,0 +1,109 @@
+// Command seed fills a local database with projects, credits, transactions,
+// monitoring readings and benchmark datasets for development.
+//
+// It only runs against local hosts and is safe to run repeatedly. For load tests,
+// -scale generates a larger data set and -schema writes it to a separate schema.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/database"
+	"carbon-scribe/project-portal/project-portal-backend/internal/seed"
+
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func main() {
+	_ = godotenv.Load()
+
+	opts := seed.DefaultOptions()
+	flag.IntVar(&opts.Projects, "projects", opts.Projects, "number of projects")
+	flag.IntVar(&opts.CreditsPerProject, "credits", opts.CreditsPerProject, "carbon credit batches per project")
+	flag.IntVar(&opts.TransactionsPerCredit, "transactions", opts.TransactionsPerCredit, "transactions per credit batch")
+	flag.IntVar(&opts.ReadingsPerProject, "readings", opts.ReadingsPerProject, "daily monitoring readings per project")
+	org := flag.String("org", "", "organization ID to own the seeded rows")
+	scale := flag.Int("scale", 0, "load-test scale; replaces the per-table counts with 50 projects per step")
+	schema := flag.String("schema", "", "schema to seed instead of public, created from the migrated tables")
+	flag.Parse()
+
+	if *scale > 0 {
+		loadTest := seed.LoadTestOptions(*scale)
+		opts.Projects = loadTest.Projects
+		opts.CreditsPerProject = loadTest.CreditsPerProject
+		opts.TransactionsPerCredit = loadTest.TransactionsPerCredit
+		opts.ReadingsPerProject = loadTest.ReadingsPerProject
+	}
+
+	if *org != "" {
+		orgID, err := uuid.Parse(*org)
+		if err != nil {
+			log.Fatalf("Invalid organization ID: %v", err)
+		}
+		opts.OrganizationID = &orgID
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL environment variable is required")
+	}
+	if err := seed.CheckLocal(databaseURL); err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get underlying DB: %v", err)
+	}
+	defer sqlDB.Close()
+
+	// The fixtures need the reporting tables, so bring the schema up first
+	source, err := database.MigrationSource(os.Getenv("MIGRATIONS_PATH"))
+	if err != nil {
+		log.Fatalf("Failed to open migrations: %v", err)
+	}
+	migrator, err := database.NewMigrator(sqlDB, source)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+
+	if *schema != "" {
+		if err := seed.PrepareSchema(context.Background(), db, *schema); err != nil {
+			log.Fatal(err)
+		}
+		schemaURL, err := seed.SchemaDSN(databaseURL, *schema)
+		if err != nil {
+			log.Fatal(err)
+		}
+		db, err = gorm.Open(postgres.Open(schemaURL), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		if err != nil {
+			log.Fatalf("Failed to connect to schema %s: %v", *schema, err)
+		}
+		schemaDB, err := db.DB()
+		if err != nil {
+			log.Fatalf("Failed to get underlying DB: %v", err)
+		}
+		defer schemaDB.Close()
+	}
+
+	summary, err := seed.Run(context.Background(), db, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Seeded %d projects, %d monitoring areas, %d credit batches, %d transactions, %d readings, %d benchmarks",
+		summary.Projects, summary.Areas, summary.Credits, summary.Transactions, summary.Readings, summary.Benchmarks)
+}
@@ -1,12 +1,37 @@
 package auth
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
 
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+	"carbon-scribe/project-portal/project-portal-backend/pkg/utils"
+
 	"github.com/gin-gonic/gin"
 )
 
-type Handler struct{}
+// UserStore looks up the account a login names
+type UserStore interface {
+	GetUserByEmail(email string) (*User, error)
+}
+
+type Handler struct {
+	users UserStore
+}
+
+// NewHandler creates an auth handler that logs users in from users
+func NewHandler(users UserStore) *Handler {
+	return &Handler{users: users}
+}
+
+// LoginRequest is the body of POST /auth/login
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
 
 // Ping endpoint
 func (h *Handler) Ping(c *gin.Context) {
@@ -18,7 +43,29 @@ func (h *Handler) Register(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "register endpoint works"})
 }
 
-// Dummy login endpoint
+// Login checks the password and returns a JWT carrying the user's role and organization
 func (h *Handler) Login(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "login endpoint works"})
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+
+	// Unknown emails and wrong passwords get the same answer so accounts cannot be probed
+	user, err := h.users.GetUserByEmail(req.Email)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		c.Error(fmt.Errorf("failed to look up user: %w", err))
+		return
+	}
+	if err != nil || !user.IsActive || utils.CheckPassword(req.Password, user.PasswordHash) != nil {
+		c.Error(apperrors.Unauthorized("invalid email or password"))
+		return
+	}
+
+	token, err := GenerateJWT(user)
+	if err != nil {
+		c.Error(fmt.Errorf("failed to issue token: %w", err))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"token": token, "user": user})
 }
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+	"carbon-scribe/project-portal/project-portal-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeUserStore map[string]*User
+
+func (f fakeUserStore) GetUserByEmail(email string) (*User, error) {
+	user, ok := f[email]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+func login(t *testing.T, router *gin.Engine, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestLogin_TokenCarriesOrganization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hash, err := utils.HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	const orgID = "6f1c1c52-2a4e-4d8e-9a55-0b7c6f0e3f21"
+	users := fakeUserStore{"ana@example.com": {
+		ID:             "0d7e4a2b-5c61-4f0b-8f3e-2b9f1c7a6d10",
+		Email:          "ana@example.com",
+		PasswordHash:   hash,
+		Role:           "analyst",
+		OrganizationID: orgID,
+		IsActive:       true,
+	}}
+
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	RegisterRoutes(router, NewHandler(users))
+	var seen any
+	router.GET("/whoami", AuthMiddleware(), func(c *gin.Context) {
+		seen, _ = c.Get("organization_id")
+	})
+
+	w := login(t, router, `{"email":"ana@example.com","password":"correct horse"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	claims, err := ValidateJWT(resp.Token)
+	if err != nil {
+		t.Fatalf("ValidateJWT: %v", err)
+	}
+	if claims.OrganizationID != orgID || claims.Role != "analyst" {
+		t.Errorf("expected org %s and role analyst in claims, got %q and %q", orgID, claims.OrganizationID, claims.Role)
+	}
+
+	// The middleware hands the organization on as the caller's tenant
+	req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+	req.Header.Set("Authorization", "Bearer "+resp.Token)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if seen != orgID {
+		t.Errorf("expected organization_id %s in context, got %v", orgID, seen)
+	}
+}
+
+func TestLogin_RejectsBadCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hash, _ := utils.HashPassword("correct horse")
+	users := fakeUserStore{
+		"ana@example.com":  {Email: "ana@example.com", PasswordHash: hash, IsActive: true},
+		"gone@example.com": {Email: "gone@example.com", PasswordHash: hash, IsActive: false},
+	}
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	RegisterRoutes(router, NewHandler(users))
+
+	for name, tt := range map[string]struct {
+		body   string
+		status int
+		code   string
+	}{
+		"wrong password": {`{"email":"ana@example.com","password":"battery staple"}`, http.StatusUnauthorized, middleware.CodeUnauthorized},
+		"unknown email":  {`{"email":"bob@example.com","password":"correct horse"}`, http.StatusUnauthorized, middleware.CodeUnauthorized},
+		"inactive user":  {`{"email":"gone@example.com","password":"correct horse"}`, http.StatusUnauthorized, middleware.CodeUnauthorized},
+		"invalid email":  {`{"email":"ana","password":"correct horse"}`, http.StatusBadRequest, middleware.CodeValidation},
+	} {
+		w := login(t, router, tt.body)
+		var body middleware.ErrorResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		if w.Code != tt.status || body.Code != tt.code {
+			t.Errorf("%s: expected %d %s, got %d %s", name, tt.status, tt.code, w.Code, w.Body.String())
+		}
+	}
+}
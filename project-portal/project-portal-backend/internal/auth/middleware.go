@@ -1,18 +1,20 @@
 package auth
 
 import (
-	"net/http"
 	"strings"
 
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware validates JWT tokens in the Authorization header
+// AuthMiddleware validates JWT tokens in the Authorization header. Rejected
+// requests are aborted with an unauthorized error for ErrorHandler to write.
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header missing"})
+			c.Error(apperrors.Unauthorized("Authorization header missing"))
 			c.Abort()
 			return
 		}
@@ -20,7 +22,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		// Expect "Bearer <token>"
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
+			c.Error(apperrors.Unauthorized("Authorization header format must be Bearer {token}"))
 			c.Abort()
 			return
 		}
@@ -29,7 +31,7 @@ func AuthMiddleware() gin.HandlerFunc {
 
 		claims, err := ValidateJWT(tokenStr)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token: " + err.Error()})
+			c.Error(apperrors.Unauthorized("Invalid token: " + err.Error()))
 			c.Abort()
 			return
 		}
@@ -38,6 +40,9 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
 		c.Set("role", claims.Role)
+		if claims.OrganizationID != "" {
+			c.Set("organization_id", claims.OrganizationID)
+		}
 
 		c.Next()
 	}
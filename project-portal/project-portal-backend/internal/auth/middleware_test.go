@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAuthMiddleware_RejectsWithErrorEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	router.GET("/private", AuthMiddleware(), func(c *gin.Context) {
+		t.Error("handler reached without a valid token")
+	})
+
+	for name, header := range map[string]string{
+		"missing header": "",
+		"wrong scheme":   "Basic dXNlcjpwYXNz",
+		"invalid token":  "Bearer not-a-jwt",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/private", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var body middleware.ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: decode response: %v", name, err)
+		}
+		if w.Code != http.StatusUnauthorized || body.Code != middleware.CodeUnauthorized || body.Error == "" {
+			t.Errorf("%s: expected a 401 %s envelope, got %d %s", name, middleware.CodeUnauthorized, w.Code, w.Body.String())
+		}
+	}
+}
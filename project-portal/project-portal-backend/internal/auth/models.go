@@ -5,13 +5,14 @@ import (
 )
 
 type User struct {
-	ID            string    `json:"id"`
-	Email         string    `json:"email"`
-	PasswordHash  string    `json:"-"`
-	FullName      string    `json:"full_name"`
-	Role          string    `json:"role"`
-	EmailVerified bool      `json:"email_verified"`
-	IsActive      bool      `json:"is_active"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID             string    `json:"id"`
+	Email          string    `json:"email"`
+	PasswordHash   string    `json:"-"`
+	FullName       string    `json:"full_name"`
+	Role           string    `json:"role"`
+	OrganizationID string    `json:"organization_id,omitempty"`
+	EmailVerified  bool      `json:"email_verified"`
+	IsActive       bool      `json:"is_active"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
@@ -12,8 +12,8 @@ func NewRepository(db *sql.DB) *Repository {
 
 func (r *Repository) CreateUser(user *User) error {
 	query := `
-		INSERT INTO users (email, password_hash, full_name, role)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO users (email, password_hash, full_name, role, organization_id)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid)
 		RETURNING id, created_at
 	`
 	return r.DB.QueryRow(
@@ -22,13 +22,14 @@ func (r *Repository) CreateUser(user *User) error {
 		user.PasswordHash,
 		user.FullName,
 		user.Role,
+		user.OrganizationID,
 	).Scan(&user.ID, &user.CreatedAt)
 }
 
 func (r *Repository) GetUserByEmail(email string) (*User, error) {
 	user := &User{}
 	query := `
-		SELECT id, email, password_hash, full_name, role, email_verified, is_active, created_at
+		SELECT id, email, password_hash, full_name, role, COALESCE(organization_id::text, ''), email_verified, is_active, created_at
 		FROM users WHERE email = $1
 	`
 	err := r.DB.QueryRow(query, email).Scan(
@@ -37,6 +38,7 @@ func (r *Repository) GetUserByEmail(email string) (*User, error) {
 		&user.PasswordHash,
 		&user.FullName,
 		&user.Role,
+		&user.OrganizationID,
 		&user.EmailVerified,
 		&user.IsActive,
 		&user.CreatedAt,
@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -25,13 +27,13 @@ func (h *Handler) InviteUser(c *gin.Context) {
 	projectID := c.Param("id")
 	var req InviteUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	invite, err := h.service.InviteUser(c.Request.Context(), projectID, req.Email, req.Role)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -45,7 +47,7 @@ func (h *Handler) GetActivities(c *gin.Context) {
 
 	activities, err := h.service.ListProjectActivities(c.Request.Context(), projectID, limit, offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -55,13 +57,13 @@ func (h *Handler) GetActivities(c *gin.Context) {
 func (h *Handler) CreateComment(c *gin.Context) {
 	var comment Comment
 	if err := c.ShouldBindJSON(&comment); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 	// Assume UserID is set from auth middleware context, but for now take from body
 	
 	if err := h.service.AddComment(c.Request.Context(), &comment); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -71,12 +73,12 @@ func (h *Handler) CreateComment(c *gin.Context) {
 func (h *Handler) CreateTask(c *gin.Context) {
 	var task Task
 	if err := c.ShouldBindJSON(&task); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 	
 	if err := h.service.CreateTask(c.Request.Context(), &task); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -86,12 +88,12 @@ func (h *Handler) CreateTask(c *gin.Context) {
 func (h *Handler) CreateResource(c *gin.Context) {
 	var resource SharedResource
 	if err := c.ShouldBindJSON(&resource); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 	
 	if err := h.service.AddResource(c.Request.Context(), &resource); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
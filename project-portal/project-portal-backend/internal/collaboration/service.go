@@ -4,17 +4,47 @@ import (
 	"context"
 	"time"
 
+	"carbon-scribe/project-portal/project-portal-backend/pkg/eventbus"
+
 	"github.com/google/uuid"
 )
 
 type Service struct {
-	repo Repository
+	repo   Repository
+	events eventbus.Publisher
 }
 
 func NewService(repo Repository) *Service {
 	return &Service{repo: repo}
 }
 
+// SetEventPublisher makes the service publish a domain event for every logged activity
+func (s *Service) SetEventPublisher(publisher eventbus.Publisher) {
+	s.events = publisher
+}
+
+// recordActivity logs an activity and publishes it as "collaboration.<action>"
+func (s *Service) recordActivity(ctx context.Context, activity *ActivityLog) {
+	_ = s.repo.CreateActivity(ctx, activity)
+
+	if s.events == nil {
+		return
+	}
+	data := map[string]any{
+		"project_id": activity.ProjectID,
+		"user_id":    activity.UserID,
+		"type":       activity.Type,
+	}
+	for k, v := range activity.Metadata {
+		data[k] = v
+	}
+	s.events.Publish(ctx, eventbus.Event{
+		Type:   "collaboration." + activity.Action,
+		Source: "collaboration",
+		Data:   data,
+	})
+}
+
 // InviteUser creates an invitation for a user
 func (s *Service) InviteUser(ctx context.Context, projectID, email, role string) (*ProjectInvitation, error) {
 	token := uuid.New().String()
@@ -33,7 +63,7 @@ func (s *Service) InviteUser(ctx context.Context, projectID, email, role string)
 	}
 
 	// Log activity
-	_ = s.repo.CreateActivity(ctx, &ActivityLog{
+	s.recordActivity(ctx, &ActivityLog{
 		ProjectID: projectID,
 		Type:      "system",
 		Action:    "user_invited",
@@ -56,7 +86,7 @@ func (s *Service) AddComment(ctx context.Context, comment *Comment) error {
 	}
 	
 	// Log activity
-	_ = s.repo.CreateActivity(ctx, &ActivityLog{
+	s.recordActivity(ctx, &ActivityLog{
 		ProjectID: comment.ProjectID,
 		UserID:    comment.UserID,
 		Type:      "user",
@@ -74,7 +104,7 @@ func (s *Service) CreateTask(ctx context.Context, task *Task) error {
 	}
 	
 	// Log activity
-	_ = s.repo.CreateActivity(ctx, &ActivityLog{
+	s.recordActivity(ctx, &ActivityLog{
 		ProjectID: task.ProjectID,
 		UserID:    task.CreatedBy,
 		Type:      "user",
@@ -93,7 +123,7 @@ func (s *Service) AddResource(ctx context.Context, resource *SharedResource) err
 	}
 	
 	// Log activity
-	_ = s.repo.CreateActivity(ctx, &ActivityLog{
+	s.recordActivity(ctx, &ActivityLog{
 		ProjectID: resource.ProjectID,
 		UserID:    resource.UploadedBy,
 		Type:      "user",
@@ -3,15 +3,39 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds application configuration
 type Config struct {
-	Port          string
-	DatabaseURL   string
-	Debug         bool
-	Elasticsearch ElasticsearchConfig
+	Port           string
+	DatabaseURL    string
+	Debug          bool
+	MigrationsPath string // Directory of SQL migrations; empty uses the copy built into the binary
+	MaxBodyBytes   int64  // Default request body cap; large endpoints override it
+	Database       DatabaseConfig
+	Elasticsearch  ElasticsearchConfig
+	OAuth          OAuthConfig
+	Reports        ReportsConfig
+	Retention      RetentionConfig
+}
+
+// ReportsConfig holds where generated report files live and how download links are signed
+type ReportsConfig struct {
+	StorageDir     string
+	DownloadSecret string        // HMAC key for download links; empty uses a per-process random key
+	DownloadURLTTL time.Duration // How long a signed download link stays valid
+	QueryPlans     bool          // Lets platform admins capture the EXPLAIN output of an execution
+}
+
+// DatabaseConfig holds connection pool limits applied to every database handle
+type DatabaseConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration // Zero keeps idle connections until ConnMaxLifetime
 }
 
 // ElasticsearchConfig holds configuration for Elasticsearch
@@ -23,6 +47,31 @@ type ElasticsearchConfig struct {
 	APIKey    string
 }
 
+// OAuthConfig holds OAuth client settings for integration providers
+type OAuthConfig struct {
+	RedirectURL string // Public URL of the /api/v1/integrations/oauth/callback endpoint
+	Providers   map[string]OAuthProviderConfig
+}
+
+// OAuthProviderConfig holds the OAuth client registration for one provider
+type OAuthProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+	AuthURL      string // Optional for providers with known endpoints
+	TokenURL     string // Optional for providers with known endpoints
+	Scopes       []string
+}
+
+// RetentionConfig holds how long records are kept per table before the purge job deletes them
+type RetentionConfig struct {
+	Interval time.Duration
+	DryRun   bool                     // Only log what would be deleted
+	Windows  map[string]time.Duration // Per table; tables without a window are kept forever
+}
+
+// retentionTables are the tables the purge job can be configured for
+var retentionTables = []string{"report_executions", "webhook_deliveries"}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	port := os.Getenv("PORT")
@@ -42,10 +91,38 @@ func Load() (*Config, error) {
 		esAddresses = "http://localhost:9200"
 	}
 
+	database, err := loadDatabaseConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	maxBodyBytes, err := envInt("MAX_REQUEST_BODY_BYTES", 1<<20)
+	if err != nil {
+		return nil, err
+	}
+
+	downloadURLTTL, err := envDuration("REPORTS_DOWNLOAD_URL_TTL", 15*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	retention, err := loadRetentionConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	reportsStorageDir := os.Getenv("REPORTS_STORAGE_DIR")
+	if reportsStorageDir == "" {
+		reportsStorageDir = "./data/reports"
+	}
+
 	return &Config{
-		Port:        port,
-		DatabaseURL: databaseURL,
-		Debug:       debug,
+		Port:           port,
+		DatabaseURL:    databaseURL,
+		Debug:          debug,
+		MigrationsPath: os.Getenv("MIGRATIONS_PATH"),
+		MaxBodyBytes:   int64(maxBodyBytes),
+		Database:       database,
 		Elasticsearch: ElasticsearchConfig{
 			Addresses: strings.Split(esAddresses, ","),
 			Username:  os.Getenv("ELASTICSEARCH_USERNAME"),
@@ -53,5 +130,126 @@ func Load() (*Config, error) {
 			CloudID:   os.Getenv("ELASTICSEARCH_CLOUD_ID"),
 			APIKey:    os.Getenv("ELASTICSEARCH_API_KEY"),
 		},
+		OAuth: loadOAuthConfig(),
+		Reports: ReportsConfig{
+			StorageDir:     reportsStorageDir,
+			DownloadSecret: os.Getenv("REPORTS_DOWNLOAD_SECRET"),
+			DownloadURLTTL: downloadURLTTL,
+			QueryPlans:     os.Getenv("REPORTS_QUERY_PLANS") == "true",
+		},
+		Retention: retention,
 	}, nil
 }
+
+// loadDatabaseConfig reads DATABASE_MAX_OPEN_CONNS, DATABASE_MAX_IDLE_CONNS, DATABASE_CONN_MAX_LIFETIME
+// and DATABASE_CONN_MAX_IDLE_TIME (durations such as "5m")
+func loadDatabaseConfig() (DatabaseConfig, error) {
+	cfg := DatabaseConfig{
+		MaxOpenConns:    25,
+		MaxIdleConns:    5,
+		ConnMaxLifetime: 5 * time.Minute,
+	}
+
+	var err error
+	if cfg.MaxOpenConns, err = envInt("DATABASE_MAX_OPEN_CONNS", cfg.MaxOpenConns); err != nil {
+		return cfg, err
+	}
+	if cfg.MaxIdleConns, err = envInt("DATABASE_MAX_IDLE_CONNS", cfg.MaxIdleConns); err != nil {
+		return cfg, err
+	}
+	if cfg.ConnMaxLifetime, err = envDuration("DATABASE_CONN_MAX_LIFETIME", cfg.ConnMaxLifetime); err != nil {
+		return cfg, err
+	}
+	if cfg.ConnMaxIdleTime, err = envDuration("DATABASE_CONN_MAX_IDLE_TIME", cfg.ConnMaxIdleTime); err != nil {
+		return cfg, err
+	}
+
+	// Idle connections beyond the open limit would be closed immediately
+	if cfg.MaxOpenConns > 0 && cfg.MaxIdleConns > cfg.MaxOpenConns {
+		cfg.MaxIdleConns = cfg.MaxOpenConns
+	}
+	return cfg, nil
+}
+
+// loadRetentionConfig reads RETENTION_INTERVAL, RETENTION_DRY_RUN and, per table,
+// RETENTION_<TABLE> (e.g. RETENTION_REPORT_EXECUTIONS=2160h)
+func loadRetentionConfig() (RetentionConfig, error) {
+	cfg := RetentionConfig{
+		DryRun:  os.Getenv("RETENTION_DRY_RUN") == "true",
+		Windows: make(map[string]time.Duration),
+	}
+
+	var err error
+	if cfg.Interval, err = envDuration("RETENTION_INTERVAL", 24*time.Hour); err != nil {
+		return cfg, err
+	}
+	if cfg.Interval == 0 {
+		return cfg, fmt.Errorf("RETENTION_INTERVAL must be positive")
+	}
+	for _, table := range retentionTables {
+		window, err := envDuration("RETENTION_"+strings.ToUpper(table), 0)
+		if err != nil {
+			return cfg, err
+		}
+		if window > 0 {
+			cfg.Windows[table] = window
+		}
+	}
+	return cfg, nil
+}
+
+func envInt(key string, fallback int) (int, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer, got %q", key, raw)
+	}
+	return value, nil
+}
+
+func envDuration(key string, fallback time.Duration) (time.Duration, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative duration, got %q", key, raw)
+	}
+	return value, nil
+}
+
+// loadOAuthConfig reads OAUTH_PROVIDERS (e.g. "slack,github") and, for each provider,
+// OAUTH_<PROVIDER>_CLIENT_ID, _CLIENT_SECRET, _AUTH_URL, _TOKEN_URL and _SCOPES
+func loadOAuthConfig() OAuthConfig {
+	cfg := OAuthConfig{
+		RedirectURL: os.Getenv("OAUTH_REDIRECT_URL"),
+		Providers:   make(map[string]OAuthProviderConfig),
+	}
+
+	for _, name := range strings.Split(os.Getenv("OAUTH_PROVIDERS"), ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OAUTH_" + strings.ToUpper(name) + "_"
+
+		var scopes []string
+		if raw := os.Getenv(prefix + "SCOPES"); raw != "" {
+			scopes = strings.Split(raw, ",")
+		}
+
+		cfg.Providers[name] = OAuthProviderConfig{
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			AuthURL:      os.Getenv(prefix + "AUTH_URL"),
+			TokenURL:     os.Getenv(prefix + "TOKEN_URL"),
+			Scopes:       scopes,
+		}
+	}
+
+	return cfg
+}
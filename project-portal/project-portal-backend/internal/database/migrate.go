@@ -0,0 +1,288 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// embedded holds the SQL files shipped with the binary
+//
+//go:embed migrations/*.sql
+var embedded embed.FS
+
+// migrationsTable is where golang-migrate records the current version
+const migrationsTable = "schema_migrations"
+
+// Files are named NNN_name.up.sql with an optional NNN_name.down.sql
+var (
+	migrationFile = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+	misnamedSQLUp = regexp.MustCompile(`^\d+_.+\.sql$`)
+)
+
+// Migration is one numbered schema change
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string // Empty when the migration cannot be rolled back
+}
+
+// MigrationSource returns the migrations directory at path, or the embedded copy when path is empty
+func MigrationSource(dir string) (fs.FS, error) {
+	if dir != "" {
+		return os.DirFS(dir), nil
+	}
+	return fs.Sub(embedded, "migrations")
+}
+
+// LoadMigrations reads and orders the migrations in fsys. golang-migrate skips
+// files it cannot parse, so a misnamed file is reported here instead of being ignored.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if match == nil {
+			if misnamedSQLUp.MatchString(entry.Name()) {
+				return nil, fmt.Errorf("migration %s must be named NNN_name.up.sql or NNN_name.down.sql", entry.Name())
+			}
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+		body, err := fs.ReadFile(fsys, path.Clean(entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by both %q and %q", version, m.Name, match[2])
+		}
+
+		if match[3] == "down" {
+			m.Down = string(body)
+		} else {
+			m.Up = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies migrations with golang-migrate, which holds a postgres
+// advisory lock while running and records the current version in schema_migrations
+type Migrator struct {
+	db         *sql.DB
+	fsys       fs.FS
+	table      string
+	migrations []Migration
+}
+
+// NewMigrator loads the migrations in fsys for db
+func NewMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, fsys: fsys, table: migrationsTable, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order and returns how many ran
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.run(ctx, func(mg *migrate.Migrate) error {
+		before, _, err := currentVersion(mg)
+		if err != nil {
+			return err
+		}
+		upErr := mg.Up()
+		after, dirty, err := currentVersion(mg)
+		if err != nil {
+			return err
+		}
+		done := m.between(before, after)
+		if dirty && len(done) > 0 {
+			// golang-migrate records the version that failed as dirty
+			done = done[:len(done)-1]
+		}
+		for _, migration := range done {
+			log.Printf("Applied migration %d_%s", migration.Version, migration.Name)
+			applied++
+		}
+		if upErr != nil && !errors.Is(upErr, migrate.ErrNoChange) {
+			return describe(upErr, dirty)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the latest steps applied migrations
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	rolledBack := 0
+	err := m.run(ctx, func(mg *migrate.Migrate) error {
+		before, _, err := currentVersion(mg)
+		if err != nil {
+			return err
+		}
+		stepErr := mg.Steps(-steps)
+		after, dirty, err := currentVersion(mg)
+		if err != nil {
+			return err
+		}
+		reverted := m.between(after, before)
+		if dirty && len(reverted) > 0 {
+			// A failed rollback leaves the version below it recorded as dirty
+			reverted = reverted[1:]
+		}
+		for i := len(reverted) - 1; i >= 0; i-- {
+			log.Printf("Rolled back migration %d_%s", reverted[i].Version, reverted[i].Name)
+			rolledBack++
+		}
+		// Asking for more steps than were applied rolls back what there is
+		var short migrate.ErrShortLimit
+		if stepErr != nil && !errors.Is(stepErr, migrate.ErrNoChange) && !errors.As(stepErr, &short) {
+			return describe(stepErr, dirty)
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Force records version as applied and clears the dirty flag a failed
+// migration leaves behind. Use it once the schema has been repaired by hand.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	return m.run(ctx, func(mg *migrate.Migrate) error {
+		return mg.Force(int(version))
+	})
+}
+
+// Version returns the latest applied version, 0 when none has been, and
+// whether that migration failed part way
+func (m *Migrator) Version(ctx context.Context) (version int64, dirty bool, err error) {
+	err = m.run(ctx, func(mg *migrate.Migrate) error {
+		v, d, err := mg.Version()
+		if errors.Is(err, migrate.ErrNilVersion) {
+			return nil
+		}
+		version, dirty = int64(v), d
+		return err
+	})
+	return version, dirty, err
+}
+
+// Applied lists the migrations up to the current version
+func (m *Migrator) Applied(ctx context.Context) ([]Migration, error) {
+	version, _, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.between(0, version), nil
+}
+
+// Pending lists migrations not yet applied
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	version, _, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// between lists the migrations with from < version <= to
+func (m *Migrator) between(from, to int64) []Migration {
+	var out []Migration
+	for _, migration := range m.migrations {
+		if migration.Version > from && migration.Version <= to {
+			out = append(out, migration)
+		}
+	}
+	return out
+}
+
+// run hands fn a golang-migrate instance on its own connection. Using a
+// connection rather than the pool keeps Close from closing the caller's db.
+func (m *Migrator) run(ctx context.Context, fn func(mg *migrate.Migrate) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{MigrationsTable: m.table})
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to prepare %s: %w", m.table, err)
+	}
+	source, err := iofs.New(m.fsys, ".")
+	if err != nil {
+		driver.Close()
+		return fmt.Errorf("failed to read migrations: %w", err)
+	}
+	mg, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
+	if err != nil {
+		source.Close()
+		driver.Close()
+		return err
+	}
+	defer mg.Close()
+	return fn(mg)
+}
+
+// currentVersion is the applied version, or 0 before the first migration,
+// and whether that migration failed part way
+func currentVersion(mg *migrate.Migrate) (int64, bool, error) {
+	version, dirty, err := mg.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return int64(version), dirty, err
+}
+
+// describe adds the recovery step to an error that left the database dirty
+func describe(err error, dirty bool) error {
+	var dirtyErr migrate.ErrDirty
+	if dirty || errors.As(err, &dirtyErr) {
+		return fmt.Errorf("%w: repair the schema, then run `migrate force <version>` with the last version that applied cleanly", err)
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrationsOrdersAndPairsFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"010_second.up.sql":  {Data: []byte("CREATE TABLE b ();")},
+		"002_first.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+		"002_first.down.sql": {Data: []byte("DROP TABLE a;")},
+		"README.md":          {Data: []byte("ignored")},
+	}
+
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		t.Fatalf("LoadMigrations: %v", err)
+	}
+	if len(migrations) != 2 {
+		t.Fatalf("expected 2 migrations, got %d", len(migrations))
+	}
+	if migrations[0].Version != 2 || migrations[0].Name != "first" || migrations[0].Down != "DROP TABLE a;" {
+		t.Errorf("unexpected first migration: %+v", migrations[0])
+	}
+	if migrations[1].Version != 10 || migrations[1].Down != "" {
+		t.Errorf("unexpected second migration: %+v", migrations[1])
+	}
+}
+
+func TestLoadMigrationsRejectsBadSets(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "duplicate version",
+			fsys: fstest.MapFS{
+				"003_one.up.sql": {Data: []byte("SELECT 1;")},
+				"003_two.up.sql": {Data: []byte("SELECT 2;")},
+			},
+			want: "used by both",
+		},
+		{
+			name: "down without up",
+			fsys: fstest.MapFS{"004_orphan.down.sql": {Data: []byte("SELECT 1;")}},
+			want: "no up file",
+		},
+		{
+			// golang-migrate would skip this file without a word
+			name: "up file without suffix",
+			fsys: fstest.MapFS{"005_x.sql": {Data: []byte("SELECT 1;")}},
+			want: "must be named",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadMigrations(tt.fsys)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	source, err := MigrationSource("")
+	if err != nil {
+		t.Fatalf("MigrationSource: %v", err)
+	}
+	migrations, err := LoadMigrations(source)
+	if err != nil {
+		t.Fatalf("LoadMigrations: %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != 8 {
+		t.Fatalf("expected embedded migrations starting at 008, got %+v", migrations)
+	}
+	for _, m := range migrations {
+		if m.Down == "" {
+			t.Errorf("migration %03d_%s has no down file", m.Version, m.Name)
+		}
+	}
+}
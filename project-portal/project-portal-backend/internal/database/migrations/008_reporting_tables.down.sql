@@ -0,0 +1,8 @@
+-- Migration: 008_reporting_tables (down)
+
+DROP TABLE IF EXISTS dashboard_widgets;
+DROP TABLE IF EXISTS benchmark_datasets;
+DROP TABLE IF EXISTS report_executions;
+DROP TABLE IF EXISTS report_schedules;
+DROP TABLE IF EXISTS report_definitions;
+DROP FUNCTION IF EXISTS update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Migration: 013_health_tables (down)
+
+DROP TABLE IF EXISTS system_status_snapshots;
+DROP TABLE IF EXISTS service_dependencies;
+DROP TABLE IF EXISTS system_alerts;
+DROP TABLE IF EXISTS health_check_results;
+DROP TABLE IF EXISTS service_health_checks;
+DROP TABLE IF EXISTS system_metrics;
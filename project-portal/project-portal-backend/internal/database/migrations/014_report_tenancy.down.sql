@@ -0,0 +1,4 @@
+-- Migration: 014_report_tenancy (down)
+
+DROP INDEX IF EXISTS idx_report_definitions_organization_id;
+ALTER TABLE report_definitions DROP COLUMN IF EXISTS organization_id;
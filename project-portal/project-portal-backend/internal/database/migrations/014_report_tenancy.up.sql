@@ -0,0 +1,7 @@
+-- Migration: 014_report_tenancy
+-- Description: Scope report definitions to an owning organization
+-- Date: 2026-10-16
+
+ALTER TABLE report_definitions ADD COLUMN IF NOT EXISTS organization_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_report_definitions_organization_id ON report_definitions(organization_id);
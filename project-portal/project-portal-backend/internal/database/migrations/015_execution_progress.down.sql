@@ -0,0 +1,4 @@
+-- Migration: 015_execution_progress (down)
+
+ALTER TABLE report_executions DROP COLUMN IF EXISTS progress_percent;
+ALTER TABLE report_executions DROP COLUMN IF EXISTS rows_processed;
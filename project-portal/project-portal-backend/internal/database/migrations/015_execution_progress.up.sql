@@ -0,0 +1,6 @@
+-- Migration: 015_execution_progress
+-- Description: Track row-level progress of long-running report executions
+-- Date: 2026-10-16
+
+ALTER TABLE report_executions ADD COLUMN IF NOT EXISTS rows_processed BIGINT DEFAULT 0;
+ALTER TABLE report_executions ADD COLUMN IF NOT EXISTS progress_percent INTEGER DEFAULT 0;
@@ -0,0 +1,5 @@
+-- Migration: 016_schedule_run_times (down)
+
+DROP INDEX IF EXISTS idx_report_schedules_next_run_at;
+ALTER TABLE report_schedules DROP COLUMN IF EXISTS next_run_at;
+ALTER TABLE report_schedules DROP COLUMN IF EXISTS last_run_at;
@@ -0,0 +1,8 @@
+-- Migration: 016_schedule_run_times
+-- Description: Track last and next run times so the scheduler can find due schedules
+-- Date: 2026-10-16
+
+ALTER TABLE report_schedules ADD COLUMN IF NOT EXISTS last_run_at TIMESTAMPTZ;
+ALTER TABLE report_schedules ADD COLUMN IF NOT EXISTS next_run_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_report_schedules_next_run_at ON report_schedules(next_run_at) WHERE is_active = true;
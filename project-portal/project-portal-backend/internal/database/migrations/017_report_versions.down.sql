@@ -0,0 +1,3 @@
+-- Migration: 017_report_versions (down)
+
+DROP TABLE IF EXISTS report_definition_versions;
@@ -0,0 +1,17 @@
+-- Migration: 017_report_versions
+-- Description: Immutable config snapshots for every report definition version
+-- Date: 2026-10-16
+
+CREATE TABLE IF NOT EXISTS report_definition_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    report_definition_id UUID NOT NULL REFERENCES report_definitions(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    config JSONB NOT NULL,
+    created_by UUID,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (report_definition_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_definition_versions_report ON report_definition_versions(report_definition_id);
@@ -0,0 +1,8 @@
+-- Migration: 018_reporting_sources (down)
+--
+-- The up migration only creates the core domain tables (projects,
+-- carbon_credits, transactions, monitoring_areas, monitoring_data) when
+-- they are missing. They may have existed before it ran and hold
+-- production data, so rolling back leaves them in place.
+
+SELECT 1;
@@ -0,0 +1,67 @@
+-- Migration: 018_reporting_sources
+-- Description: Source tables read by the reporting datasets and dashboard summary
+-- Date: 2026-10-16
+
+CREATE TABLE IF NOT EXISTS projects (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID,
+    name VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'pending', -- 'active', 'pending', 'completed', 'cancelled'
+    methodology VARCHAR(100),
+    region VARCHAR(100),
+    total_area_hectares DECIMAL(12,2),
+    estimated_credits DECIMAL(15,2),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS carbon_credits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    quantity DECIMAL(15,2) NOT NULL,
+    vintage_year INTEGER NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'pending', -- 'issued', 'retired', 'transferred', 'pending'
+    price_per_credit DECIMAL(10,2),
+    issued_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID,
+    credit_id UUID REFERENCES carbon_credits(id) ON DELETE SET NULL,
+    type VARCHAR(50) NOT NULL, -- 'sale', 'purchase', 'retirement', 'transfer'
+    amount DECIMAL(15,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    status VARCHAR(50) NOT NULL DEFAULT 'completed',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS monitoring_areas (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS monitoring_data (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    metric_type VARCHAR(100) NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    unit VARCHAR(50),
+    recorded_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_projects_organization_id ON projects(organization_id);
+CREATE INDEX IF NOT EXISTS idx_carbon_credits_project_id ON carbon_credits(project_id);
+CREATE INDEX IF NOT EXISTS idx_carbon_credits_organization_id ON carbon_credits(organization_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_credit_id ON transactions(credit_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_organization_id ON transactions(organization_id);
+CREATE INDEX IF NOT EXISTS idx_monitoring_areas_project_id ON monitoring_areas(project_id);
+CREATE INDEX IF NOT EXISTS idx_monitoring_data_project_recorded ON monitoring_data(project_id, recorded_at DESC);
+CREATE INDEX IF NOT EXISTS idx_monitoring_data_organization_id ON monitoring_data(organization_id);
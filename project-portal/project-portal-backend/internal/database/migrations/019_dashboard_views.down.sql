@@ -0,0 +1,3 @@
+-- Migration: 019_dashboard_views (down)
+
+DROP TABLE IF EXISTS dashboard_views;
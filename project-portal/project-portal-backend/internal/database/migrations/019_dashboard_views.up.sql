@@ -0,0 +1,26 @@
+-- Migration: 019_dashboard_views
+-- Description: Saved dashboard views (widgets, filters and time range) per user
+-- Date: 2026-10-16
+
+CREATE TABLE IF NOT EXISTS dashboard_views (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    widget_ids UUID[] DEFAULT '{}',
+    filters JSONB,
+    time_range JSONB,
+    is_default BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_dashboard_views_user_id ON dashboard_views(user_id);
+
+-- At most one default view per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dashboard_views_user_default ON dashboard_views(user_id) WHERE is_default;
+
+DROP TRIGGER IF EXISTS update_dashboard_views_updated_at ON dashboard_views;
+CREATE TRIGGER update_dashboard_views_updated_at
+    BEFORE UPDATE ON dashboard_views
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+-- Migration: 020_retention_legal_hold (down)
+
+DROP INDEX IF EXISTS idx_report_executions_purgeable;
+ALTER TABLE report_executions DROP COLUMN IF EXISTS legal_hold;
+ALTER TABLE IF EXISTS webhook_deliveries DROP COLUMN IF EXISTS legal_hold;
@@ -0,0 +1,9 @@
+-- Migration: 020_retention_legal_hold
+-- Description: Legal-hold flag exempting executions and webhook deliveries from retention purges
+-- Date: 2026-10-16
+
+ALTER TABLE report_executions ADD COLUMN IF NOT EXISTS legal_hold BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS idx_report_executions_purgeable ON report_executions(triggered_at) WHERE NOT legal_hold;
+
+-- webhook_deliveries is created by the application on first start
+ALTER TABLE IF EXISTS webhook_deliveries ADD COLUMN IF NOT EXISTS legal_hold BOOLEAN NOT NULL DEFAULT FALSE;
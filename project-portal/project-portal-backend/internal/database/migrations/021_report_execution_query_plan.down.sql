@@ -0,0 +1,3 @@
+-- Migration: 021_report_execution_query_plan (down)
+
+ALTER TABLE report_executions DROP COLUMN IF EXISTS query_plan;
@@ -0,0 +1,5 @@
+-- Migration: 021_report_execution_query_plan
+-- Description: Scrubbed EXPLAIN ANALYZE output captured for a report execution on request
+-- Date: 2026-10-16
+
+ALTER TABLE report_executions ADD COLUMN IF NOT EXISTS query_plan TEXT;
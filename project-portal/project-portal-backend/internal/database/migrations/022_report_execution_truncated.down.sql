@@ -0,0 +1,3 @@
+-- Migration: 022_report_execution_truncated (down)
+
+ALTER TABLE report_executions DROP COLUMN IF EXISTS truncated;
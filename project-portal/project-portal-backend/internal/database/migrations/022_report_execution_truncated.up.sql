@@ -0,0 +1,5 @@
+-- Migration: 022_report_execution_truncated
+-- Description: Flags executions whose output was cut off at the hard row limit
+-- Date: 2026-10-16
+
+ALTER TABLE report_executions ADD COLUMN IF NOT EXISTS truncated BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+-- Migration: 023_user_organization (down)
+
+DROP INDEX IF EXISTS idx_users_organization_id;
+ALTER TABLE users DROP COLUMN IF EXISTS organization_id;
@@ -0,0 +1,7 @@
+-- Migration: 023_user_organization
+-- Description: Organization a user belongs to, issued in their JWT as the report tenant
+-- Date: 2026-10-16
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS organization_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_users_organization_id ON users(organization_id);
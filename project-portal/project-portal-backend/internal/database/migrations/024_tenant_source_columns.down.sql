@@ -0,0 +1,6 @@
+-- Migration: 024_tenant_source_columns (down)
+--
+-- 018 may have created these columns along with their tables, and tenant
+-- scoping depends on them, so rolling back leaves them in place.
+
+SELECT 1;
@@ -0,0 +1,16 @@
+-- Migration: 024_tenant_source_columns
+-- Description: organization_id on the tables the dashboard summary counts, for
+--              databases where they existed before 018 created them with it
+-- Date: 2026-10-16
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS organization_id UUID;
+ALTER TABLE carbon_credits ADD COLUMN IF NOT EXISTS organization_id UUID;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS organization_id UUID;
+ALTER TABLE monitoring_areas ADD COLUMN IF NOT EXISTS organization_id UUID;
+ALTER TABLE monitoring_data ADD COLUMN IF NOT EXISTS organization_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_projects_organization_id ON projects(organization_id);
+CREATE INDEX IF NOT EXISTS idx_carbon_credits_organization_id ON carbon_credits(organization_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_organization_id ON transactions(organization_id);
+CREATE INDEX IF NOT EXISTS idx_monitoring_areas_organization_id ON monitoring_areas(organization_id);
+CREATE INDEX IF NOT EXISTS idx_monitoring_data_organization_id ON monitoring_data(organization_id);
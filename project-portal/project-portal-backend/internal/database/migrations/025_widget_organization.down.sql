@@ -0,0 +1,4 @@
+-- Migration: 025_widget_organization (down)
+
+DROP INDEX IF EXISTS idx_dashboard_widgets_organization_id;
+ALTER TABLE dashboard_widgets DROP COLUMN IF EXISTS organization_id;
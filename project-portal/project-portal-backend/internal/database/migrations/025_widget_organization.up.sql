@@ -0,0 +1,7 @@
+-- Migration: 025_widget_organization
+-- Description: Organization a dashboard widget belongs to, so widget lists stay within the tenant
+-- Date: 2026-10-16
+
+ALTER TABLE dashboard_widgets ADD COLUMN IF NOT EXISTS organization_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_dashboard_widgets_organization_id ON dashboard_widgets(organization_id);
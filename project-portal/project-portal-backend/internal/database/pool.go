@@ -0,0 +1,17 @@
+// Package database holds the SQL migrations and shared connection setup.
+package database
+
+import (
+	"database/sql"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/config"
+)
+
+// ConfigurePool applies the configured pool limits to db.
+// Every handle the service opens should go through here so the limits stay consistent.
+func ConfigurePool(db *sql.DB, cfg config.DatabaseConfig) {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
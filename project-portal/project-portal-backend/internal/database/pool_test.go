@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/config"
+)
+
+// stubDriver hands out connections that never touch a real database
+type stubDriver struct{}
+
+func (stubDriver) Open(name string) (driver.Conn, error) { return stubConn{}, nil }
+
+type stubConn struct{}
+
+func (stubConn) Prepare(query string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (stubConn) Close() error                              { return nil }
+func (stubConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+func init() {
+	sql.Register("pool-stub", stubDriver{})
+}
+
+func TestConfigurePool_AppliesLimits(t *testing.T) {
+	db, err := sql.Open("pool-stub", "")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+
+	ConfigurePool(db, config.DatabaseConfig{
+		MaxOpenConns:    3,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: time.Minute,
+	})
+
+	if got := db.Stats().MaxOpenConnections; got != 3 {
+		t.Fatalf("MaxOpenConnections = %d, want 3", got)
+	}
+
+	ctx := context.Background()
+	var conns []*sql.Conn
+	for i := 0; i < 3; i++ {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatalf("conn %d: %v", i, err)
+		}
+		conns = append(conns, conn)
+	}
+
+	// A fourth caller must wait for a free connection
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := db.Conn(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected pool exhaustion to block, got %v", err)
+	}
+	if stats := db.Stats(); stats.InUse != 3 || stats.WaitCount != 1 {
+		t.Errorf("expected 3 in use and 1 wait, got %+v", stats)
+	}
+
+	for _, conn := range conns {
+		_ = conn.Close()
+	}
+
+	// Only MaxIdleConns connections are kept once released
+	stats := db.Stats()
+	if stats.Idle != 2 || stats.MaxIdleClosed != 1 {
+		t.Errorf("expected 2 idle and 1 closed over the idle limit, got %+v", stats)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"net/http"
 	"time"
 
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -24,6 +27,7 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 		// Metrics
 		health.POST("/metrics", h.CreateSystemMetric)
 		health.GET("/metrics", h.GetSystemMetrics)
+		health.GET("/metrics/database", h.GetDatabasePoolStats)
 
 		// Status
 		health.GET("/status", h.GetSystemStatus)
@@ -66,13 +70,13 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 func (h *Handler) CreateSystemMetric(c *gin.Context) {
 	var req CreateSystemMetricRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	metric, err := h.service.CreateSystemMetric(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -97,13 +101,13 @@ func (h *Handler) CreateSystemMetric(c *gin.Context) {
 func (h *Handler) GetSystemMetrics(c *gin.Context) {
 	var query MetricQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	metrics, err := h.service.GetSystemMetrics(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -120,7 +124,7 @@ func (h *Handler) GetSystemMetrics(c *gin.Context) {
 func (h *Handler) GetSystemStatus(c *gin.Context) {
 	status, err := h.service.GetStatus(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -137,7 +141,7 @@ func (h *Handler) GetSystemStatus(c *gin.Context) {
 func (h *Handler) GetDetailedStatus(c *gin.Context) {
 	status, err := h.service.GetDetailedStatus(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -154,7 +158,7 @@ func (h *Handler) GetDetailedStatus(c *gin.Context) {
 func (h *Handler) GetServicesHealth(c *gin.Context) {
 	services, err := h.service.GetServicesHealth(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -177,13 +181,13 @@ func (h *Handler) GetServicesHealth(c *gin.Context) {
 func (h *Handler) CreateServiceHealthCheck(c *gin.Context) {
 	var req CreateServiceHealthCheckRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	check, err := h.service.CreateServiceHealthCheck(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -211,13 +215,13 @@ func (h *Handler) CreateServiceHealthCheck(c *gin.Context) {
 func (h *Handler) GetSystemAlerts(c *gin.Context) {
 	var query AlertQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	alerts, err := h.service.GetSystemAlerts(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -240,13 +244,13 @@ func (h *Handler) AcknowledgeAlert(c *gin.Context) {
 	id := c.Param("id")
 	var req AcknowledgeAlertRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	alert, err := h.service.AcknowledgeAlert(c.Request.Context(), id, req.AcknowledgedBy)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -266,12 +270,12 @@ func (h *Handler) AcknowledgeAlert(c *gin.Context) {
 func (h *Handler) GetDailyReport(c *gin.Context) {
 	report, err := h.service.GetDailyReport(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	if report == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "daily report not found"})
+		c.Error(apperrors.NotFound("daily report not found"))
 		return
 	}
 
@@ -290,13 +294,32 @@ func (h *Handler) GetDailyReport(c *gin.Context) {
 func (h *Handler) GetDependencies(c *gin.Context) {
 	dependencies, err := h.service.GetDependencies(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, dependencies)
 }
 
+// ========== Database pool ==========
+
+// GetDatabasePoolStats returns connection pool usage
+// @Summary Get database pool statistics
+// @Description Get open, in-use and idle connections plus wait counts for the database pool
+// @Tags health
+// @Produce json
+// @Success 200 {object} DatabasePoolStats
+// @Router /api/v1/health/metrics/database [get]
+func (h *Handler) GetDatabasePoolStats(c *gin.Context) {
+	stats, err := h.service.GetDatabasePoolStats(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
 // ========== Uptime ==========
 
 // GetUptimeStats returns uptime statistics for monitored services
@@ -309,7 +332,7 @@ func (h *Handler) GetDependencies(c *gin.Context) {
 func (h *Handler) GetUptimeStats(c *gin.Context) {
 	stats, err := h.service.GetUptimeStats(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"carbon-scribe/project-portal/project-portal-backend/internal/health"
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -23,6 +24,7 @@ import (
 func setupTestRouter(t *testing.T) (*gin.Engine, *gorm.DB) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
 
 	dbURL := os.Getenv("DATABASE_URL")
 	if dbURL == "" {
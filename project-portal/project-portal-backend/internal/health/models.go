@@ -1,6 +1,7 @@
 package health
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/lib/pq"
@@ -298,6 +299,37 @@ type ServiceUptime struct {
 	Uptime30d   float64 `json:"uptime_30d"`
 }
 
+// DatabasePoolStats reports connection pool usage for spotting exhaustion
+type DatabasePoolStats struct {
+	MaxOpenConnections int       `json:"max_open_connections"`
+	OpenConnections    int       `json:"open_connections"`
+	InUse              int       `json:"in_use"`
+	Idle               int       `json:"idle"`
+	WaitCount          int64     `json:"wait_count"`           // Total requests that waited for a connection
+	WaitDurationMs     int64     `json:"wait_duration_ms"`     // Total time spent waiting
+	MaxIdleClosed      int64     `json:"max_idle_closed"`      // Closed because the idle pool was full
+	MaxIdleTimeClosed  int64     `json:"max_idle_time_closed"` // Closed by ConnMaxIdleTime
+	MaxLifetimeClosed  int64     `json:"max_lifetime_closed"`  // Closed by ConnMaxLifetime
+	Saturated          bool      `json:"saturated"`            // Every allowed connection is in use
+	Timestamp          time.Time `json:"timestamp"`
+}
+
+func newDatabasePoolStats(stats sql.DBStats) DatabasePoolStats {
+	return DatabasePoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		Saturated:          stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections,
+		Timestamp:          time.Now(),
+	}
+}
+
 // UptimeResponse represents the response for the uptime statistics endpoint
 type UptimeResponse struct {
 	Services  []ServiceUptime `json:"services"`
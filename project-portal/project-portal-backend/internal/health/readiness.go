@@ -0,0 +1,132 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultReadinessTimeout bounds each dependency check so /readyz answers quickly during an outage
+const DefaultReadinessTimeout = 2 * time.Second
+
+// Readiness statuses
+const (
+	DependencyUp   = "up"
+	DependencyDown = "down"
+)
+
+// Dependency is something the service needs to reach before it can take traffic
+type Dependency struct {
+	Name     string
+	Check    func(ctx context.Context) error
+	Optional bool // Reported, but a failure does not make the service unready
+}
+
+// DependencyStatus is the outcome of one readiness check
+type DependencyStatus struct {
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Optional  bool   `json:"optional,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ReadinessResponse is the body of /readyz
+type ReadinessResponse struct {
+	Status       string                      `json:"status"` // "ready" or "not_ready"
+	Timestamp    time.Time                   `json:"timestamp"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+// Probes serves the liveness and readiness endpoints used by orchestrators
+type Probes struct {
+	dependencies []Dependency
+	timeout      time.Duration
+}
+
+// NewProbes creates probes that check dependencies with the given per-check timeout
+func NewProbes(timeout time.Duration, dependencies ...Dependency) *Probes {
+	if timeout <= 0 {
+		timeout = DefaultReadinessTimeout
+	}
+	return &Probes{dependencies: dependencies, timeout: timeout}
+}
+
+// RegisterRoutes registers /livez and /readyz at the root of the router
+func (p *Probes) RegisterRoutes(router gin.IRoutes) {
+	router.GET("/livez", p.Livez)
+	router.GET("/readyz", p.Readyz)
+}
+
+// Livez reports that the process is up; it never touches dependencies
+func (p *Probes) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "alive", "timestamp": time.Now().UTC()})
+}
+
+// Readyz reports whether every required dependency is reachable
+func (p *Probes) Readyz(c *gin.Context) {
+	response := p.Check(c.Request.Context())
+	status := http.StatusOK
+	if response.Status != "ready" {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, response)
+}
+
+// Check runs every dependency check concurrently. A check that ignores its
+// context is abandoned at the timeout rather than holding up the response.
+func (p *Probes) Check(ctx context.Context) ReadinessResponse {
+	response := ReadinessResponse{
+		Status:       "ready",
+		Timestamp:    time.Now().UTC(),
+		Dependencies: make(map[string]DependencyStatus, len(p.dependencies)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, dep := range p.dependencies {
+		wg.Add(1)
+		go func(dep Dependency) {
+			defer wg.Done()
+			result := p.checkOne(ctx, dep)
+
+			mu.Lock()
+			defer mu.Unlock()
+			response.Dependencies[dep.Name] = result
+			if result.Status != DependencyUp && !dep.Optional {
+				response.Status = "not_ready"
+			}
+		}(dep)
+	}
+	wg.Wait()
+	return response
+}
+
+func (p *Probes) checkOne(ctx context.Context, dep Dependency) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1) // Buffered so an abandoned check can still finish
+	go func() { done <- dep.Check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	status := DependencyStatus{
+		Status:    DependencyUp,
+		LatencyMS: time.Since(start).Milliseconds(),
+		Optional:  dep.Optional,
+	}
+	if err != nil {
+		status.Status = DependencyDown
+		status.Error = err.Error()
+	}
+	return status
+}
@@ -0,0 +1,116 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func up(ctx context.Context) error { return nil }
+
+func probeRouter(p *Probes) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	p.RegisterRoutes(router)
+	return router
+}
+
+func getReadiness(t *testing.T, router *gin.Engine) (int, ReadinessResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var body ReadinessResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid readiness body %q: %v", w.Body.String(), err)
+	}
+	return w.Code, body
+}
+
+func TestReadyzAllDependenciesUp(t *testing.T) {
+	router := probeRouter(NewProbes(time.Second,
+		Dependency{Name: "database", Check: up},
+		Dependency{Name: "elasticsearch", Check: up, Optional: true},
+	))
+
+	code, body := getReadiness(t, router)
+	if code != http.StatusOK || body.Status != "ready" {
+		t.Fatalf("expected ready, got %d %+v", code, body)
+	}
+	if body.Dependencies["database"].Status != DependencyUp || body.Dependencies["elasticsearch"].Status != DependencyUp {
+		t.Errorf("expected every dependency up, got %+v", body.Dependencies)
+	}
+}
+
+func TestReadyzRequiredDependencyDown(t *testing.T) {
+	router := probeRouter(NewProbes(time.Second,
+		Dependency{Name: "database", Check: func(ctx context.Context) error { return errors.New("connection refused") }},
+	))
+
+	code, body := getReadiness(t, router)
+	if code != http.StatusServiceUnavailable || body.Status != "not_ready" {
+		t.Fatalf("expected not_ready, got %d %+v", code, body)
+	}
+	if dep := body.Dependencies["database"]; dep.Status != DependencyDown || dep.Error != "connection refused" {
+		t.Errorf("unexpected database status: %+v", dep)
+	}
+}
+
+func TestReadyzOptionalDependencyDownStaysReady(t *testing.T) {
+	router := probeRouter(NewProbes(time.Second,
+		Dependency{Name: "database", Check: up},
+		Dependency{Name: "elasticsearch", Optional: true, Check: func(ctx context.Context) error { return errors.New("no nodes") }},
+	))
+
+	code, body := getReadiness(t, router)
+	if code != http.StatusOK || body.Status != "ready" {
+		t.Fatalf("expected ready, got %d %+v", code, body)
+	}
+	if dep := body.Dependencies["elasticsearch"]; dep.Status != DependencyDown || !dep.Optional {
+		t.Errorf("expected optional elasticsearch reported down, got %+v", dep)
+	}
+}
+
+func TestReadyzTimesOutHungDependency(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	router := probeRouter(NewProbes(50*time.Millisecond,
+		// Ignores its context, like a driver stuck on a dead TCP connection
+		Dependency{Name: "database", Check: func(ctx context.Context) error { <-release; return nil }},
+	))
+
+	start := time.Now()
+	code, body := getReadiness(t, router)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("readiness blocked for %s", elapsed)
+	}
+	if code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", code)
+	}
+	if dep := body.Dependencies["database"]; dep.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("expected deadline error, got %+v", dep)
+	}
+}
+
+func TestLivezIgnoresDependencies(t *testing.T) {
+	called := false
+	router := probeRouter(NewProbes(time.Second,
+		Dependency{Name: "database", Check: func(ctx context.Context) error { called = true; return errors.New("down") }},
+	))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if called {
+		t.Error("liveness must not run dependency checks")
+	}
+}
@@ -2,6 +2,7 @@ package health
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,6 +16,7 @@ type Repository interface {
 
 	// Status
 	PingDB(ctx context.Context) error
+	PoolStats(ctx context.Context) (sql.DBStats, error)
 
 	// Service
 	ListServiceHealthChecks(ctx context.Context) ([]ServiceHealthCheck, error)
@@ -94,6 +96,14 @@ func (r *repository) PingDB(ctx context.Context) error {
 	return sqlDB.PingContext(ctx)
 }
 
+func (r *repository) PoolStats(ctx context.Context) (sql.DBStats, error) {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return sql.DBStats{}, err
+	}
+	return sqlDB.Stats(), nil
+}
+
 func (r *repository) ListServiceHealthChecks(ctx context.Context) ([]ServiceHealthCheck, error) {
 	var checks []ServiceHealthCheck
 	err := r.db.Find(&checks).Error
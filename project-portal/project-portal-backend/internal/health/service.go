@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
 	"gorm.io/datatypes"
 )
 
@@ -14,6 +16,7 @@ type Service interface {
 	// Metrics
 	CreateSystemMetric(ctx context.Context, req CreateSystemMetricRequest) (*SystemMetric, error)
 	GetSystemMetrics(ctx context.Context, query MetricQuery) ([]SystemMetric, error)
+	GetDatabasePoolStats(ctx context.Context) (DatabasePoolStats, error)
 
 	// Status
 	GetStatus(ctx context.Context) (SystemStatusResponse, error)
@@ -93,6 +96,14 @@ func (s *service) GetSystemMetrics(ctx context.Context, query MetricQuery) ([]Sy
 	return s.repo.QuerySystemMetrics(ctx, query)
 }
 
+func (s *service) GetDatabasePoolStats(ctx context.Context) (DatabasePoolStats, error) {
+	stats, err := s.repo.PoolStats(ctx)
+	if err != nil {
+		return DatabasePoolStats{}, fmt.Errorf("failed to read pool stats: %w", err)
+	}
+	return newDatabasePoolStats(stats), nil
+}
+
 func (s *service) GetStatus(ctx context.Context) (SystemStatusResponse, error) {
 	status := "healthy"
 	if err := s.repo.PingDB(ctx); err != nil {
@@ -220,7 +231,7 @@ func (s *service) GetSystemAlerts(ctx context.Context, query AlertQuery) ([]Syst
 func (s *service) AcknowledgeAlert(ctx context.Context, id string, userID string) (*SystemAlert, error) {
 	alert, err := s.repo.GetSystemAlertByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find alert: %w", err)
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "alert not found", err)
 	}
 
 	if alert.Status == "acknowledged" {
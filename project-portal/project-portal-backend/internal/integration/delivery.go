@@ -0,0 +1,256 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
+	"github.com/google/uuid"
+)
+
+// Delivery statuses
+const (
+	DeliveryPending      = "pending"
+	DeliveryRetrying     = "retrying"
+	DeliverySuccess      = "success"
+	DeliveryFailed       = "failed"
+	DeliveryDeadLettered = "dead_lettered"
+)
+
+// DeliveryWorkerInterval is how often the background worker looks for due deliveries
+const DeliveryWorkerInterval = 15 * time.Second
+
+const (
+	defaultMaxAttempts    = 5
+	baseRetryDelay        = 30 * time.Second
+	maxRetryDelay         = time.Hour
+	deliveryBatchSize     = 100
+	signatureHeader       = "X-CarbonScribe-Signature"
+	maxDeadLettersPerHook = 1000
+	deadLetterRetention   = 30 * 24 * time.Hour
+)
+
+// ErrEndpointUnhealthy is returned when a replay is requested while the endpoint is still failing
+var ErrEndpointUnhealthy = apperrors.Conflict("webhook endpoint is not healthy")
+
+// ProcessDueDeliveries sends every pending or retrying delivery whose retry time has passed,
+// oldest first so each webhook receives events in the order they were produced. A delivery
+// waiting to be retried holds back later deliveries to its webhook until it succeeds or is
+// dead-lettered, and retries keep their delivery ID and sequence number.
+func (s *Service) ProcessDueDeliveries(ctx context.Context, now time.Time) error {
+	deliveries, err := s.repo.ListDueDeliveries(ctx, now, deliveryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	webhooks := make(map[string]*WebhookConfig)
+	blocked := make(map[string]bool)
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		// Once a webhook fails in this batch, hold its later deliveries to keep ordering
+		if blocked[delivery.WebhookID] {
+			continue
+		}
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = s.repo.GetWebhookConfig(ctx, delivery.WebhookID)
+			if err != nil {
+				delivery.Status = DeliveryFailed
+				delivery.ResponseBody = "webhook configuration not found"
+				delivery.NextRetryAt = nil
+				_ = s.repo.UpdateWebhookDelivery(ctx, delivery)
+				continue
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		if !s.attemptDelivery(ctx, webhook, delivery, now) {
+			blocked[delivery.WebhookID] = true
+		}
+	}
+	return nil
+}
+
+// StartDeliveryWorker processes due deliveries every interval until ctx is cancelled
+func (s *Service) StartDeliveryWorker(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if err := s.ProcessDueDeliveries(ctx, now); err != nil {
+					log.Printf("webhook delivery worker: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// attemptDelivery posts the payload once and records the outcome; it reports whether it succeeded
+func (s *Service) attemptDelivery(ctx context.Context, webhook *WebhookConfig, delivery *WebhookDelivery, now time.Time) bool {
+	delivery.Attempt++
+
+	status, body, err := s.postWebhook(ctx, webhook, delivery)
+	delivery.ResponseStatus = status
+	delivery.ResponseBody = body
+	if err == nil && status >= 200 && status < 300 {
+		delivery.Status = DeliverySuccess
+		delivery.NextRetryAt = nil
+		_ = s.repo.UpdateWebhookDelivery(ctx, delivery)
+		return true
+	}
+	if err != nil {
+		delivery.ResponseBody = err.Error()
+	}
+
+	if delivery.Attempt >= maxAttempts(webhook) {
+		s.deadLetter(ctx, webhook, delivery, now)
+		return false
+	}
+
+	next := now.Add(retryDelay(delivery.Attempt))
+	delivery.Status = DeliveryRetrying
+	delivery.NextRetryAt = &next
+	_ = s.repo.UpdateWebhookDelivery(ctx, delivery)
+	return false
+}
+
+// deadLetter moves a permanently failed delivery into the dead-letter store
+func (s *Service) deadLetter(ctx context.Context, webhook *WebhookConfig, delivery *WebhookDelivery, now time.Time) {
+	delivery.Status = DeliveryDeadLettered
+	delivery.NextRetryAt = nil
+	_ = s.repo.UpdateWebhookDelivery(ctx, delivery)
+
+	if err := s.repo.CreateDeadLetter(ctx, &WebhookDeadLetter{
+		ID:                uuid.New().String(),
+		WebhookID:         webhook.ID,
+		DeliveryID:        delivery.ID,
+		Sequence:          delivery.Sequence,
+		EventID:           delivery.EventID,
+		EventType:         delivery.EventType,
+		Payload:           delivery.Payload,
+		Attempts:          delivery.Attempt,
+		LastStatus:        delivery.ResponseStatus,
+		LastError:         delivery.ResponseBody,
+		OriginalCreatedAt: delivery.CreatedAt,
+		DeadLetteredAt:    now,
+	}); err != nil {
+		log.Printf("failed to dead-letter delivery %s: %v", delivery.ID, err)
+		return
+	}
+
+	// Retention cap: keep the newest entries and nothing older than the retention window
+	if err := s.repo.PruneDeadLetters(ctx, webhook.ID, maxDeadLettersPerHook, now.Add(-deadLetterRetention)); err != nil {
+		log.Printf("failed to prune dead letters for webhook %s: %v", webhook.ID, err)
+	}
+}
+
+// ListDeadLetters returns a webhook's dead-lettered deliveries in original order
+func (s *Service) ListDeadLetters(ctx context.Context, webhookID string) ([]WebhookDeadLetter, error) {
+	return s.repo.ListDeadLetters(ctx, webhookID)
+}
+
+// ReplayDeadLetters re-enqueues a webhook's dead letters in their original order once the
+// endpoint answers a health probe again. Replays keep the delivery ID and sequence number of
+// the original attempt, so receivers can dedupe them. It returns the number re-enqueued.
+func (s *Service) ReplayDeadLetters(ctx context.Context, webhookID string) (int, error) {
+	webhook, err := s.repo.GetWebhookConfig(ctx, webhookID)
+	if err != nil {
+		return 0, err
+	}
+
+	probe := &ConnectionTestResult{}
+	s.probeHead(ctx, webhook.URL, probe)
+	if !probe.Success {
+		return 0, fmt.Errorf("%w: %s", ErrEndpointUnhealthy, probe.Message)
+	}
+
+	letters, err := s.repo.ListDeadLetters(ctx, webhookID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.repo.ReplayDeadLetters(ctx, letters); err != nil {
+		return 0, err
+	}
+	return len(letters), nil
+}
+
+// PurgeDeliveries deletes settled deliveries created before cutoff, except those on
+// legal hold. A dry run only counts them.
+func (s *Service) PurgeDeliveries(ctx context.Context, before time.Time, dryRun bool) (int64, error) {
+	if dryRun {
+		return s.repo.CountPurgeableDeliveries(ctx, before)
+	}
+	return s.repo.PurgeDeliveries(ctx, before)
+}
+
+// postWebhook sends the payload signed with the webhook secret
+func (s *Service) postWebhook(ctx context.Context, webhook *WebhookConfig, delivery *WebhookDelivery) (int, string, error) {
+	body, err := json.Marshal(delivery.Payload)
+	if err != nil {
+		return 0, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range webhook.Headers {
+		req.Header.Set(k, v)
+	}
+	mac := hmac.New(sha256.New, []byte(webhook.Secret))
+	mac.Write(body)
+	req.Header.Set(signatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("X-CarbonScribe-Event", delivery.EventType)
+	req.Header.Set("X-CarbonScribe-Delivery", delivery.ID)
+	req.Header.Set("X-CarbonScribe-Sequence", strconv.FormatInt(delivery.Sequence, 10))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4*1024))
+	return resp.StatusCode, string(respBody), nil
+}
+
+func maxAttempts(webhook *WebhookConfig) int {
+	if v, ok := webhook.RetryConfig["max_attempts"].(float64); ok && v >= 1 {
+		return int(v)
+	}
+	if v, ok := webhook.RetryConfig["max_attempts"].(int); ok && v >= 1 {
+		return v
+	}
+	return defaultMaxAttempts
+}
+
+// retryDelay backs off exponentially from baseRetryDelay up to maxRetryDelay
+func retryDelay(attempt int) time.Duration {
+	delay := baseRetryDelay
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,373 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyEndpoint records every POSTed event_id and fails while down is set
+type flakyEndpoint struct {
+	down     atomic.Bool
+	mu       sync.Mutex
+	received []string
+}
+
+func (e *flakyEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if e.down.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method == http.MethodPost {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		e.mu.Lock()
+		e.received = append(e.received, body["event_id"].(string))
+		e.mu.Unlock()
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func seedDeliveries(t *testing.T, repo *fakeRepository, webhookID string, base time.Time, eventIDs ...string) {
+	t.Helper()
+	for i, id := range eventIDs {
+		if err := repo.CreateWebhookDelivery(context.Background(), &WebhookDelivery{
+			ID:        "delivery-" + id,
+			WebhookID: webhookID,
+			EventID:   id,
+			EventType: "project.created",
+			Payload:   map[string]any{"event_id": id},
+			Status:    DeliveryPending,
+			CreatedAt: base.Add(time.Duration(i) * time.Second),
+		}); err != nil {
+			t.Fatalf("seed delivery: %v", err)
+		}
+	}
+}
+
+func TestProcessDueDeliveries_DeadLettersAfterMaxAttempts(t *testing.T) {
+	endpoint := &flakyEndpoint{}
+	endpoint.down.Store(true)
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	repo := newFakeRepository()
+	_ = repo.CreateWebhookConfig(context.Background(), &WebhookConfig{
+		ID: "hook-1", URL: server.URL, Secret: "s3cret",
+		RetryConfig: map[string]any{"max_attempts": float64(3)},
+	})
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	seedDeliveries(t, repo, "hook-1", now, "evt-1")
+
+	svc := NewService(repo)
+	for i := 0; i < 3; i++ {
+		if err := svc.ProcessDueDeliveries(context.Background(), now); err != nil {
+			t.Fatalf("ProcessDueDeliveries: %v", err)
+		}
+		// Jump past the backoff window so the next attempt is due
+		now = now.Add(maxRetryDelay)
+	}
+
+	delivery := repo.deliveries[0]
+	if delivery.Status != DeliveryDeadLettered {
+		t.Fatalf("expected delivery to be dead-lettered, got %q", delivery.Status)
+	}
+	if delivery.Attempt != 3 {
+		t.Errorf("expected 3 attempts, got %d", delivery.Attempt)
+	}
+
+	letters, _ := repo.ListDeadLetters(context.Background(), "hook-1")
+	if len(letters) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(letters))
+	}
+	if letters[0].EventID != "evt-1" || letters[0].LastStatus != http.StatusServiceUnavailable {
+		t.Errorf("unexpected dead letter: %+v", letters[0])
+	}
+}
+
+func TestProcessDueDeliveries_BacksOffBetweenAttempts(t *testing.T) {
+	endpoint := &flakyEndpoint{}
+	endpoint.down.Store(true)
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	repo := newFakeRepository()
+	_ = repo.CreateWebhookConfig(context.Background(), &WebhookConfig{ID: "hook-1", URL: server.URL})
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	seedDeliveries(t, repo, "hook-1", now, "evt-1")
+
+	svc := NewService(repo)
+	_ = svc.ProcessDueDeliveries(context.Background(), now)
+	_ = svc.ProcessDueDeliveries(context.Background(), now.Add(time.Second))
+
+	delivery := repo.deliveries[0]
+	if delivery.Attempt != 1 || delivery.Status != DeliveryRetrying {
+		t.Fatalf("expected a single attempt before the retry is due, got %d (%s)", delivery.Attempt, delivery.Status)
+	}
+	if delivery.NextRetryAt == nil || !delivery.NextRetryAt.Equal(now.Add(baseRetryDelay)) {
+		t.Errorf("expected next retry at %v, got %v", now.Add(baseRetryDelay), delivery.NextRetryAt)
+	}
+}
+
+func TestProcessDueDeliveries_HoldsLaterDeliveriesBehindRetry(t *testing.T) {
+	endpoint := &flakyEndpoint{}
+	endpoint.down.Store(true)
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	repo := newFakeRepository()
+	_ = repo.CreateWebhookConfig(context.Background(), &WebhookConfig{ID: "hook-1", URL: server.URL})
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	seedDeliveries(t, repo, "hook-1", now, "evt-1", "evt-2", "evt-3")
+
+	for i, d := range repo.deliveries {
+		if d.Sequence != int64(i+1) {
+			t.Fatalf("expected sequence %d for %s, got %d", i+1, d.EventID, d.Sequence)
+		}
+	}
+
+	svc := NewService(repo)
+	_ = svc.ProcessDueDeliveries(context.Background(), now.Add(3*time.Second))
+
+	// The endpoint recovers, but evt-1 is not due again yet; evt-2 and evt-3 must wait for it
+	endpoint.down.Store(false)
+	_ = svc.ProcessDueDeliveries(context.Background(), now.Add(10*time.Second))
+	if len(endpoint.received) != 0 {
+		t.Fatalf("expected later deliveries held behind the retry, got %v", endpoint.received)
+	}
+
+	_ = svc.ProcessDueDeliveries(context.Background(), now.Add(3*time.Second+baseRetryDelay))
+	want := []string{"evt-1", "evt-2", "evt-3"}
+	if len(endpoint.received) != len(want) {
+		t.Fatalf("expected %v delivered, got %v", want, endpoint.received)
+	}
+	for i := range want {
+		if endpoint.received[i] != want[i] {
+			t.Fatalf("expected delivery order %v, got %v", want, endpoint.received)
+		}
+	}
+	if retried := repo.deliveries[0]; retried.Attempt != 2 || retried.ID != "delivery-evt-1" {
+		t.Errorf("expected the retry to reuse the delivery, got attempt %d of %s", retried.Attempt, retried.ID)
+	}
+}
+
+func TestProcessDueDeliveries_DeadLetterReleasesLaterDeliveries(t *testing.T) {
+	endpoint := &flakyEndpoint{}
+	endpoint.down.Store(true)
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	repo := newFakeRepository()
+	_ = repo.CreateWebhookConfig(context.Background(), &WebhookConfig{
+		ID: "hook-1", URL: server.URL,
+		RetryConfig: map[string]any{"max_attempts": float64(2)},
+	})
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	seedDeliveries(t, repo, "hook-1", now, "evt-1", "evt-2")
+
+	svc := NewService(repo)
+	_ = svc.ProcessDueDeliveries(context.Background(), now.Add(2*time.Second))
+	_ = svc.ProcessDueDeliveries(context.Background(), now.Add(2*time.Second+baseRetryDelay))
+	if repo.deliveries[0].Status != DeliveryDeadLettered {
+		t.Fatalf("expected evt-1 dead-lettered, got %s", repo.deliveries[0].Status)
+	}
+
+	endpoint.down.Store(false)
+	_ = svc.ProcessDueDeliveries(context.Background(), now.Add(3*time.Second+baseRetryDelay))
+	if len(endpoint.received) != 1 || endpoint.received[0] != "evt-2" {
+		t.Fatalf("expected evt-2 delivered once evt-1 was dead-lettered, got %v", endpoint.received)
+	}
+}
+
+func TestPostWebhook_SendsDeliveryIDAndSequence(t *testing.T) {
+	headers := make(chan http.Header, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+	}))
+	defer server.Close()
+
+	svc := NewService(newFakeRepository())
+	_, _, err := svc.postWebhook(context.Background(), &WebhookConfig{URL: server.URL},
+		&WebhookDelivery{ID: "delivery-1", Sequence: 42, EventType: "project.created"})
+	if err != nil {
+		t.Fatalf("postWebhook: %v", err)
+	}
+
+	got := <-headers
+	if got.Get("X-CarbonScribe-Delivery") != "delivery-1" || got.Get("X-CarbonScribe-Sequence") != "42" {
+		t.Errorf("expected delivery ID and sequence headers, got %v", got)
+	}
+}
+
+func TestReplayDeadLetters_PreservesOriginalOrder(t *testing.T) {
+	endpoint := &flakyEndpoint{}
+	endpoint.down.Store(true)
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	repo := newFakeRepository()
+	_ = repo.CreateWebhookConfig(context.Background(), &WebhookConfig{
+		ID: "hook-1", URL: server.URL,
+		RetryConfig: map[string]any{"max_attempts": float64(1)},
+	})
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	seedDeliveries(t, repo, "hook-1", now, "evt-1", "evt-2", "evt-3")
+
+	svc := NewService(repo)
+	// Each pass only attempts the oldest delivery, since later ones wait behind a failure
+	for i := 0; i < 3; i++ {
+		_ = svc.ProcessDueDeliveries(context.Background(), now)
+	}
+	if got := len(repo.deadLetters); got != 3 {
+		t.Fatalf("expected 3 dead letters, got %d", got)
+	}
+
+	if _, err := svc.ReplayDeadLetters(context.Background(), "hook-1"); !errors.Is(err, ErrEndpointUnhealthy) {
+		t.Fatalf("expected replay to be refused while endpoint is down, got %v", err)
+	}
+
+	endpoint.down.Store(false)
+	replayed, err := svc.ReplayDeadLetters(context.Background(), "hook-1")
+	if err != nil {
+		t.Fatalf("ReplayDeadLetters: %v", err)
+	}
+	if replayed != 3 {
+		t.Errorf("expected 3 replayed deliveries, got %d", replayed)
+	}
+	if len(repo.deadLetters) != 0 {
+		t.Errorf("expected dead letters to be cleared, got %d", len(repo.deadLetters))
+	}
+	// The original deliveries are requeued rather than copied, so receivers can dedupe them
+	if len(repo.deliveries) != 3 {
+		t.Fatalf("expected the 3 original deliveries to be requeued, got %d", len(repo.deliveries))
+	}
+	for i, d := range repo.deliveries {
+		if d.ID != "delivery-evt-"+strconv.Itoa(i+1) || d.Sequence != int64(i+1) || d.Status != DeliveryPending || d.Attempt != 0 {
+			t.Errorf("expected delivery-evt-%d with sequence %d pending, got %+v", i+1, i+1, d)
+		}
+	}
+
+	if err := svc.ProcessDueDeliveries(context.Background(), time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("ProcessDueDeliveries: %v", err)
+	}
+	want := []string{"evt-1", "evt-2", "evt-3"}
+	if len(endpoint.received) != len(want) {
+		t.Fatalf("expected %v delivered, got %v", want, endpoint.received)
+	}
+	for i := range want {
+		if endpoint.received[i] != want[i] {
+			t.Fatalf("expected delivery order %v, got %v", want, endpoint.received)
+		}
+	}
+}
+
+func TestReplayDeadLetters_FailureKeepsDeadLetters(t *testing.T) {
+	server := httptest.NewServer(&flakyEndpoint{})
+	defer server.Close()
+
+	repo := newFakeRepository()
+	_ = repo.CreateWebhookConfig(context.Background(), &WebhookConfig{ID: "hook-1", URL: server.URL})
+	for _, id := range []string{"evt-1", "evt-2"} {
+		_ = repo.CreateDeadLetter(context.Background(), &WebhookDeadLetter{
+			ID: "letter-" + id, WebhookID: "hook-1", DeliveryID: "delivery-" + id, EventID: id,
+		})
+	}
+	repo.replayErr = errors.New("insert failed")
+
+	replayed, err := NewService(repo).ReplayDeadLetters(context.Background(), "hook-1")
+	if err == nil || replayed != 0 {
+		t.Fatalf("expected the replay to fail with nothing replayed, got %d, %v", replayed, err)
+	}
+	// Nothing was queued, so replaying again cannot send duplicates
+	if len(repo.deliveries) != 0 || len(repo.deadLetters) != 2 {
+		t.Errorf("expected no deliveries and both dead letters kept, got %d and %d", len(repo.deliveries), len(repo.deadLetters))
+	}
+}
+
+func TestDeadLetter_RetentionCap(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo)
+	webhook := &WebhookConfig{ID: "hook-1"}
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	// An entry older than the retention window is dropped on the next dead-letter
+	_ = repo.CreateDeadLetter(context.Background(), &WebhookDeadLetter{
+		ID: "stale", WebhookID: "hook-1", DeadLetteredAt: now.Add(-deadLetterRetention - time.Hour),
+	})
+	for i := 0; i < maxDeadLettersPerHook+5; i++ {
+		delivery := &WebhookDelivery{ID: "d", WebhookID: "hook-1", EventID: "evt"}
+		svc.deadLetter(context.Background(), webhook, delivery, now.Add(time.Duration(i)*time.Second))
+	}
+
+	letters, _ := repo.ListDeadLetters(context.Background(), "hook-1")
+	if len(letters) != maxDeadLettersPerHook {
+		t.Fatalf("expected dead letters capped at %d, got %d", maxDeadLettersPerHook, len(letters))
+	}
+	for _, l := range letters {
+		if l.ID == "stale" {
+			t.Fatal("expected entry past retention window to be pruned")
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 3: 2 * time.Minute, 10: maxRetryDelay}
+	for attempt, want := range tests {
+		if got := retryDelay(attempt); got != want {
+			t.Errorf("retryDelay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestPurgeDeliveries_RespectsWindowAndLegalHold(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo)
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	cutoff := now.Add(-30 * 24 * time.Hour)
+	old := cutoff.Add(-time.Hour)
+
+	for _, d := range []WebhookDelivery{
+		{ID: "old-success", Status: DeliverySuccess, CreatedAt: old},
+		{ID: "old-dead", Status: DeliveryDeadLettered, CreatedAt: old},
+		{ID: "old-held", Status: DeliverySuccess, CreatedAt: old, LegalHold: true},
+		{ID: "old-retrying", Status: DeliveryRetrying, CreatedAt: old},
+		{ID: "recent", Status: DeliverySuccess, CreatedAt: now.Add(-time.Hour)},
+	} {
+		_ = repo.CreateWebhookDelivery(context.Background(), &d)
+	}
+
+	count, err := svc.PurgeDeliveries(context.Background(), cutoff, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if count != 2 || len(repo.deliveries) != 5 {
+		t.Fatalf("expected dry run to report 2 and delete none, got %d with %d left", count, len(repo.deliveries))
+	}
+
+	deleted, err := svc.PurgeDeliveries(context.Background(), cutoff, false)
+	if err != nil {
+		t.Fatalf("purge failed: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 deliveries deleted, got %d", deleted)
+	}
+	kept := map[string]bool{}
+	for _, d := range repo.deliveries {
+		kept[d.ID] = true
+	}
+	for _, id := range []string{"old-held", "old-retrying", "recent"} {
+		if !kept[id] {
+			t.Errorf("expected %s to be kept", id)
+		}
+	}
+	if kept["old-success"] || kept["old-dead"] {
+		t.Errorf("expected settled deliveries past the window to be purged, kept %v", kept)
+	}
+}
@@ -3,6 +3,9 @@ package integration
 import (
 	"net/http"
 
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -18,12 +21,12 @@ func NewHandler(service *Service) *Handler {
 func (h *Handler) RegisterConnection(c *gin.Context) {
 	var conn IntegrationConnection
 	if err := c.ShouldBindJSON(&conn); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	if err := h.service.RegisterConnection(c.Request.Context(), &conn); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -34,18 +37,58 @@ func (h *Handler) RegisterConnection(c *gin.Context) {
 func (h *Handler) ConfigureWebhook(c *gin.Context) {
 	var webhook WebhookConfig
 	if err := c.ShouldBindJSON(&webhook); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	if err := h.service.ConfigureWebhook(c.Request.Context(), &webhook); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, webhook)
 }
 
+// TestConnection
+func (h *Handler) TestConnection(c *gin.Context) {
+	var req struct {
+		ConnectionID string `json:"connection_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+
+	result, err := h.service.TestConnection(c.Request.Context(), req.ConnectionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// The test itself ran; success or failure is reported in the body
+	c.JSON(http.StatusOK, result)
+}
+
+// ListDeadLetters
+func (h *Handler) ListDeadLetters(c *gin.Context) {
+	letters, err := h.service.ListDeadLetters(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"dead_letters": letters})
+}
+
+// ReplayDeadLetters
+func (h *Handler) ReplayDeadLetters(c *gin.Context) {
+	replayed, err := h.service.ReplayDeadLetters(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"replayed": replayed})
+}
+
 // IncomingWebhook
 func (h *Handler) IncomingWebhook(c *gin.Context) {
 	// Verify signature logic would go here
@@ -56,12 +99,12 @@ func (h *Handler) IncomingWebhook(c *gin.Context) {
 func (h *Handler) SubscribeToEvent(c *gin.Context) {
 	var sub EventSubscription
 	if err := c.ShouldBindJSON(&sub); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	if err := h.service.SubscribeToEvent(c.Request.Context(), &sub); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -74,26 +117,43 @@ func (h *Handler) GetHealth(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "ok", "message": "All systems operational"})
 }
 
+// oauthStateCookie binds the issued state to the browser that started the flow
+const oauthStateCookie = "integration_oauth_state"
+
 // OAuth2 Authorize
 func (h *Handler) OAuth2Authorize(c *gin.Context) {
-	provider := c.Param("provider")
-	url, err := h.service.InitiateOAuth2(c.Request.Context(), provider)
+	connectionID := c.Query("connection_id")
+	if connectionID == "" {
+		c.Error(apperrors.Field("connection_id", "is required"))
+		return
+	}
+
+	url, state, err := h.service.InitiateOAuth2(c.Request.Context(), connectionID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, state, int(oauthStateTTL.Seconds()), "/", "", c.Request.TLS != nil, true)
 	c.Redirect(http.StatusFound, url)
 }
 
 // OAuth2 Callback
 func (h *Handler) OAuth2Callback(c *gin.Context) {
-	provider := c.Param("provider")
-	code := c.Query("code")
-	
-	if err := h.service.HandleOAuth2Callback(c.Request.Context(), provider, code); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.Error(apperrors.Validation("authorization denied: " + providerErr))
+		return
+	}
+
+	browserState, _ := c.Cookie(oauthStateCookie)
+	c.SetCookie(oauthStateCookie, "", -1, "/", "", c.Request.TLS != nil, true)
+
+	token, err := h.service.HandleOAuth2Callback(c.Request.Context(), c.Query("state"), browserState, c.Query("code"))
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	
-	c.JSON(http.StatusOK, gin.H{"message": "Authentication successful"})
+
+	c.JSON(http.StatusOK, gin.H{"message": "Authentication successful", "connection_id": token.ConnectionID})
 }
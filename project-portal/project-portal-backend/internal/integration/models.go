@@ -31,6 +31,11 @@ type WebhookConfig struct {
 	IsActive    bool           `gorm:"default:true" json:"is_active"`
 	Headers     map[string]string `gorm:"serializer:json" json:"headers,omitempty"`
 	RetryConfig map[string]any `gorm:"serializer:json" json:"retry_config,omitempty"`
+	// PayloadTemplate is a Go text/template rendering the canonical event into the subscriber's JSON shape.
+	// Empty means the raw event is delivered.
+	PayloadTemplate string `gorm:"type:text" json:"payload_template,omitempty"`
+	// DeliverySequence is the sequence number given to this webhook's latest delivery
+	DeliverySequence int64 `gorm:"not null;default:0" json:"-"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
@@ -40,17 +45,35 @@ type WebhookConfig struct {
 type WebhookDelivery struct {
 	ID             string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
 	WebhookID      string    `gorm:"index;not null" json:"webhook_id"`
+	Sequence       int64     `gorm:"not null;default:0" json:"sequence"` // Increases by one per delivery to the same webhook
 	EventID        string    `gorm:"index;not null" json:"event_id"`
 	EventType      string    `gorm:"index;not null" json:"event_type"`
 	Payload        map[string]any `gorm:"serializer:json" json:"payload"`
 	ResponseStatus int       `json:"response_status"`
 	ResponseBody   string    `json:"response_body"`
-	Status         string    `gorm:"index;not null" json:"status"` // success, failed, pending
+	Status         string    `gorm:"index;not null" json:"status"` // pending, retrying, success, failed, dead_lettered
 	Attempt        int       `json:"attempt"`
 	NextRetryAt    *time.Time `gorm:"index" json:"next_retry_at,omitempty"`
+	LegalHold      bool      `gorm:"not null;default:false" json:"legal_hold"` // Exempts the delivery from retention purges
 	CreatedAt      time.Time `json:"created_at"`
 }
 
+// WebhookDeadLetter keeps the payload of a delivery that exhausted its retries so it can be replayed
+type WebhookDeadLetter struct {
+	ID                string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	WebhookID         string         `gorm:"index;not null" json:"webhook_id"`
+	DeliveryID        string         `gorm:"index" json:"delivery_id"`
+	Sequence          int64          `gorm:"not null;default:0" json:"sequence"` // Sequence of the original delivery, reused on replay
+	EventID           string         `gorm:"not null" json:"event_id"`
+	EventType         string         `gorm:"not null" json:"event_type"`
+	Payload           map[string]any `gorm:"serializer:json" json:"payload"`
+	Attempts          int            `json:"attempts"`
+	LastStatus        int            `json:"last_status"`
+	LastError         string         `json:"last_error,omitempty"`
+	OriginalCreatedAt time.Time      `gorm:"index" json:"original_created_at"`
+	DeadLetteredAt    time.Time      `gorm:"index" json:"dead_lettered_at"`
+}
+
 // EventSubscription represents an external service subscribing to internal events
 type EventSubscription struct {
 	ID          string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
@@ -78,6 +101,15 @@ type OAuthToken struct {
 	UpdatedAt    time.Time      `json:"updated_at"`
 }
 
+// OAuthState is a single-use CSRF token issued when an OAuth flow starts
+type OAuthState struct {
+	State        string    `gorm:"primaryKey" json:"-"`
+	ConnectionID string    `gorm:"index;not null" json:"connection_id"`
+	Provider     string    `gorm:"not null" json:"provider"`
+	ExpiresAt    time.Time `gorm:"index;not null" json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
 // IntegrationHealth represents the health status of a connection
 type IntegrationHealth struct {
 	ID           string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
@@ -0,0 +1,220 @@
+package integration
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+)
+
+// oauthStateTTL bounds how long a user has to complete the provider consent screen
+const oauthStateTTL = 10 * time.Minute
+
+// ErrOAuthStateMismatch is returned when the callback state does not match an issued state
+var ErrOAuthStateMismatch = apperrors.Forbidden("oauth state mismatch")
+
+// OAuthProviderConfig holds the OAuth client registration for one integration provider
+type OAuthProviderConfig struct {
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	Scopes       []string
+}
+
+// knownOAuthEndpoints lets operators configure only client credentials for common providers
+var knownOAuthEndpoints = map[string]OAuthProviderConfig{
+	"slack":  {AuthURL: "https://slack.com/oauth/v2/authorize", TokenURL: "https://slack.com/api/oauth.v2.access"},
+	"github": {AuthURL: "https://github.com/login/oauth/authorize", TokenURL: "https://github.com/login/oauth/access_token"},
+	"google": {AuthURL: "https://accounts.google.com/o/oauth2/v2/auth", TokenURL: "https://oauth2.googleapis.com/token"},
+}
+
+// ConfigureOAuth sets the callback URL and registers the OAuth clients per provider
+func (s *Service) ConfigureOAuth(redirectURL string, providers map[string]OAuthProviderConfig) {
+	s.oauthRedirectURL = redirectURL
+	s.oauthProviders = make(map[string]OAuthProviderConfig, len(providers))
+	for name, cfg := range providers {
+		name = strings.ToLower(name)
+		if known, ok := knownOAuthEndpoints[name]; ok {
+			if cfg.AuthURL == "" {
+				cfg.AuthURL = known.AuthURL
+			}
+			if cfg.TokenURL == "" {
+				cfg.TokenURL = known.TokenURL
+			}
+		}
+		s.oauthProviders[name] = cfg
+	}
+}
+
+// InitiateOAuth2 issues a single-use state for the connection and returns the provider consent URL
+func (s *Service) InitiateOAuth2(ctx context.Context, connectionID string) (string, string, error) {
+	conn, err := s.repo.GetConnection(ctx, connectionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	provider, err := s.oauthProvider(conn.Provider)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := randomState()
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.repo.SaveOAuthState(ctx, &OAuthState{
+		State:        state,
+		ConnectionID: conn.ID,
+		Provider:     strings.ToLower(conn.Provider),
+		ExpiresAt:    time.Now().Add(oauthStateTTL),
+		CreatedAt:    time.Now(),
+	}); err != nil {
+		return "", "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", provider.ClientID)
+	params.Set("redirect_uri", s.oauthRedirectURL)
+	params.Set("state", state)
+	if len(provider.Scopes) > 0 {
+		params.Set("scope", strings.Join(provider.Scopes, " "))
+	}
+
+	separator := "?"
+	if strings.Contains(provider.AuthURL, "?") {
+		separator = "&"
+	}
+	return provider.AuthURL + separator + params.Encode(), state, nil
+}
+
+// HandleOAuth2Callback validates state, exchanges the code and stores the token on the connection.
+// browserState is the state bound to the user agent at authorize time (cookie) and must match.
+func (s *Service) HandleOAuth2Callback(ctx context.Context, state, browserState, code string) (*OAuthToken, error) {
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(browserState)) != 1 {
+		return nil, ErrOAuthStateMismatch
+	}
+	if code == "" {
+		return nil, apperrors.Field("code", "is required")
+	}
+
+	// States are single use: consuming it first prevents replaying the callback
+	issued, err := s.repo.ConsumeOAuthState(ctx, state)
+	if err != nil {
+		return nil, ErrOAuthStateMismatch
+	}
+	if time.Now().After(issued.ExpiresAt) {
+		return nil, fmt.Errorf("%w: state expired", ErrOAuthStateMismatch)
+	}
+
+	provider, err := s.oauthProvider(issued.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.exchangeCode(ctx, provider, code)
+	if err != nil {
+		return nil, err
+	}
+	token.ConnectionID = issued.ConnectionID
+	token.Provider = issued.Provider
+
+	// Replace any previous token for the connection rather than adding another row
+	if existing, err := s.repo.GetOAuthToken(ctx, issued.ConnectionID); err == nil {
+		token.ID = existing.ID
+		token.CreatedAt = existing.CreatedAt
+	}
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+	token.UpdatedAt = time.Now()
+
+	if err := s.repo.SaveOAuthToken(ctx, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s *Service) oauthProvider(name string) (OAuthProviderConfig, error) {
+	provider, ok := s.oauthProviders[strings.ToLower(name)]
+	if !ok || provider.ClientID == "" || provider.AuthURL == "" || provider.TokenURL == "" {
+		return OAuthProviderConfig{}, apperrors.Validation(fmt.Sprintf("oauth is not configured for provider %q", name))
+	}
+	return provider, nil
+}
+
+// exchangeCode trades an authorization code for tokens at the provider's token endpoint
+func (s *Service) exchangeCode(ctx context.Context, provider OAuthProviderConfig, code string) (*OAuthToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", s.oauthRedirectURL)
+	form.Set("client_id", provider.ClientID)
+	form.Set("client_secret", provider.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token exchange failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token exchange failed: provider returned HTTP %d", resp.StatusCode)
+	}
+
+	var reply struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int    `json:"expires_in"`
+		Scope        string `json:"scope"`
+		Error        string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &reply); err != nil {
+		return nil, fmt.Errorf("token exchange failed: %w", err)
+	}
+	if reply.AccessToken == "" {
+		if reply.Error != "" {
+			return nil, fmt.Errorf("token exchange failed: %s", reply.Error)
+		}
+		return nil, errors.New("token exchange failed: no access token returned")
+	}
+
+	token := &OAuthToken{
+		AccessToken:  reply.AccessToken,
+		RefreshToken: reply.RefreshToken,
+		TokenType:    reply.TokenType,
+		Scope:        reply.Scope,
+	}
+	if reply.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(reply.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+func randomState() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
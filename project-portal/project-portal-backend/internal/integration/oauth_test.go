@@ -0,0 +1,147 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newOAuthFixture(t *testing.T, tokenHandler http.HandlerFunc) (*fakeRepository, *gin.Engine) {
+	t.Helper()
+	tokenServer := httptest.NewServer(tokenHandler)
+	t.Cleanup(tokenServer.Close)
+
+	repo := newFakeRepository()
+	repo.CreateConnection(context.Background(), &IntegrationConnection{ID: "conn-1", Provider: "slack"})
+
+	svc := NewService(repo)
+	svc.ConfigureOAuth("https://portal.example.com/api/v1/integrations/oauth/callback", map[string]OAuthProviderConfig{
+		"slack": {ClientID: "client-id", ClientSecret: "client-secret", TokenURL: tokenServer.URL, Scopes: []string{"chat:write"}},
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	RegisterRoutes(router, NewHandler(svc))
+	return repo, router
+}
+
+// authorize starts the flow and returns the issued state and its cookie
+func authorize(t *testing.T, router *gin.Engine) (string, *http.Cookie) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/integrations/oauth/authorize?connection_id=conn-1", nil))
+	if w.Code != http.StatusFound {
+		t.Fatalf("expected redirect, got %d %s", w.Code, w.Body.String())
+	}
+
+	location, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid redirect: %v", err)
+	}
+	if location.Host != "slack.com" || location.Query().Get("client_id") != "client-id" {
+		t.Errorf("unexpected authorize URL %s", location)
+	}
+
+	state := location.Query().Get("state")
+	if state == "" {
+		t.Fatal("expected state in authorize URL")
+	}
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == oauthStateCookie {
+			return state, cookie
+		}
+	}
+	t.Fatal("expected state cookie")
+	return "", nil
+}
+
+func callback(router *gin.Engine, state, code string, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/integrations/oauth/callback?state="+url.QueryEscape(state)+"&code="+code, nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestOAuth_SuccessfulExchange(t *testing.T) {
+	repo, router := newOAuthFixture(t, func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code") != "auth-code" || r.Form.Get("client_secret") != "client-secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "xoxb-token",
+			"refresh_token": "refresh",
+			"token_type":    "bearer",
+			"expires_in":    3600,
+			"scope":         "chat:write",
+		})
+	})
+
+	state, cookie := authorize(t, router)
+	w := callback(router, state, "auth-code", cookie)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected successful callback, got %d %s", w.Code, w.Body.String())
+	}
+
+	token, err := repo.GetOAuthToken(context.Background(), "conn-1")
+	if err != nil {
+		t.Fatalf("expected token to be stored: %v", err)
+	}
+	if token.AccessToken != "xoxb-token" || token.RefreshToken != "refresh" || token.Provider != "slack" {
+		t.Errorf("unexpected stored token %+v", token)
+	}
+	if token.ExpiresAt.IsZero() {
+		t.Error("expected expiry to be set from expires_in")
+	}
+
+	// The state is single use
+	if w := callback(router, state, "auth-code", cookie); w.Code != http.StatusForbidden {
+		t.Errorf("expected replayed state to be rejected, got %d", w.Code)
+	}
+}
+
+func TestOAuth_StateMismatchRejected(t *testing.T) {
+	exchanged := false
+	repo, router := newOAuthFixture(t, func(w http.ResponseWriter, r *http.Request) {
+		exchanged = true
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "should-not-happen"})
+	})
+
+	state, cookie := authorize(t, router)
+
+	// Forged state with a matching cookie was never issued
+	forged := &http.Cookie{Name: oauthStateCookie, Value: "forged"}
+	if w := callback(router, "forged", "auth-code", forged); w.Code != http.StatusForbidden {
+		t.Errorf("expected forged state to be rejected, got %d", w.Code)
+	}
+
+	// Issued state arriving in a different browser (no cookie) is rejected
+	if w := callback(router, state, "auth-code", nil); w.Code != http.StatusForbidden {
+		t.Errorf("expected missing cookie to be rejected, got %d", w.Code)
+	}
+
+	// Issued state with another flow's cookie is rejected
+	other := &http.Cookie{Name: oauthStateCookie, Value: cookie.Value + "x"}
+	if w := callback(router, state, "auth-code", other); w.Code != http.StatusForbidden {
+		t.Errorf("expected mismatched cookie to be rejected, got %d", w.Code)
+	}
+
+	if exchanged {
+		t.Error("token endpoint must not be called on state mismatch")
+	}
+	if _, err := repo.GetOAuthToken(context.Background(), "conn-1"); err == nil {
+		t.Error("expected no token to be stored")
+	}
+}
@@ -2,8 +2,11 @@ package integration
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
@@ -22,6 +25,15 @@ type Repository interface {
 	// Webhook Delivery
 	CreateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error
 	UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error)
+	CountPurgeableDeliveries(ctx context.Context, before time.Time) (int64, error)
+	PurgeDeliveries(ctx context.Context, before time.Time) (int64, error)
+
+	// Webhook Dead Letters
+	CreateDeadLetter(ctx context.Context, letter *WebhookDeadLetter) error
+	ListDeadLetters(ctx context.Context, webhookID string) ([]WebhookDeadLetter, error)
+	ReplayDeadLetters(ctx context.Context, letters []WebhookDeadLetter) error
+	PruneDeadLetters(ctx context.Context, webhookID string, keep int, before time.Time) error
 
 	// Event Subscription
 	CreateSubscription(ctx context.Context, sub *EventSubscription) error
@@ -30,6 +42,8 @@ type Repository interface {
 	// OAuth Token
 	SaveOAuthToken(ctx context.Context, token *OAuthToken) error
 	GetOAuthToken(ctx context.Context, connectionID string) (*OAuthToken, error)
+	SaveOAuthState(ctx context.Context, state *OAuthState) error
+	ConsumeOAuthState(ctx context.Context, state string) (*OAuthState, error)
 
 	// Health
 	RecordHealth(ctx context.Context, health *IntegrationHealth) error
@@ -102,14 +116,147 @@ func (r *repository) GetWebhookConfig(ctx context.Context, id string) (*WebhookC
 
 // Webhook Delivery
 
+// CreateWebhookDelivery stores the delivery under the webhook's next sequence number. The
+// counter row is locked by the UPDATE, so concurrent deliveries get distinct numbers.
 func (r *repository) CreateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
-	return r.db.WithContext(ctx).Create(delivery).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		sequence, err := nextDeliverySequence(tx, delivery.WebhookID)
+		if err != nil {
+			return err
+		}
+		delivery.Sequence = sequence
+		return tx.Create(delivery).Error
+	})
+}
+
+// nextDeliverySequence advances and returns the webhook's delivery counter within tx
+func nextDeliverySequence(tx *gorm.DB, webhookID string) (int64, error) {
+	var sequence []int64
+	if err := tx.Raw("UPDATE webhook_configs SET delivery_sequence = delivery_sequence + 1 WHERE id = ? RETURNING delivery_sequence",
+		webhookID).Scan(&sequence).Error; err != nil {
+		return 0, err
+	}
+	if len(sequence) == 1 {
+		return sequence[0], nil
+	}
+	return 0, nil
 }
 
 func (r *repository) UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
 	return r.db.WithContext(ctx).Save(delivery).Error
 }
 
+func (r *repository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+	if err := r.db.WithContext(ctx).
+		Where("status IN ?", []string{DeliveryPending, DeliveryRetrying}).
+		Where("next_retry_at IS NULL OR next_retry_at <= ?", now).
+		// An earlier delivery still waiting for its retry holds back the rest of its webhook
+		Where(`NOT EXISTS (SELECT 1 FROM webhook_deliveries earlier
+			WHERE earlier.webhook_id = webhook_deliveries.webhook_id
+			AND earlier.sequence < webhook_deliveries.sequence
+			AND earlier.status = ? AND earlier.next_retry_at > ?)`, DeliveryRetrying, now).
+		Order("created_at ASC, sequence ASC").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// purgeableDeliveries selects settled deliveries created before cutoff that are not on legal hold
+func purgeableDeliveries(query *gorm.DB, before time.Time) *gorm.DB {
+	return query.Model(&WebhookDelivery{}).
+		Where("status IN ? AND created_at < ? AND NOT legal_hold", []string{DeliverySuccess, DeliveryFailed, DeliveryDeadLettered}, before)
+}
+
+func (r *repository) CountPurgeableDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	var count int64
+	err := purgeableDeliveries(r.db.WithContext(ctx), before).Count(&count).Error
+	return count, err
+}
+
+func (r *repository) PurgeDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	result := purgeableDeliveries(r.db.WithContext(ctx), before).Delete(&WebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
+
+// Webhook Dead Letters
+
+func (r *repository) CreateDeadLetter(ctx context.Context, letter *WebhookDeadLetter) error {
+	return r.db.WithContext(ctx).Create(letter).Error
+}
+
+func (r *repository) ListDeadLetters(ctx context.Context, webhookID string) ([]WebhookDeadLetter, error) {
+	var letters []WebhookDeadLetter
+	if err := r.db.WithContext(ctx).
+		Where("webhook_id = ?", webhookID).
+		Order("original_created_at ASC").
+		Find(&letters).Error; err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+// ReplayDeadLetters puts each letter's original delivery back in the queue as pending,
+// under its original ID and sequence so receivers can recognise the retry, and removes
+// the letters. Either every letter is replayed or none is.
+func (r *repository) ReplayDeadLetters(ctx context.Context, letters []WebhookDeadLetter) error {
+	if len(letters) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids := make([]string, 0, len(letters))
+		for _, letter := range letters {
+			delivery := WebhookDelivery{
+				ID:        letter.DeliveryID,
+				WebhookID: letter.WebhookID,
+				Sequence:  letter.Sequence,
+				EventID:   letter.EventID,
+				EventType: letter.EventType,
+				Payload:   letter.Payload,
+				Status:    DeliveryPending,
+				CreatedAt: letter.OriginalCreatedAt,
+			}
+			// Letters written before the delivery was recorded on them get a fresh one
+			if delivery.ID == "" {
+				sequence, err := nextDeliverySequence(tx, letter.WebhookID)
+				if err != nil {
+					return err
+				}
+				delivery.ID, delivery.Sequence = uuid.New().String(), sequence
+			}
+
+			// The original row is usually still there, dead-lettered; it may have been purged
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"status", "attempt", "next_retry_at", "response_status", "response_body"}),
+			}).Create(&delivery).Error; err != nil {
+				return err
+			}
+			ids = append(ids, letter.ID)
+		}
+		return tx.Delete(&WebhookDeadLetter{}, "id IN ?", ids).Error
+	})
+}
+
+func (r *repository) PruneDeadLetters(ctx context.Context, webhookID string, keep int, before time.Time) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Where("webhook_id = ? AND dead_lettered_at < ?", webhookID, before).
+		Delete(&WebhookDeadLetter{}).Error; err != nil {
+		return err
+	}
+
+	// Drop everything beyond the newest `keep` entries
+	newest := db.Model(&WebhookDeadLetter{}).
+		Select("id").
+		Where("webhook_id = ?", webhookID).
+		Order("dead_lettered_at DESC").
+		Limit(keep)
+	return db.Where("webhook_id = ? AND id NOT IN (?)", webhookID, newest).
+		Delete(&WebhookDeadLetter{}).Error
+}
+
 // Event Subscription
 
 func (r *repository) CreateSubscription(ctx context.Context, sub *EventSubscription) error {
@@ -138,6 +285,26 @@ func (r *repository) GetOAuthToken(ctx context.Context, connectionID string) (*O
 	return &token, nil
 }
 
+func (r *repository) SaveOAuthState(ctx context.Context, state *OAuthState) error {
+	return r.db.WithContext(ctx).Create(state).Error
+}
+
+// ConsumeOAuthState deletes and returns a state so it can only be redeemed once
+func (r *repository) ConsumeOAuthState(ctx context.Context, state string) (*OAuthState, error) {
+	var issued OAuthState
+	result := r.db.WithContext(ctx).
+		Clauses(clause.Returning{}).
+		Where("state = ?", state).
+		Delete(&issued)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &issued, nil
+}
+
 // Health
 
 func (r *repository) RecordHealth(ctx context.Context, health *IntegrationHealth) error {
@@ -0,0 +1,355 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// fakeRepository is an in-memory Repository used by the service and handler tests
+type fakeRepository struct {
+	mu sync.Mutex
+
+	connections   map[string]*IntegrationConnection
+	webhooks      map[string]*WebhookConfig
+	deliveries    []WebhookDelivery
+	subscriptions []EventSubscription
+	tokens        map[string]*OAuthToken
+	states        map[string]*OAuthState
+	health        []IntegrationHealth
+	deadLetters   []WebhookDeadLetter
+
+	// replayErr fails ReplayDeadLetters as a rolled-back transaction would
+	replayErr error
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{
+		connections: make(map[string]*IntegrationConnection),
+		webhooks:    make(map[string]*WebhookConfig),
+		tokens:      make(map[string]*OAuthToken),
+		states:      make(map[string]*OAuthState),
+	}
+}
+
+var errFakeNotFound = errors.New("record not found")
+
+func (f *fakeRepository) CreateConnection(ctx context.Context, conn *IntegrationConnection) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *conn
+	f.connections[conn.ID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) GetConnection(ctx context.Context, id string) (*IntegrationConnection, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	conn, ok := f.connections[id]
+	if !ok {
+		return nil, errFakeNotFound
+	}
+	copied := *conn
+	return &copied, nil
+}
+
+func (f *fakeRepository) ListConnections(ctx context.Context) ([]IntegrationConnection, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var conns []IntegrationConnection
+	for _, conn := range f.connections {
+		conns = append(conns, *conn)
+	}
+	return conns, nil
+}
+
+func (f *fakeRepository) UpdateConnection(ctx context.Context, conn *IntegrationConnection) error {
+	return f.CreateConnection(ctx, conn)
+}
+
+func (f *fakeRepository) DeleteConnection(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.connections, id)
+	return nil
+}
+
+func (f *fakeRepository) CreateWebhookConfig(ctx context.Context, webhook *WebhookConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *webhook
+	f.webhooks[webhook.ID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) ListWebhookConfigs(ctx context.Context, projectID *string) ([]WebhookConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var webhooks []WebhookConfig
+	for _, webhook := range f.webhooks {
+		if projectID != nil && (webhook.ProjectID == nil || *webhook.ProjectID != *projectID) {
+			continue
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+	return webhooks, nil
+}
+
+func (f *fakeRepository) GetWebhookConfig(ctx context.Context, id string) (*WebhookConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	webhook, ok := f.webhooks[id]
+	if !ok {
+		return nil, errFakeNotFound
+	}
+	copied := *webhook
+	return &copied, nil
+}
+
+func (f *fakeRepository) CreateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if webhook, ok := f.webhooks[delivery.WebhookID]; ok {
+		webhook.DeliverySequence++
+		delivery.Sequence = webhook.DeliverySequence
+	}
+	f.deliveries = append(f.deliveries, *delivery)
+	return nil
+}
+
+func (f *fakeRepository) UpdateWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.deliveries {
+		if f.deliveries[i].ID == delivery.ID {
+			f.deliveries[i] = *delivery
+			return nil
+		}
+	}
+	return errFakeNotFound
+}
+
+func (f *fakeRepository) CreateSubscription(ctx context.Context, sub *EventSubscription) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subscriptions = append(f.subscriptions, *sub)
+	return nil
+}
+
+func (f *fakeRepository) ListSubscriptions(ctx context.Context, eventType string) ([]EventSubscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var subs []EventSubscription
+	for _, sub := range f.subscriptions {
+		if sub.EventType == eventType {
+			subs = append(subs, sub)
+		}
+	}
+	return subs, nil
+}
+
+func (f *fakeRepository) SaveOAuthToken(ctx context.Context, token *OAuthToken) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *token
+	f.tokens[token.ConnectionID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) GetOAuthToken(ctx context.Context, connectionID string) (*OAuthToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	token, ok := f.tokens[connectionID]
+	if !ok {
+		return nil, errFakeNotFound
+	}
+	copied := *token
+	return &copied, nil
+}
+
+func (f *fakeRepository) SaveOAuthState(ctx context.Context, state *OAuthState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *state
+	f.states[state.State] = &copied
+	return nil
+}
+
+func (f *fakeRepository) ConsumeOAuthState(ctx context.Context, state string) (*OAuthState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	issued, ok := f.states[state]
+	if !ok {
+		return nil, errFakeNotFound
+	}
+	delete(f.states, state)
+	return issued, nil
+}
+
+func (f *fakeRepository) RecordHealth(ctx context.Context, health *IntegrationHealth) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.health = append(f.health, *health)
+	return nil
+}
+
+func (f *fakeRepository) GetLatestHealth(ctx context.Context, connectionID string) (*IntegrationHealth, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := len(f.health) - 1; i >= 0; i-- {
+		if f.health[i].ConnectionID == connectionID {
+			copied := f.health[i]
+			return &copied, nil
+		}
+	}
+	return nil, errFakeNotFound
+}
+
+func (f *fakeRepository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var due []WebhookDelivery
+	for _, d := range f.deliveries {
+		if d.Status != DeliveryPending && d.Status != DeliveryRetrying {
+			continue
+		}
+		if d.NextRetryAt != nil && d.NextRetryAt.After(now) {
+			continue
+		}
+		if f.heldBack(d, now) {
+			continue
+		}
+		due = append(due, d)
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		if !due[i].CreatedAt.Equal(due[j].CreatedAt) {
+			return due[i].CreatedAt.Before(due[j].CreatedAt)
+		}
+		return due[i].Sequence < due[j].Sequence
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// heldBack mirrors the NOT EXISTS clause of ListDueDeliveries
+func (f *fakeRepository) heldBack(d WebhookDelivery, now time.Time) bool {
+	for _, earlier := range f.deliveries {
+		if earlier.WebhookID == d.WebhookID && earlier.Sequence < d.Sequence &&
+			earlier.Status == DeliveryRetrying && earlier.NextRetryAt != nil && earlier.NextRetryAt.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+func purgeableDelivery(d WebhookDelivery, before time.Time) bool {
+	settled := d.Status == DeliverySuccess || d.Status == DeliveryFailed || d.Status == DeliveryDeadLettered
+	return settled && d.CreatedAt.Before(before) && !d.LegalHold
+}
+
+func (f *fakeRepository) CountPurgeableDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var count int64
+	for _, d := range f.deliveries {
+		if purgeableDelivery(d, before) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeRepository) PurgeDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	kept := f.deliveries[:0]
+	for _, d := range f.deliveries {
+		if !purgeableDelivery(d, before) {
+			kept = append(kept, d)
+		}
+	}
+	deleted := int64(len(f.deliveries) - len(kept))
+	f.deliveries = kept
+	return deleted, nil
+}
+
+func (f *fakeRepository) CreateDeadLetter(ctx context.Context, letter *WebhookDeadLetter) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deadLetters = append(f.deadLetters, *letter)
+	return nil
+}
+
+func (f *fakeRepository) ListDeadLetters(ctx context.Context, webhookID string) ([]WebhookDeadLetter, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var letters []WebhookDeadLetter
+	for _, l := range f.deadLetters {
+		if l.WebhookID == webhookID {
+			letters = append(letters, l)
+		}
+	}
+	sort.SliceStable(letters, func(i, j int) bool {
+		return letters[i].OriginalCreatedAt.Before(letters[j].OriginalCreatedAt)
+	})
+	return letters, nil
+}
+
+func (f *fakeRepository) ReplayDeadLetters(ctx context.Context, letters []WebhookDeadLetter) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.replayErr != nil {
+		return f.replayErr
+	}
+	remove := make(map[string]bool, len(letters))
+	for _, letter := range letters {
+		remove[letter.ID] = true
+		replayed := WebhookDelivery{
+			ID: letter.DeliveryID, WebhookID: letter.WebhookID, Sequence: letter.Sequence,
+			EventID: letter.EventID, EventType: letter.EventType, Payload: letter.Payload,
+			Status: DeliveryPending, CreatedAt: letter.OriginalCreatedAt,
+		}
+		found := false
+		for i := range f.deliveries {
+			if f.deliveries[i].ID == letter.DeliveryID {
+				replayed.LegalHold = f.deliveries[i].LegalHold
+				f.deliveries[i], found = replayed, true
+			}
+		}
+		if !found {
+			f.deliveries = append(f.deliveries, replayed)
+		}
+	}
+	kept := f.deadLetters[:0]
+	for _, l := range f.deadLetters {
+		if !remove[l.ID] {
+			kept = append(kept, l)
+		}
+	}
+	f.deadLetters = kept
+	return nil
+}
+
+func (f *fakeRepository) PruneDeadLetters(ctx context.Context, webhookID string, keep int, before time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var mine, others []WebhookDeadLetter
+	for _, l := range f.deadLetters {
+		switch {
+		case l.WebhookID != webhookID:
+			others = append(others, l)
+		case !l.DeadLetteredAt.Before(before):
+			mine = append(mine, l)
+		}
+	}
+	sort.SliceStable(mine, func(i, j int) bool { return mine[i].DeadLetteredAt.After(mine[j].DeadLetteredAt) })
+	if len(mine) > keep {
+		mine = mine[:keep]
+	}
+	f.deadLetters = append(others, mine...)
+	return nil
+}
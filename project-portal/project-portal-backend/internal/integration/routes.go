@@ -7,10 +7,13 @@ func RegisterRoutes(r *gin.Engine, h *Handler) {
 	{
 		// Connection Management
 		v1.POST("/connections", h.RegisterConnection)
+		v1.POST("/test", h.TestConnection)
 		
 		// Webhooks
 		v1.POST("/webhooks", h.ConfigureWebhook)
 		v1.POST("/webhooks/incoming", h.IncomingWebhook)
+		v1.GET("/webhooks/:id/dead-letters", h.ListDeadLetters)
+		v1.POST("/webhooks/:id/dead-letters/replay", h.ReplayDeadLetters)
 		
 		// Subscriptions
 		v1.POST("/subscriptions", h.SubscribeToEvent)
@@ -19,7 +22,7 @@ func RegisterRoutes(r *gin.Engine, h *Handler) {
 		v1.GET("/health", h.GetHealth)
 		
 		// OAuth2
-		v1.GET("/oauth2/authorize/:provider", h.OAuth2Authorize)
-		v1.POST("/oauth2/callback/:provider", h.OAuth2Callback)
+		v1.GET("/oauth/authorize", h.OAuth2Authorize)
+		v1.GET("/oauth/callback", h.OAuth2Callback)
 	}
 }
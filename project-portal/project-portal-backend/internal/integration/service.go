@@ -2,18 +2,27 @@ package integration
 
 import (
 	"context"
-	"errors"
+	"net/http"
 	"time"
 
+	"carbon-scribe/project-portal/project-portal-backend/pkg/eventbus"
+
 	"github.com/google/uuid"
 )
 
 type Service struct {
-	repo Repository
+	repo       Repository
+	httpClient *http.Client
+
+	oauthRedirectURL string
+	oauthProviders   map[string]OAuthProviderConfig
 }
 
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{
+		repo:       repo,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
 }
 
 // RegisterConnection creates a new integration connection
@@ -24,35 +33,46 @@ func (s *Service) RegisterConnection(ctx context.Context, conn *IntegrationConne
 	return s.repo.CreateConnection(ctx, conn)
 }
 
-// TestConnection verifies connectivity (placeholder)
-func (s *Service) TestConnection(ctx context.Context, id string) error {
+// TestConnection makes a lightweight call to the provider with the stored credentials
+func (s *Service) TestConnection(ctx context.Context, id string) (*ConnectionTestResult, error) {
 	conn, err := s.repo.GetConnection(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	result := s.probeConnection(ctx, conn)
+
+	// Update LastTested and reflect the outcome on the connection
+	conn.LastTested = &result.CheckedAt
+	if result.Success {
+		conn.Status = "active"
+	} else {
+		conn.Status = "error"
 	}
-	
-	// Simulate connection test based on Provider
-	// e.g., if conn.Provider == "stripe" { ... }
-	
-	// Update LastTested
-	now := time.Now()
-	conn.LastTested = &now
 	_ = s.repo.UpdateConnection(ctx, conn)
-	
+
 	// Record Health
-	_ = s.repo.RecordHealth(ctx, &IntegrationHealth{
+	health := &IntegrationHealth{
 		ConnectionID: conn.ID,
 		Status:       "healthy",
-		LatencyMs:    45, // Dummy value
-		CheckedAt:    time.Now(),
-		Message:      "Connection successful",
-	})
+		LatencyMs:    result.LatencyMs,
+		CheckedAt:    result.CheckedAt,
+		Message:      result.Message,
+	}
+	if !result.Success {
+		health.Status = "down"
+		health.ErrorRate = 1
+	}
+	_ = s.repo.RecordHealth(ctx, health)
 
-	return nil
+	return result, nil
 }
 
 // ConfigureWebhook creates a new outgoing webhook configuration
 func (s *Service) ConfigureWebhook(ctx context.Context, webhook *WebhookConfig) error {
+	if err := validatePayloadTemplate(webhook.PayloadTemplate); err != nil {
+		return err
+	}
 	if webhook.Secret == "" {
 		webhook.Secret = uuid.New().String() // Generate secret if not provided
 	}
@@ -85,6 +105,7 @@ func (s *Service) TriggerWebhook(ctx context.Context, eventType string, payload
 	for _, sub := range subs {
 		// Simulate delivery
 		delivery := &WebhookDelivery{
+			ID:             uuid.New().String(),
 			WebhookID:      sub.ID, // Using Sub ID as placeholder
 			EventID:        uuid.New().String(),
 			EventType:      eventType,
@@ -94,23 +115,53 @@ func (s *Service) TriggerWebhook(ctx context.Context, eventType string, payload
 		}
 		_ = s.repo.CreateWebhookDelivery(ctx, delivery)
 	}
-	
+
+	// 3. Queue deliveries for configured webhooks, shaped by their payload templates
+	webhooks, err := s.repo.ListWebhookConfigs(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, webhook := range webhooks {
+		if !webhook.IsActive || !containsString(webhook.Events, eventType) {
+			continue
+		}
+
+		eventID := uuid.New().String()
+		body, err := renderPayload(webhook.PayloadTemplate, canonicalEvent(eventID, eventType, payload))
+		status := DeliveryPending
+		if err != nil {
+			// A template that breaks on real data fails this delivery only
+			body = map[string]any{"error": err.Error()}
+			status = DeliveryFailed
+		}
+		_ = s.repo.CreateWebhookDelivery(ctx, &WebhookDelivery{
+			ID:        uuid.New().String(),
+			WebhookID: webhook.ID,
+			EventID:   eventID,
+			EventType: eventType,
+			Payload:   body,
+			Status:    status,
+			CreatedAt: time.Now(),
+		})
+	}
+
 	return nil
 }
 
-// OAuth2 Flow Placeholders
-
-func (s *Service) InitiateOAuth2(ctx context.Context, provider string) (string, error) {
-	// Return authorization URL
-	return "https://" + provider + ".com/oauth/authorize?client_id=...", nil
+// HandleEvent is the event bus subscriber that forwards domain events to webhooks and subscriptions
+func (s *Service) HandleEvent(ctx context.Context, event eventbus.Event) error {
+	payload := event.Data
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	return s.TriggerWebhook(ctx, event.Type, payload)
 }
 
-func (s *Service) HandleOAuth2Callback(ctx context.Context, provider, code string) error {
-	// Exchange code for token and save
-	if code == "" {
-		return errors.New("invalid code")
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
 	}
-	// Mock saving token
-	// s.repo.SaveOAuthToken(...)
-	return nil
+	return false
 }
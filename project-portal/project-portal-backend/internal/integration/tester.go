@@ -0,0 +1,175 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Default API endpoints used when a connection does not override base_url
+const (
+	slackDefaultBaseURL  = "https://slack.com"
+	stripeDefaultBaseURL = "https://api.stripe.com"
+)
+
+// ConnectionTestResult is the outcome of a lightweight provider call
+type ConnectionTestResult struct {
+	ConnectionID string    `json:"connection_id"`
+	Provider     string    `json:"provider"`
+	Success      bool      `json:"success"`
+	StatusCode   int       `json:"status_code,omitempty"`
+	LatencyMs    int       `json:"latency_ms"`
+	Message      string    `json:"message"`
+	CheckedAt    time.Time `json:"checked_at"`
+}
+
+// probeConnection performs the provider-appropriate check for a connection
+func (s *Service) probeConnection(ctx context.Context, conn *IntegrationConnection) *ConnectionTestResult {
+	result := &ConnectionTestResult{
+		ConnectionID: conn.ID,
+		Provider:     conn.Provider,
+	}
+
+	start := time.Now()
+	switch strings.ToLower(conn.Provider) {
+	case "slack":
+		s.probeSlack(ctx, conn, result)
+	case "stripe":
+		s.probeBearerGET(ctx, conn, result, baseURL(conn, stripeDefaultBaseURL)+"/v1/balance", "api_key")
+	default:
+		url := stringValue(conn.Config, "url")
+		if url == "" {
+			url = stringValue(conn.Credentials, "url")
+		}
+		if url == "" {
+			result.Message = fmt.Sprintf("no connection test available for provider %q (set config.url to probe an endpoint)", conn.Provider)
+			break
+		}
+		s.probeHead(ctx, url, result)
+	}
+	result.LatencyMs = int(time.Since(start).Milliseconds())
+	result.CheckedAt = time.Now()
+
+	return result
+}
+
+// probeSlack calls auth.test, which validates a token without side effects
+func (s *Service) probeSlack(ctx context.Context, conn *IntegrationConnection, result *ConnectionTestResult) {
+	token := stringValue(conn.Credentials, "token")
+	if token == "" {
+		result.Message = "missing credentials.token"
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL(conn, slackDefaultBaseURL)+"/api/auth.test", nil)
+	if err != nil {
+		result.Message = err.Error()
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, ok := s.send(req, result)
+	if !ok {
+		return
+	}
+
+	var reply struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+		Team  string `json:"team"`
+	}
+	if err := json.Unmarshal(body, &reply); err != nil {
+		result.Message = fmt.Sprintf("unexpected response from slack: %v", err)
+		return
+	}
+	if !reply.OK {
+		result.Message = "slack rejected credentials: " + reply.Error
+		return
+	}
+
+	result.Success = true
+	result.Message = "authenticated to slack workspace " + reply.Team
+}
+
+// probeBearerGET issues an authenticated GET against a read-only endpoint
+func (s *Service) probeBearerGET(ctx context.Context, conn *IntegrationConnection, result *ConnectionTestResult, url, credentialKey string) {
+	secret := stringValue(conn.Credentials, credentialKey)
+	if secret == "" {
+		result.Message = "missing credentials." + credentialKey
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		result.Message = err.Error()
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+secret)
+
+	if _, ok := s.send(req, result); !ok {
+		return
+	}
+	result.Success = true
+	result.Message = fmt.Sprintf("authenticated to %s", conn.Provider)
+}
+
+// probeHead checks that a URL is reachable; any non-5xx answer counts as reachable
+// because many webhook receivers reject HEAD with 404/405
+func (s *Service) probeHead(ctx context.Context, url string, result *ConnectionTestResult) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		result.Message = err.Error()
+		return
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		result.Message = fmt.Sprintf("endpoint unreachable: %v", err)
+		return
+	}
+	resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode >= http.StatusInternalServerError {
+		result.Message = fmt.Sprintf("endpoint returned HTTP %d", resp.StatusCode)
+		return
+	}
+	result.Success = true
+	result.Message = fmt.Sprintf("endpoint reachable (HTTP %d)", resp.StatusCode)
+}
+
+// send executes req and reports transport or non-2xx failures on result
+func (s *Service) send(req *http.Request, result *ConnectionTestResult) ([]byte, bool) {
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		result.Message = fmt.Sprintf("request failed: %v", err)
+		return nil, false
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		result.Message = fmt.Sprintf("provider returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		return nil, false
+	}
+	return body, true
+}
+
+func baseURL(conn *IntegrationConnection, fallback string) string {
+	if override := stringValue(conn.Config, "base_url"); override != "" {
+		return strings.TrimRight(override, "/")
+	}
+	return fallback
+}
+
+func stringValue(m map[string]any, key string) string {
+	if v, ok := m[key].(string); ok {
+		return v
+	}
+	return ""
+}
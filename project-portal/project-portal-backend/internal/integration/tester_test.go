@@ -0,0 +1,114 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTestConnection_Slack(t *testing.T) {
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/auth.test" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") == "Bearer good-token" {
+			json.NewEncoder(w).Encode(map[string]any{"ok": true, "team": "carbon"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"ok": false, "error": "invalid_auth"})
+	}))
+	defer provider.Close()
+
+	tests := []struct {
+		name        string
+		token       string
+		wantSuccess bool
+		wantHealth  string
+		wantMessage string
+	}{
+		{"valid token", "good-token", true, "healthy", "carbon"},
+		{"revoked token", "bad-token", false, "down", "invalid_auth"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepository()
+			repo.CreateConnection(context.Background(), &IntegrationConnection{
+				ID:          "conn-1",
+				Provider:    "slack",
+				Credentials: map[string]any{"token": tt.token},
+				Config:      map[string]any{"base_url": provider.URL},
+			})
+			svc := NewService(repo)
+
+			result, err := svc.TestConnection(context.Background(), "conn-1")
+			if err != nil {
+				t.Fatalf("TestConnection failed: %v", err)
+			}
+			if result.Success != tt.wantSuccess {
+				t.Errorf("expected success=%v, got %+v", tt.wantSuccess, result)
+			}
+			if !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("expected message to mention %q, got %q", tt.wantMessage, result.Message)
+			}
+
+			health, err := repo.GetLatestHealth(context.Background(), "conn-1")
+			if err != nil {
+				t.Fatalf("expected health to be recorded: %v", err)
+			}
+			if health.Status != tt.wantHealth {
+				t.Errorf("expected health %q, got %q", tt.wantHealth, health.Status)
+			}
+
+			conn, _ := repo.GetConnection(context.Background(), "conn-1")
+			if conn.LastTested == nil {
+				t.Error("expected LastTested to be set")
+			}
+		})
+	}
+}
+
+func TestTestConnection_WebhookHead(t *testing.T) {
+	status := http.StatusOK
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("expected HEAD, got %s", r.Method)
+		}
+		w.WriteHeader(status)
+	}))
+	defer endpoint.Close()
+
+	repo := newFakeRepository()
+	repo.CreateConnection(context.Background(), &IntegrationConnection{
+		ID:       "hook-1",
+		Provider: "webhook",
+		Config:   map[string]any{"url": endpoint.URL},
+	})
+	svc := NewService(repo)
+
+	result, _ := svc.TestConnection(context.Background(), "hook-1")
+	if !result.Success || result.StatusCode != http.StatusOK {
+		t.Errorf("expected reachable endpoint, got %+v", result)
+	}
+
+	status = http.StatusBadGateway
+	result, _ = svc.TestConnection(context.Background(), "hook-1")
+	if result.Success || result.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected failing endpoint, got %+v", result)
+	}
+	conn, _ := repo.GetConnection(context.Background(), "hook-1")
+	if conn.Status != "error" {
+		t.Errorf("expected connection status error, got %q", conn.Status)
+	}
+}
+
+func TestTestConnection_UnknownConnection(t *testing.T) {
+	svc := NewService(newFakeRepository())
+	if _, err := svc.TestConnection(context.Background(), "missing"); err == nil {
+		t.Error("expected error for unknown connection")
+	}
+}
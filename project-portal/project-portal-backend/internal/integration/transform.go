@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+)
+
+// maxTransformedPayloadBytes caps template output so a runaway template cannot exhaust memory
+const maxTransformedPayloadBytes = 256 * 1024
+
+// ErrInvalidTemplate is returned when a webhook payload template fails validation
+var ErrInvalidTemplate = apperrors.Validation("invalid payload template", apperrors.FieldError{Field: "payload_template", Message: "is not a valid template"})
+
+// templateFuncs is the complete set of functions available to payload templates.
+// text/template has no file, network or exec access, so templates can only reshape the event.
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// sampleEvent is used to validate templates when a webhook is saved
+var sampleEvent = map[string]any{
+	"id":          "00000000-0000-0000-0000-000000000000",
+	"type":        "sample.event",
+	"occurred_at": "2026-01-01T00:00:00Z",
+	"data":        map[string]any{"id": "sample", "name": "Sample"},
+}
+
+// canonicalEvent builds the envelope every webhook receives unless a template reshapes it
+func canonicalEvent(eventID, eventType string, payload map[string]any) map[string]any {
+	return map[string]any{
+		"id":          eventID,
+		"type":        eventType,
+		"occurred_at": time.Now().UTC().Format(time.RFC3339),
+		"data":        payload,
+	}
+}
+
+// validatePayloadTemplate parses the template and renders the sample event through it
+func validatePayloadTemplate(source string) error {
+	if source == "" {
+		return nil
+	}
+	if _, err := renderPayload(source, sampleEvent); err != nil {
+		return err
+	}
+	return nil
+}
+
+// renderPayload applies a webhook's template to an event; an empty template passes the event through
+func renderPayload(source string, event map[string]any) (map[string]any, error) {
+	if source == "" {
+		return event, nil
+	}
+
+	tmpl, err := template.New("payload").Funcs(templateFuncs).Option("missingkey=zero").Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	out := &limitedBuffer{limit: maxTransformedPayloadBytes}
+	if err := tmpl.Execute(out, event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		return nil, fmt.Errorf("%w: output is not a JSON object: %v", ErrInvalidTemplate, err)
+	}
+	return payload, nil
+}
+
+// limitedBuffer fails writes once the limit is reached, aborting template execution
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, fmt.Errorf("output exceeds %d bytes", b.limit)
+	}
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,100 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderPayload_ReshapesEvent(t *testing.T) {
+	template := `{"text": "Project {{.data.name}} {{.type | upper}}", "project": {{json .data.id}}, "tags": {{json .data.tags}}}`
+	event := canonicalEvent("evt-1", "project.created", map[string]any{
+		"id":   "p-42",
+		"name": "Mangrove Restoration",
+		"tags": []any{"blue-carbon", "kenya"},
+	})
+
+	payload, err := renderPayload(template, event)
+	if err != nil {
+		t.Fatalf("renderPayload failed: %v", err)
+	}
+
+	want := map[string]any{
+		"text":    "Project Mangrove Restoration PROJECT.CREATED",
+		"project": "p-42",
+		"tags":    []any{"blue-carbon", "kenya"},
+	}
+	if !reflect.DeepEqual(payload, want) {
+		t.Errorf("unexpected payload:\n got  %v\n want %v", payload, want)
+	}
+}
+
+func TestRenderPayload_DefaultsToRawEvent(t *testing.T) {
+	event := canonicalEvent("evt-1", "project.created", map[string]any{"id": "p-42"})
+	payload, err := renderPayload("", event)
+	if err != nil {
+		t.Fatalf("renderPayload failed: %v", err)
+	}
+	if !reflect.DeepEqual(payload, event) {
+		t.Errorf("expected raw event, got %v", payload)
+	}
+}
+
+func TestConfigureWebhook_RejectsBadTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"syntax error", `{"text": "{{.data.name"}`},
+		{"unknown function", `{"text": "{{exec "rm -rf /"}}"}`},
+		{"not json", `Project {{.data.name}} created`},
+		{"oversized output", `{"x": "` + strings.Repeat("A", maxTransformedPayloadBytes) + `"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRepository()
+			svc := NewService(repo)
+
+			err := svc.ConfigureWebhook(context.Background(), &WebhookConfig{
+				ID:              "hook-1",
+				URL:             "https://example.com/hook",
+				PayloadTemplate: tt.template,
+			})
+			if !errors.Is(err, ErrInvalidTemplate) {
+				t.Fatalf("expected ErrInvalidTemplate, got %v", err)
+			}
+			if _, err := repo.GetWebhookConfig(context.Background(), "hook-1"); err == nil {
+				t.Error("expected webhook with bad template not to be saved")
+			}
+		})
+	}
+}
+
+func TestTriggerWebhook_AppliesTemplate(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo)
+
+	if err := svc.ConfigureWebhook(context.Background(), &WebhookConfig{
+		ID:              "hook-1",
+		URL:             "https://example.com/hook",
+		Events:          []string{"project.created"},
+		IsActive:        true,
+		PayloadTemplate: `{"summary": "{{.data.name}}"}`,
+	}); err != nil {
+		t.Fatalf("ConfigureWebhook failed: %v", err)
+	}
+
+	if err := svc.TriggerWebhook(context.Background(), "project.created", map[string]any{"name": "Agroforestry"}); err != nil {
+		t.Fatalf("TriggerWebhook failed: %v", err)
+	}
+
+	if len(repo.deliveries) != 1 {
+		t.Fatalf("expected 1 delivery, got %d", len(repo.deliveries))
+	}
+	if got := repo.deliveries[0].Payload; !reflect.DeepEqual(got, map[string]any{"summary": "Agroforestry"}) {
+		t.Errorf("expected transformed payload, got %v", got)
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaxBodyBytes is the request body cap used when none is configured
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// BodyLimit caps request bodies at limit bytes and answers 413 once a body goes past it.
+// overrides set a different cap for specific routes, keyed by gin route pattern
+// (e.g. "/api/v1/reports/benchmarks").
+func BodyLimit(limit int64, overrides map[string]int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		max := limit
+		if override, ok := overrides[c.FullPath()]; ok {
+			max = override
+		}
+
+		// Reject declared sizes up front; chunked bodies are cut off while binding
+		if c.Request.ContentLength > max {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, payloadTooLarge(max))
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, max)
+		c.Next()
+	}
+}
+
+func payloadTooLarge(limit int64) ErrorResponse {
+	return ErrorResponse{
+		Code:  CodePayloadTooLarge,
+		Error: fmt.Sprintf("request body exceeds %d bytes", limit),
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+	"carbon-scribe/project-portal/project-portal-backend/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// Machine-readable error codes returned in ErrorResponse.Code
+const (
+	CodeValidation = "validation_error"
+	CodeNotFound   = "not_found"
+	CodeConflict   = "conflict"
+	CodeForbidden  = "forbidden"
+	CodeInternal   = "internal_error"
+
+	CodePayloadTooLarge = "payload_too_large"
+	CodeNotAcceptable   = "not_acceptable"
+	CodeUnauthorized    = "unauthorized"
+)
+
+// ErrorResponse is the JSON body written for every failed request
+type ErrorResponse struct {
+	Code    string                 `json:"code"`
+	Error   string                 `json:"error"`
+	Details []apperrors.FieldError `json:"details,omitempty"`
+}
+
+// ErrorHandler writes the last error a handler attached with c.Error
+// as an ErrorResponse, unless the handler already wrote a response
+func ErrorHandler() gin.HandlerFunc {
+	useJSONFieldNames.Do(func() {
+		if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+			v.RegisterTagNameFunc(jsonFieldName)
+		}
+	})
+
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		status, body := ErrorBody(c.Errors.Last().Err)
+		if status == http.StatusInternalServerError {
+			log.Printf("[%s] %s %s: %v", requestid.FromContext(c.Request.Context()), c.Request.Method, c.FullPath(), c.Errors.Last().Err)
+		}
+		c.JSON(status, body)
+	}
+}
+
+// ErrorBody maps err to an HTTP status and response body
+func ErrorBody(err error) (int, ErrorResponse) {
+	var validationErrs validator.ValidationErrors
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return http.StatusRequestEntityTooLarge, payloadTooLarge(maxBytesErr.Limit)
+	case errors.As(err, &validationErrs):
+		return http.StatusBadRequest, ErrorResponse{
+			Code:    CodeValidation,
+			Error:   "invalid request",
+			Details: fieldErrors(validationErrs),
+		}
+	case errors.Is(err, apperrors.ErrValidation):
+		return http.StatusBadRequest, ErrorResponse{Code: CodeValidation, Error: err.Error(), Details: apperrors.Fields(err)}
+	case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound, ErrorResponse{Code: CodeNotFound, Error: err.Error()}
+	case errors.Is(err, apperrors.ErrConflict):
+		return http.StatusConflict, ErrorResponse{Code: CodeConflict, Error: err.Error()}
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		return http.StatusUnauthorized, ErrorResponse{Code: CodeUnauthorized, Error: err.Error()}
+	case errors.Is(err, apperrors.ErrForbidden):
+		return http.StatusForbidden, ErrorResponse{Code: CodeForbidden, Error: err.Error()}
+	case errors.Is(err, apperrors.ErrNotAcceptable):
+		return http.StatusNotAcceptable, ErrorResponse{Code: CodeNotAcceptable, Error: err.Error()}
+	default:
+		// Unclassified errors may carry driver details, so they are logged rather than returned
+		return http.StatusInternalServerError, ErrorResponse{Code: CodeInternal, Error: "internal server error"}
+	}
+}
+
+// BindError classifies a ShouldBind* failure as a validation error
+func BindError(err error) error {
+	var validationErrs validator.ValidationErrors
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &validationErrs) || errors.As(err, &maxBytesErr) {
+		return err
+	}
+	return apperrors.Wrap(apperrors.ErrValidation, "invalid request body", err)
+}
+
+func fieldErrors(errs validator.ValidationErrors) []apperrors.FieldError {
+	fields := make([]apperrors.FieldError, 0, len(errs))
+	for _, fe := range errs {
+		message := "failed on " + fe.Tag()
+		if fe.Param() != "" {
+			message += "=" + fe.Param()
+		}
+		fields = append(fields, apperrors.FieldError{Field: fe.Field(), Message: message})
+	}
+	return fields
+}
+
+var useJSONFieldNames sync.Once
+
+// jsonFieldName makes validation errors report fields by their JSON name
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
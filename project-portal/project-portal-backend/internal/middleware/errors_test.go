@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func serveError(t *testing.T, err error) (int, ErrorResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.GET("/", func(c *gin.Context) { c.Error(err) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var body ErrorResponse
+	if decodeErr := json.Unmarshal(w.Body.Bytes(), &body); decodeErr != nil {
+		t.Fatalf("response is not an ErrorResponse: %s", w.Body.String())
+	}
+	return w.Code, body
+}
+
+func TestErrorHandler_MapsKinds(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		message string
+	}{
+		{"validation", apperrors.Validation("name is required"), http.StatusBadRequest, CodeValidation, "name is required"},
+		{"not found", apperrors.NotFound("report not found"), http.StatusNotFound, CodeNotFound, "report not found"},
+		{"gorm not found", fmt.Errorf("lookup: %w", gorm.ErrRecordNotFound), http.StatusNotFound, CodeNotFound, "lookup: record not found"},
+		{"conflict", apperrors.Conflict("already cancelled"), http.StatusConflict, CodeConflict, "already cancelled"},
+		{"forbidden", fmt.Errorf("%w to report", apperrors.Forbidden("access denied")), http.StatusForbidden, CodeForbidden, "access denied to report"},
+		{"unauthorized", apperrors.Unauthorized("invalid token"), http.StatusUnauthorized, CodeUnauthorized, "invalid token"},
+		{"not acceptable", apperrors.NotAcceptable("unsupported media type"), http.StatusNotAcceptable, CodeNotAcceptable, "unsupported media type"},
+		{"wrapped cause", apperrors.Wrap(apperrors.ErrNotFound, "schedule not found", errors.New("no rows")), http.StatusNotFound, CodeNotFound, "schedule not found: no rows"},
+		{"unclassified", errors.New("pq: connection refused"), http.StatusInternalServerError, CodeInternal, "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := serveError(t, tt.err)
+			if status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+			if body.Code != tt.code {
+				t.Errorf("code = %q, want %q", body.Code, tt.code)
+			}
+			if body.Error != tt.message {
+				t.Errorf("error = %q, want %q", body.Error, tt.message)
+			}
+		})
+	}
+}
+
+func TestErrorHandler_FieldDetails(t *testing.T) {
+	err := apperrors.Wrap(apperrors.ErrValidation, "invalid report configuration", apperrors.Field("dataset", "is required"))
+	status, body := serveError(t, err)
+	if status != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", status)
+	}
+	if len(body.Details) != 1 || body.Details[0].Field != "dataset" || body.Details[0].Message != "is required" {
+		t.Errorf("unexpected details: %+v", body.Details)
+	}
+}
+
+func TestErrorHandler_BindingErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.POST("/", func(c *gin.Context) {
+		var req struct {
+			Email string `json:"email" binding:"required,email"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(BindError(err))
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	post := func(payload string) (*httptest.ResponseRecorder, ErrorResponse) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload)))
+		var body ErrorResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w, body
+	}
+
+	w, body := post(`{"email":"not-an-email"}`)
+	if w.Code != http.StatusBadRequest || body.Code != CodeValidation {
+		t.Fatalf("expected validation error, got %d %+v", w.Code, body)
+	}
+	if len(body.Details) != 1 || body.Details[0].Field != "email" || body.Details[0].Message != "failed on email" {
+		t.Errorf("unexpected details: %+v", body.Details)
+	}
+
+	w, body = post(`{`)
+	if w.Code != http.StatusBadRequest || body.Code != CodeValidation {
+		t.Errorf("expected malformed JSON to be a validation error, got %d %+v", w.Code, body)
+	}
+}
+
+func TestErrorHandler_LeavesWrittenResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.GET("/", func(c *gin.Context) {
+		c.Error(errors.New("logged only"))
+		c.JSON(http.StatusAccepted, gin.H{"status": "queued"})
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusAccepted {
+		t.Errorf("expected handler's own response to stand, got %d", w.Code)
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"carbon-scribe/project-portal/project-portal-backend/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxRequestIDLength bounds client-supplied IDs so they cannot bloat logs
+const maxRequestIDLength = 128
+
+// RequestID stores the caller's X-Request-ID (or a generated one) in the
+// request context and echoes it in the response
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = requestid.New()
+		}
+
+		c.Request = c.Request.WithContext(requestid.WithID(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+		c.Next()
+	}
+}
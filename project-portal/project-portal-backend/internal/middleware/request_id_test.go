@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID())
+	var seen string
+	router.GET("/", func(c *gin.Context) {
+		seen = requestid.FromContext(c.Request.Context())
+	})
+
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{"echoes caller ID", "abc-123", true},
+		{"generates when missing", "", false},
+		{"replaces oversized ID", strings.Repeat("x", maxRequestIDLength+1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(requestid.Header, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			echoed := w.Header().Get(requestid.Header)
+			if echoed == "" || echoed != seen {
+				t.Fatalf("expected response header %q to match context ID %q", echoed, seen)
+			}
+			if (echoed == tt.incoming) != tt.keep {
+				t.Errorf("keep caller ID = %v, want %v", echoed == tt.incoming, tt.keep)
+			}
+		})
+	}
+}
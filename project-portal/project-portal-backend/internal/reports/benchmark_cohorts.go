@@ -0,0 +1,76 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+)
+
+// CompareCohorts compares a project against the benchmark of each cohort. Every
+// cohort is compared independently, so the project's percentile is per cohort.
+func (s *service) CompareCohorts(ctx context.Context, req CohortComparisonRequest) (*CohortComparisonResponse, error) {
+	seen := make(map[string]bool, len(req.Cohorts))
+	for i := range req.Cohorts {
+		cohort := &req.Cohorts[i]
+		if cohort.Name == "" {
+			cohort.Name = cohortName(*cohort)
+		}
+		if seen[cohort.Name] {
+			return nil, apperrors.Field(fmt.Sprintf("cohorts[%d].name", i), fmt.Sprintf("cohort %q is listed twice", cohort.Name))
+		}
+		seen[cohort.Name] = true
+	}
+
+	projectMetrics := s.getProjectMetrics(ctx, req.ProjectID)
+	response := &CohortComparisonResponse{
+		ProjectID:      req.ProjectID,
+		ProjectMetrics: projectMetrics,
+		Cohorts:        make([]CohortComparison, 0, len(req.Cohorts)),
+	}
+
+	for _, cohort := range req.Cohorts {
+		comparison := CohortComparison{
+			Cohort:         cohort,
+			Benchmarks:     []BenchmarkResult{},
+			PercentileRank: map[string]float64{},
+			GapAnalysis:    []GapAnalysisResult{},
+		}
+
+		benchmark, err := s.repo.GetBenchmarkByCategory(ctx, cohort.Category, cohort.Methodology, cohort.Region, cohort.Year)
+		if err != nil {
+			response.Cohorts = append(response.Cohorts, comparison)
+			continue
+		}
+
+		var benchmarkData []BenchmarkData
+		if err := json.Unmarshal(benchmark.Data, &benchmarkData); err != nil {
+			return nil, fmt.Errorf("failed to parse benchmark data of %s: %w", benchmark.ID, err)
+		}
+
+		comparison.Matched = true
+		comparison.BenchmarkID = &benchmark.ID
+		comparison.BenchmarkName = benchmark.Name
+		comparison.BenchmarkYear = benchmark.Year
+		comparison.Benchmarks, comparison.PercentileRank, comparison.GapAnalysis = compareMetrics(projectMetrics, benchmarkData)
+		response.Cohorts = append(response.Cohorts, comparison)
+	}
+
+	return response, nil
+}
+
+// cohortName labels a cohort by its selectors, e.g. "forestry / Brazil / 2024"
+func cohortName(cohort BenchmarkCohort) string {
+	parts := []string{cohort.Category}
+	for _, part := range []string{cohort.Methodology, cohort.Region} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if cohort.Year > 0 {
+		parts = append(parts, strconv.Itoa(cohort.Year))
+	}
+	return stringJoin(parts, " / ")
+}
@@ -0,0 +1,99 @@
+package reports
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+func seedBenchmark(t *testing.T, repo *fakeRepository, dataset BenchmarkDataset, data ...BenchmarkData) uuid.UUID {
+	t.Helper()
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("failed to encode benchmark data: %v", err)
+	}
+	dataset.ID = uuid.New()
+	dataset.Category = "forestry"
+	dataset.IsActive = true
+	dataset.Data = datatypes.JSON(raw)
+	repo.benchmarks[dataset.ID] = &dataset
+	return dataset.ID
+}
+
+func TestCompareCohorts_GroupsResultsByCohort(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+
+	// The project's sample sequestration rate is 15.5
+	brazil := seedBenchmark(t, repo, BenchmarkDataset{Name: "Brazil forestry", Region: "Brazil", Year: 2024},
+		BenchmarkData{Metric: "carbon_sequestration_rate", Value: 12, Percentile: 50, LowerBound: 8, UpperBound: 20})
+	vm0047 := seedBenchmark(t, repo, BenchmarkDataset{Name: "VM0047 projects", Methodology: "VM0047", Year: 2024},
+		BenchmarkData{Metric: "carbon_sequestration_rate", Value: 22, Percentile: 50, LowerBound: 10, UpperBound: 30})
+
+	w := doAs(router, uuid.New(), http.MethodPost, "/api/v1/reports/benchmark/cohort-comparison", CohortComparisonRequest{
+		ProjectID: uuid.New(),
+		Cohorts: []BenchmarkCohort{
+			{Category: "forestry", Region: "Brazil"},
+			{Name: "Same methodology", Category: "forestry", Methodology: "VM0047"},
+			{Category: "forestry", Year: 2019},
+		},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response CohortComparisonResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if len(response.Cohorts) != 3 {
+		t.Fatalf("expected one comparison per cohort, got %+v", response.Cohorts)
+	}
+
+	region := response.Cohorts[0]
+	if region.Cohort.Name != "forestry / Brazil" || !region.Matched || *region.BenchmarkID != brazil {
+		t.Errorf("unexpected region cohort: %+v", region)
+	}
+	if got := region.PercentileRank["carbon_sequestration_rate"]; got != 62.5 {
+		t.Errorf("expected the 62.5th percentile in the region cohort, got %v", got)
+	}
+	if len(region.Benchmarks) != 1 || region.Benchmarks[0].PerformanceLevel != "above" || len(region.GapAnalysis) != 0 {
+		t.Errorf("expected the project above its region, got %+v", region)
+	}
+
+	methodology := response.Cohorts[1]
+	if methodology.Cohort.Name != "Same methodology" || *methodology.BenchmarkID != vm0047 {
+		t.Errorf("unexpected methodology cohort: %+v", methodology)
+	}
+	if got := methodology.PercentileRank["carbon_sequestration_rate"]; math.Abs(got-27.5) > 1e-9 {
+		t.Errorf("expected the 27.5th percentile in the methodology cohort, got %v", got)
+	}
+	if len(methodology.GapAnalysis) != 1 || methodology.GapAnalysis[0].Priority != "high" {
+		t.Errorf("expected a high priority gap against the methodology cohort, got %+v", methodology.GapAnalysis)
+	}
+
+	vintage := response.Cohorts[2]
+	if vintage.Matched || vintage.BenchmarkID != nil || vintage.Cohort.Name != "forestry / 2019" {
+		t.Errorf("expected the 2019 cohort to have no benchmark, got %+v", vintage)
+	}
+}
+
+func TestCompareCohorts_RejectsInvalidRequests(t *testing.T) {
+	router := newSharingTestRouter(newFakeRepository())
+
+	for name, req := range map[string]CohortComparisonRequest{
+		"no cohorts":       {ProjectID: uuid.New()},
+		"missing category": {ProjectID: uuid.New(), Cohorts: []BenchmarkCohort{{Region: "Brazil"}}},
+		"duplicate cohort": {ProjectID: uuid.New(), Cohorts: []BenchmarkCohort{
+			{Category: "forestry", Region: "Brazil"}, {Category: "forestry", Region: "Brazil"},
+		}},
+	} {
+		w := doAs(router, uuid.New(), http.MethodPost, "/api/v1/reports/benchmark/cohort-comparison", req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", name, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package reports
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestCancelExecution_StopsRunningQuery(t *testing.T) {
+	repo := newFakeRepository()
+	started := make(chan struct{})
+	stopped := make(chan error, 1)
+	repo.queryFunc = func(ctx context.Context, config ReportConfig) ([]map[string]interface{}, int64, error) {
+		close(started)
+		select {
+		case <-ctx.Done():
+			stopped <- ctx.Err()
+			return nil, 0, ctx.Err()
+		case <-time.After(10 * time.Second):
+			stopped <- nil
+			return nil, 0, nil
+		}
+	}
+	svc := NewService(repo, nil)
+
+	owner := uuid.New()
+	ctx := context.Background()
+	report, err := svc.CreateReport(ctx, owner, CreateReportRequest{
+		Name:   "Slow report",
+		Config: ReportConfig{Dataset: "transactions", Fields: []FieldConfig{{Name: "amount"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	execution, err := svc.ExecuteReport(ctx, owner, report.ID, ExecuteReportRequest{})
+	if err != nil {
+		t.Fatalf("ExecuteReport failed: %v", err)
+	}
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("query never started")
+	}
+
+	if err := svc.CancelExecution(ctx, owner, execution.ID); err != nil {
+		t.Fatalf("CancelExecution failed: %v", err)
+	}
+
+	select {
+	case err := <-stopped:
+		if err != context.Canceled {
+			t.Errorf("expected query context to be cancelled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("query kept running after cancellation")
+	}
+
+	// Give the goroutine a moment to unwind, then check it did not overwrite the cancellation
+	time.Sleep(20 * time.Millisecond)
+	final, err := svc.GetExecution(ctx, owner, execution.ID)
+	if err != nil {
+		t.Fatalf("GetExecution failed: %v", err)
+	}
+	if final.Status != StatusFailed || final.ErrorMessage != "Cancelled by user" {
+		t.Errorf("expected cancelled execution, got status=%s message=%q", final.Status, final.ErrorMessage)
+	}
+}
+
+// slowStore holds each Put until the run is cancelled and the test releases it, so the
+// run finishes after the cancellation was recorded
+type slowStore struct {
+	mu      sync.Mutex
+	files   map[string][]byte
+	putting chan struct{}
+	release chan struct{}
+}
+
+func (s *slowStore) Put(ctx context.Context, key string, data []byte) error {
+	close(s.putting)
+	<-ctx.Done()
+	<-s.release
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[key] = data
+	return nil
+}
+
+func (s *slowStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.files[key], nil
+}
+
+func (s *slowStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.files, key)
+	return nil
+}
+
+func TestCancelExecution_LateCompletionKeepsCancellation(t *testing.T) {
+	repo := newFakeRepository()
+	repo.queryRows = []map[string]interface{}{{"amount": 10}}
+	svc := NewService(repo, nil)
+	store := &slowStore{files: map[string][]byte{}, putting: make(chan struct{}), release: make(chan struct{})}
+	svc.ConfigureDownloads(store, NewDownloadSigner("secret", time.Minute))
+
+	owner := uuid.New()
+	ctx := context.Background()
+	report, err := svc.CreateReport(ctx, owner, CreateReportRequest{
+		Name:   "Late report",
+		Config: ReportConfig{Dataset: "transactions", Fields: []FieldConfig{{Name: "amount"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	execution, err := svc.ExecuteReport(ctx, owner, report.ID, ExecuteReportRequest{Format: FormatJSON})
+	if err != nil {
+		t.Fatalf("ExecuteReport failed: %v", err)
+	}
+
+	select {
+	case <-store.putting:
+	case <-time.After(time.Second):
+		t.Fatal("export was never stored")
+	}
+
+	// The run is past its last cancellation check, so it goes on to record a completion
+	if err := svc.CancelExecution(ctx, owner, execution.ID); err != nil {
+		t.Fatalf("CancelExecution failed: %v", err)
+	}
+	close(store.release)
+	if err := svc.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	final, err := svc.GetExecution(ctx, owner, execution.ID)
+	if err != nil {
+		t.Fatalf("GetExecution failed: %v", err)
+	}
+	if final.Status != StatusFailed || final.ErrorMessage != "Cancelled by user" {
+		t.Errorf("expected cancellation to stand, got status=%s message=%q", final.Status, final.ErrorMessage)
+	}
+	if len(store.files) != 0 {
+		t.Errorf("expected the late export to be removed, got %d files", len(store.files))
+	}
+}
+
+func TestFinishExecution_OnlyUpdatesRunningExecutions(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("failed to open dry-run db: %v", err)
+	}
+	var sql string
+	db.Callback().Update().After("gorm:update").Register("test:capture", func(tx *gorm.DB) {
+		sql = tx.Statement.SQL.String()
+	})
+
+	execution := &ReportExecution{ID: uuid.New(), Status: StatusCompleted, TriggeredAt: time.Now()}
+	if _, err := NewRepository(db).FinishExecution(context.Background(), execution); err != nil {
+		t.Fatalf("FinishExecution failed: %v", err)
+	}
+	for _, want := range []string{"UPDATE \"report_executions\"", "\"status\"=", "status IN ($", "\"id\" = $"} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in %q", want, sql)
+		}
+	}
+}
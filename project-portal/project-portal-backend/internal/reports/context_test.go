@@ -0,0 +1,147 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/requestid"
+
+	"github.com/google/uuid"
+)
+
+// blockingQuery makes the fake repository's query wait for ctx, reporting the context it ran under
+func blockingQuery(repo *fakeRepository) (started chan context.Context, stopped chan error) {
+	started = make(chan context.Context, 1)
+	stopped = make(chan error, 1)
+	repo.queryFunc = func(ctx context.Context, config ReportConfig) ([]map[string]interface{}, int64, error) {
+		started <- ctx
+		select {
+		case <-ctx.Done():
+			stopped <- ctx.Err()
+			return nil, 0, ctx.Err()
+		case <-time.After(10 * time.Second):
+			stopped <- nil
+			return nil, 0, nil
+		}
+	}
+	return started, stopped
+}
+
+func createSlowReport(t *testing.T, svc Service, ctx context.Context, owner uuid.UUID) *ReportDefinition {
+	t.Helper()
+	report, err := svc.CreateReport(ctx, owner, CreateReportRequest{
+		Name:   "Slow report",
+		Config: ReportConfig{Dataset: "transactions", Fields: []FieldConfig{{Name: "amount"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+	return report
+}
+
+func TestExecuteReport_OutlivesRequestButKeepsItsValues(t *testing.T) {
+	repo := newFakeRepository()
+	started, stopped := blockingQuery(repo)
+	svc := NewService(repo, nil)
+
+	owner, org := uuid.New(), uuid.New()
+	reqCtx, cancelRequest := context.WithCancel(requestid.WithID(WithTenant(context.Background(), Tenant{OrganizationID: &org}), "req-123"))
+	report := createSlowReport(t, svc, reqCtx, owner)
+
+	execution, err := svc.ExecuteReport(reqCtx, owner, report.ID, ExecuteReportRequest{})
+	if err != nil {
+		t.Fatalf("ExecuteReport failed: %v", err)
+	}
+
+	queryCtx := <-started
+	if got := requestid.FromContext(queryCtx); got != "req-123" {
+		t.Errorf("expected request ID to reach the query, got %q", got)
+	}
+	if tenant := TenantFromContext(queryCtx); tenant.OrganizationID == nil || *tenant.OrganizationID != org {
+		t.Errorf("expected tenant to reach the query, got %+v", tenant)
+	}
+	if _, ok := queryCtx.Deadline(); !ok {
+		t.Error("expected the execution to have its own deadline")
+	}
+
+	// The HTTP request finishing must not abort the report
+	cancelRequest()
+	select {
+	case err := <-stopped:
+		t.Fatalf("query stopped when the request ended: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := svc.CancelExecution(WithTenant(context.Background(), Tenant{OrganizationID: &org}), owner, execution.ID); err != nil {
+		t.Fatalf("CancelExecution failed: %v", err)
+	}
+	<-stopped
+}
+
+func TestShutdown_CancelsRunningExecutions(t *testing.T) {
+	repo := newFakeRepository()
+	started, stopped := blockingQuery(repo)
+	svc := NewService(repo, nil)
+
+	owner := uuid.New()
+	ctx := context.Background()
+	report := createSlowReport(t, svc, ctx, owner)
+
+	execution, err := svc.ExecuteReport(ctx, owner, report.ID, ExecuteReportRequest{})
+	if err != nil {
+		t.Fatalf("ExecuteReport failed: %v", err)
+	}
+	<-started
+
+	// The deadline passes while the query is still running, so it is cancelled
+	shutdownCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := svc.Shutdown(shutdownCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected Shutdown to report the deadline, got %v", err)
+	}
+
+	select {
+	case err := <-stopped:
+		if err != context.Canceled {
+			t.Errorf("expected query context to be cancelled, got %v", err)
+		}
+	default:
+		t.Fatal("Shutdown returned before the execution stopped")
+	}
+
+	final, err := svc.GetExecution(ctx, owner, execution.ID)
+	if err != nil {
+		t.Fatalf("GetExecution failed: %v", err)
+	}
+	if final.Status != StatusFailed || final.ErrorMessage != errServiceShutdown.Error() || final.CompletedAt == nil {
+		t.Errorf("expected execution failed by shutdown, got status=%s message=%q", final.Status, final.ErrorMessage)
+	}
+
+	if _, err := svc.ExecuteReport(ctx, owner, report.ID, ExecuteReportRequest{}); !errors.Is(err, errServiceShutdown) {
+		t.Errorf("expected new executions to be refused after shutdown, got %v", err)
+	}
+}
+
+func TestShutdown_WaitsForFinishingExecutions(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+
+	owner := uuid.New()
+	ctx := context.Background()
+	report := createSlowReport(t, svc, ctx, owner)
+
+	execution, err := svc.ExecuteReport(ctx, owner, report.ID, ExecuteReportRequest{})
+	if err != nil {
+		t.Fatalf("ExecuteReport failed: %v", err)
+	}
+
+	if err := svc.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	final, _ := svc.GetExecution(ctx, owner, execution.ID)
+	if final.Status != StatusCompleted {
+		t.Errorf("expected execution to complete before shutdown returned, got %s", final.Status)
+	}
+}
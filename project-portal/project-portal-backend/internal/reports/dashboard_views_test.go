@@ -0,0 +1,162 @@
+package reports
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func decodeView(t *testing.T, body []byte) DashboardView {
+	t.Helper()
+	var view DashboardView
+	if err := json.Unmarshal(body, &view); err != nil {
+		t.Fatalf("invalid view response: %v", err)
+	}
+	return view
+}
+
+func createView(t *testing.T, router *gin.Engine, userID uuid.UUID, req SaveDashboardViewRequest) DashboardView {
+	t.Helper()
+	w := doAs(router, userID, http.MethodPost, "/api/v1/reports/dashboard/views", req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create view failed: %d %s", w.Code, w.Body.String())
+	}
+	return decodeView(t, w.Body.Bytes())
+}
+
+func TestDashboardViews_CreateAndLoadWithWidgets(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+	user := uuid.New()
+
+	shared := &DashboardWidget{ID: uuid.New(), Title: "Credits issued", WidgetType: "metric"}
+	own := &DashboardWidget{ID: uuid.New(), UserID: &user, Title: "Revenue", WidgetType: "chart"}
+	repo.widgets[shared.ID] = shared
+	repo.widgets[own.ID] = own
+
+	created := createView(t, router, user, SaveDashboardViewRequest{
+		Name:      "Quarterly review",
+		WidgetIDs: []uuid.UUID{own.ID, shared.ID},
+		Filters:   []FilterConfig{{Field: "region", Operator: "eq", Value: "EU"}},
+		TimeRange: &DashboardTimeRange{Preset: "90d"},
+	})
+	if created.IsDefault {
+		t.Error("view must not become default unless asked")
+	}
+
+	w := doAs(router, user, http.MethodGet, "/api/v1/reports/dashboard/views/"+created.ID.String(), nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("get view failed: %d %s", w.Code, w.Body.String())
+	}
+	loaded := decodeView(t, w.Body.Bytes())
+	if len(loaded.Widgets) != 2 || loaded.Widgets[0].ID != own.ID || loaded.Widgets[1].ID != shared.ID {
+		t.Errorf("expected widgets in saved order, got %+v", loaded.Widgets)
+	}
+
+	var timeRange DashboardTimeRange
+	if err := json.Unmarshal(loaded.TimeRange, &timeRange); err != nil || timeRange.Preset != "90d" {
+		t.Errorf("expected preset 90d, got %s", loaded.TimeRange)
+	}
+
+	// Views are private to their owner
+	if w := doAs(router, uuid.New(), http.MethodGet, "/api/v1/reports/dashboard/views/"+created.ID.String(), nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for another user, got %d", w.Code)
+	}
+}
+
+func TestDashboardViews_DefaultSwitching(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+	user := uuid.New()
+
+	if w := doAs(router, user, http.MethodGet, "/api/v1/reports/dashboard/views/default", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 without a default, got %d", w.Code)
+	}
+
+	first := createView(t, router, user, SaveDashboardViewRequest{Name: "Overview", IsDefault: true})
+	if !first.IsDefault {
+		t.Fatal("expected first view to be default")
+	}
+	second := createView(t, router, user, SaveDashboardViewRequest{Name: "Finance"})
+
+	w := doAs(router, user, http.MethodPost, "/api/v1/reports/dashboard/views/"+second.ID.String()+"/default", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("set default failed: %d %s", w.Code, w.Body.String())
+	}
+	if !decodeView(t, w.Body.Bytes()).IsDefault {
+		t.Error("expected response to mark the view default")
+	}
+
+	w = doAs(router, user, http.MethodGet, "/api/v1/reports/dashboard/views/default", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("get default failed: %d %s", w.Code, w.Body.String())
+	}
+	if got := decodeView(t, w.Body.Bytes()); got.ID != second.ID {
+		t.Errorf("expected %s as default, got %s", second.ID, got.ID)
+	}
+	if repo.views[first.ID].IsDefault {
+		t.Error("previous default must be cleared")
+	}
+
+	// Updating without is_default keeps the current default
+	w = doAs(router, user, http.MethodPut, "/api/v1/reports/dashboard/views/"+second.ID.String(), SaveDashboardViewRequest{Name: "Finance (EU)"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("update failed: %d %s", w.Code, w.Body.String())
+	}
+	if !repo.views[second.ID].IsDefault || repo.views[second.ID].Name != "Finance (EU)" {
+		t.Errorf("unexpected view after update: %+v", repo.views[second.ID])
+	}
+
+	// Another user can neither see nor claim the view
+	if w := doAs(router, uuid.New(), http.MethodPost, "/api/v1/reports/dashboard/views/"+first.ID.String()+"/default", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for another user, got %d", w.Code)
+	}
+
+	if w := doAs(router, user, http.MethodDelete, "/api/v1/reports/dashboard/views/"+second.ID.String(), nil); w.Code != http.StatusNoContent {
+		t.Fatalf("delete failed: %d", w.Code)
+	}
+	if w := doAs(router, user, http.MethodGet, "/api/v1/reports/dashboard/views/default", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected no default after deleting it, got %d", w.Code)
+	}
+}
+
+func TestDashboardViews_RejectsInvalidViews(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+	user, other := uuid.New(), uuid.New()
+
+	foreign := &DashboardWidget{ID: uuid.New(), UserID: &other, Title: "Private", WidgetType: "metric"}
+	repo.widgets[foreign.ID] = foreign
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 3, 0)
+
+	tests := []struct {
+		name string
+		req  SaveDashboardViewRequest
+	}{
+		{"missing name", SaveDashboardViewRequest{}},
+		{"unknown widget", SaveDashboardViewRequest{Name: "x", WidgetIDs: []uuid.UUID{uuid.New()}}},
+		{"another user's widget", SaveDashboardViewRequest{Name: "x", WidgetIDs: []uuid.UUID{foreign.ID}}},
+		{"preset and window", SaveDashboardViewRequest{Name: "x", TimeRange: &DashboardTimeRange{Preset: "7d", Start: &start, End: &end}}},
+		{"reversed window", SaveDashboardViewRequest{Name: "x", TimeRange: &DashboardTimeRange{Start: &end, End: &start}}},
+		{"unknown preset", SaveDashboardViewRequest{Name: "x", TimeRange: &DashboardTimeRange{Preset: "fortnight"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doAs(router, user, http.MethodPost, "/api/v1/reports/dashboard/views", tt.req)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d %s", w.Code, w.Body.String())
+			}
+		})
+	}
+
+	if len(repo.views) != 0 {
+		t.Errorf("invalid views must not be stored, got %d", len(repo.views))
+	}
+}
@@ -0,0 +1,235 @@
+package reports
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
+	"github.com/google/uuid"
+)
+
+// exportFileExtensions is the file extension each export format is stored under
+var exportFileExtensions = map[ExportFormat]string{
+	FormatCSV:   "csv",
+	FormatExcel: "xlsx",
+	FormatPDF:   "pdf",
+	FormatJSON:  "json",
+}
+
+// FileStore keeps generated report files under opaque keys
+type FileStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// diskStore keeps report files in a local directory
+type diskStore struct {
+	dir string
+}
+
+// NewDiskStore creates a FileStore writing to dir, creating it if needed
+func NewDiskStore(dir string) (FileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create report storage directory: %w", err)
+	}
+	return &diskStore{dir: dir}, nil
+}
+
+func (d *diskStore) Put(ctx context.Context, key string, data []byte) error {
+	path, err := d.path(key)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a download never sees a partial file
+	tmp, err := os.CreateTemp(d.dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (d *diskStore) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := d.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+func (d *diskStore) Delete(ctx context.Context, key string) error {
+	path, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path maps a key to a file in the store, refusing keys that would leave it
+func (d *diskStore) path(key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || strings.HasPrefix(key, ".") {
+		return "", fmt.Errorf("invalid file key %q", key)
+	}
+	return filepath.Join(d.dir, key), nil
+}
+
+// DownloadSigner issues and checks short-lived download links. A link carries its
+// expiry and an HMAC over the execution ID and that expiry, so neither can be changed.
+type DownloadSigner struct {
+	key []byte
+	ttl time.Duration
+}
+
+var (
+	errDownloadLinkInvalid = errors.New("download link is invalid")
+	errDownloadLinkExpired = errors.New("download link has expired")
+)
+
+// NewDownloadSigner creates a signer whose links are valid for ttl. An empty secret
+// uses a random key, so links stop working when the process restarts.
+func NewDownloadSigner(secret string, ttl time.Duration) *DownloadSigner {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic(fmt.Sprintf("failed to generate download signing key: %v", err))
+		}
+	}
+	return &DownloadSigner{key: key, ttl: ttl}
+}
+
+// Sign returns the query parameters of a download link for executionID
+func (d *DownloadSigner) Sign(executionID uuid.UUID) (url.Values, time.Time) {
+	expiresAt := time.Now().Add(d.ttl).Truncate(time.Second)
+	expires := expiresAt.Unix()
+	return url.Values{
+		"expires":   {strconv.FormatInt(expires, 10)},
+		"signature": {d.signature(executionID, expires)},
+	}, expiresAt
+}
+
+// Verify checks a link's signature before its expiry, so a tampered link is never
+// reported as merely expired
+func (d *DownloadSigner) Verify(executionID uuid.UUID, expires int64, signature string) error {
+	expected := d.signature(executionID, expires)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return errDownloadLinkInvalid
+	}
+	if time.Now().Unix() > expires {
+		return errDownloadLinkExpired
+	}
+	return nil
+}
+
+func (d *DownloadSigner) signature(executionID uuid.UUID, expires int64) string {
+	mac := hmac.New(sha256.New, d.key)
+	fmt.Fprintf(mac, "%s\n%d", executionID, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ReportFile is a stored export ready to be served
+type ReportFile struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// ConfigureDownloads stores finished exports in files and signs their download links.
+// Without it exports are generated but not kept.
+func (s *service) ConfigureDownloads(files FileStore, signer *DownloadSigner) {
+	s.files = files
+	s.downloads = signer
+}
+
+// SignDownload returns the query of a short-lived download link for a completed execution
+func (s *service) SignDownload(execution *ReportExecution) (url.Values, time.Time, bool) {
+	if s.downloads == nil || s.files == nil || execution.Status != StatusCompleted || execution.FileKey == "" {
+		return nil, time.Time{}, false
+	}
+	query, expiresAt := s.downloads.Sign(execution.ID)
+	return query, expiresAt, true
+}
+
+// OpenDownload returns the file of an execution after checking its download link.
+// The link itself grants access, so no tenant check applies here.
+func (s *service) OpenDownload(ctx context.Context, executionID uuid.UUID, expires int64, signature string) (*ReportFile, error) {
+	if s.downloads == nil || s.files == nil {
+		return nil, apperrors.NotFound("report downloads are not enabled")
+	}
+	if err := s.downloads.Verify(executionID, expires, signature); err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrForbidden, err.Error(), err)
+	}
+
+	execution, err := s.repo.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "execution not found", err)
+	}
+	if execution.Status != StatusCompleted || execution.FileKey == "" {
+		return nil, apperrors.NotFound("execution has no file to download")
+	}
+
+	data, err := s.files.Get(ctx, execution.FileKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report file: %w", err)
+	}
+
+	return &ReportFile{
+		Name:        execution.FileKey,
+		ContentType: fileContentType(execution.FileKey),
+		Data:        data,
+	}, nil
+}
+
+// storeExport saves an execution's export and records where it was put
+func (s *service) storeExport(ctx context.Context, execution *ReportExecution, format ExportFormat, data []byte) error {
+	if s.files == nil {
+		return nil
+	}
+	key := execution.ID.String() + "." + exportFileExtensions[format]
+	if err := s.files.Put(ctx, key, data); err != nil {
+		return err
+	}
+	execution.FileKey = key
+	return nil
+}
+
+// fileContentType returns the media type of a stored export from its extension
+func fileContentType(key string) string {
+	extension := strings.TrimPrefix(filepath.Ext(key), ".")
+	for format, ext := range exportFileExtensions {
+		if ext != extension {
+			continue
+		}
+		for _, candidate := range exportMediaTypes {
+			if candidate.format == format {
+				return candidate.mediaType
+			}
+		}
+	}
+	return "application/octet-stream"
+}
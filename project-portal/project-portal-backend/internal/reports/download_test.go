@@ -0,0 +1,175 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// completedDownload runs a JSON export to completion with downloads enabled
+func completedDownload(t *testing.T) (*gin.Engine, *DownloadSigner, *ReportExecution) {
+	t.Helper()
+
+	repo := newFakeRepository()
+	repo.queryRows = []map[string]interface{}{{"name": "Alpha"}}
+	svc := NewService(repo, nil)
+
+	files, err := NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskStore failed: %v", err)
+	}
+	signer := NewDownloadSigner("test-secret", time.Minute)
+	svc.ConfigureDownloads(files, signer)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	report, err := svc.CreateReport(ctx, userID, CreateReportRequest{
+		Name:   "Projects",
+		Config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+	started, err := svc.ExecuteReport(ctx, userID, report.ID, ExecuteReportRequest{Format: FormatJSON})
+	if err != nil {
+		t.Fatalf("ExecuteReport failed: %v", err)
+	}
+	if err := svc.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	execution := repo.executions[started.ID]
+	if execution.Status != StatusCompleted || execution.FileKey != started.ID.String()+".json" {
+		t.Fatalf("expected a completed execution with a stored file, got %+v", execution)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	NewHandler(svc).RegisterRoutes(router.Group("/api/v1"), testAuth())
+	return router, signer, execution
+}
+
+func getDownload(router *gin.Engine, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w
+}
+
+func TestDownloadExecution_ValidLink(t *testing.T) {
+	router, _, execution := completedDownload(t)
+
+	w := doAs(router, *execution.TriggeredBy, http.MethodGet, "/api/v1/reports/executions/"+execution.ID.String(), nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body ReportExecution
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if body.DownloadURL == "" || body.DownloadExpiresAt == nil {
+		t.Fatalf("expected a signed download link, got %+v", body)
+	}
+	if !strings.HasPrefix(body.DownloadURL, "/api/v1/reports/executions/"+execution.ID.String()+"/download?") {
+		t.Errorf("unexpected download URL %q", body.DownloadURL)
+	}
+
+	w = getDownload(router, body.DownloadURL)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected application/json, got %q", got)
+	}
+	if !strings.Contains(w.Header().Get("Content-Disposition"), execution.FileKey) {
+		t.Errorf("expected the file name in Content-Disposition, got %q", w.Header().Get("Content-Disposition"))
+	}
+	if got := strings.TrimSpace(w.Body.String()); got != `[{"name":"Alpha"}]` {
+		t.Errorf("unexpected file contents %s", got)
+	}
+}
+
+func TestDownloadExecution_ExpiredLink(t *testing.T) {
+	router, signer, execution := completedDownload(t)
+
+	expires := time.Now().Add(-time.Second).Unix()
+	target := downloadPath(execution.ID, expires, signer.signature(execution.ID, expires))
+
+	w := getDownload(router, target)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "expired") {
+		t.Errorf("expected an expiry error, got %s", w.Body.String())
+	}
+}
+
+func TestDownloadExecution_TamperedLink(t *testing.T) {
+	router, signer, execution := completedDownload(t)
+
+	query, _ := signer.Sign(execution.ID)
+	expires, _ := strconv.ParseInt(query.Get("expires"), 10, 64)
+	signature := query.Get("signature")
+
+	flipped := []byte(signature)
+	if flipped[0] == 'A' {
+		flipped[0] = 'B'
+	} else {
+		flipped[0] = 'A'
+	}
+
+	cases := map[string]string{
+		"altered signature":   downloadPath(execution.ID, expires, string(flipped)),
+		"extended expiry":     downloadPath(execution.ID, expires+3600, signature),
+		"other execution":     downloadPath(uuid.New(), expires, signature),
+		"signed by other key": downloadPath(execution.ID, expires, NewDownloadSigner("other-secret", time.Minute).signature(execution.ID, expires)),
+	}
+	for name, target := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := getDownload(router, target)
+			if w.Code != http.StatusForbidden {
+				t.Fatalf("expected 403, got %d: %s", w.Code, w.Body.String())
+			}
+			if strings.Contains(w.Body.String(), "Alpha") {
+				t.Error("tampered link must not serve the file")
+			}
+		})
+	}
+
+	t.Run("missing signature", func(t *testing.T) {
+		w := getDownload(router, "/api/v1/reports/executions/"+execution.ID.String()+"/download")
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestDiskStore_RejectsKeysOutsideDirectory(t *testing.T) {
+	files, err := NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskStore failed: %v", err)
+	}
+	for _, key := range []string{"", "../escape.csv", "nested/file.csv", ".hidden"} {
+		if err := files.Put(context.Background(), key, []byte("x")); err == nil {
+			t.Errorf("expected key %q to be rejected", key)
+		}
+	}
+}
+
+func downloadPath(executionID uuid.UUID, expires int64, signature string) string {
+	return "/api/v1/reports/executions/" + executionID.String() + "/download?" + url.Values{
+		"expires":   {strconv.FormatInt(expires, 10)},
+		"signature": {signature},
+	}.Encode()
+}
@@ -23,6 +23,7 @@ type CSVConfig struct {
 	IncludeHeader bool
 	DateFormat    string
 	TimeFormat    string
+	Locale        string // Separators for numbers; empty writes plain machine-readable numbers
 	NullValue     string
 	Quote         rune
 }
@@ -40,8 +41,13 @@ func DefaultCSVConfig() CSVConfig {
 	}
 }
 
-// NewCSVExporter creates a new CSV exporter
+// NewCSVExporter creates a new CSV exporter. A locale that writes decimal
+// commas switches a comma delimiter to a semicolon, as spreadsheets in those
+// locales expect.
 func NewCSVExporter(config CSVConfig) *CSVExporter {
+	if config.Locale != "" && config.Delimiter == ',' && LookupLocale(config.Locale).Number.Decimal == "," {
+		config.Delimiter = ';'
+	}
 	return &CSVExporter{config: config}
 }
 
@@ -171,6 +177,12 @@ func (e *CSVExporter) formatValue(v interface{}) string {
 		return e.config.NullValue
 	}
 
+	if e.config.Locale != "" {
+		if s, ok := e.formatLocalizedNumber(v); ok {
+			return s
+		}
+	}
+
 	switch val := v.(type) {
 	case string:
 		return val
@@ -200,6 +212,23 @@ func (e *CSVExporter) formatValue(v interface{}) string {
 	}
 }
 
+// formatLocalizedNumber writes numeric values with the configured locale's separators
+func (e *CSVExporter) formatLocalizedNumber(v interface{}) (string, bool) {
+	number := LookupLocale(e.config.Locale).Number
+	switch val := reflect.ValueOf(v); val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number.FormatInt(val.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return number.FormatUint(val.Uint()), true
+	case reflect.Float32:
+		f, _ := strconv.ParseFloat(strconv.FormatFloat(val.Float(), 'g', -1, 32), 64)
+		return number.FormatFloat(f), true
+	case reflect.Float64:
+		return number.FormatFloat(val.Float()), true
+	}
+	return "", false
+}
+
 // ExportWithMapping exports data with custom column mapping
 func (e *CSVExporter) ExportWithMapping(ctx context.Context, data []map[string]interface{}, mapping []ColumnMapping) ([]byte, error) {
 	var buf bytes.Buffer
@@ -18,7 +18,7 @@ type ExcelExporter struct {
 type ExcelConfig struct {
 	SheetName     string
 	IncludeHeader bool
-	DateFormat    string
+	DateFormat    string // Excel number format for date cells, e.g. "dd.mm.yyyy"
 	TimeFormat    string
 	HeaderStyle   *ExcelStyle
 	DataStyle     *ExcelStyle
@@ -94,6 +94,13 @@ func (e *ExcelExporter) Export(ctx context.Context, data []map[string]interface{
 		return nil, fmt.Errorf("failed to create data style: %w", err)
 	}
 
+	// Dates need their own number format, otherwise Excel shows the serial number.
+	// Numbers stay numeric so Excel renders them in the reader's locale.
+	dateStyleID, err := e.createDateStyle(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create date style: %w", err)
+	}
+
 	rowOffset := 1
 
 	// Write header
@@ -114,7 +121,9 @@ func (e *ExcelExporter) Export(ctx context.Context, data []map[string]interface{
 			cell, _ := excelize.CoordinatesToCellName(colIdx+1, rowIdx+rowOffset)
 			value := e.formatValue(row[col])
 			f.SetCellValue(sheetName, cell, value)
-			if dataStyleID != 0 {
+			if _, isDate := value.(time.Time); isDate && dateStyleID != 0 {
+				f.SetCellStyle(sheetName, cell, cell, dateStyleID)
+			} else if dataStyleID != 0 {
 				f.SetCellStyle(sheetName, cell, cell, dataStyleID)
 			}
 		}
@@ -293,6 +302,25 @@ func (e *ExcelExporter) createDataStyle(f *excelize.File) (int, error) {
 	return f.NewStyle(style)
 }
 
+func (e *ExcelExporter) createDateStyle(f *excelize.File) (int, error) {
+	if e.config.DateFormat == "" {
+		return 0, nil
+	}
+
+	format := e.config.DateFormat
+	style := &excelize.Style{CustomNumFmt: &format}
+	if e.config.DataStyle != nil && e.config.DataStyle.Border {
+		style.Border = []excelize.Border{
+			{Type: "left", Color: "#D3D3D3", Style: 1},
+			{Type: "top", Color: "#D3D3D3", Style: 1},
+			{Type: "right", Color: "#D3D3D3", Style: 1},
+			{Type: "bottom", Color: "#D3D3D3", Style: 1},
+		}
+	}
+
+	return f.NewStyle(style)
+}
+
 func (e *ExcelExporter) extractColumns(row map[string]interface{}) []string {
 	columns := make([]string, 0, len(row))
 	for key := range row {
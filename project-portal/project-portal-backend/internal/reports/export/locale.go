@@ -0,0 +1,138 @@
+package export
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NumberFormat holds the separators a locale uses when writing numbers
+type NumberFormat struct {
+	Decimal   string
+	Thousands string
+}
+
+// LocaleFormat is the number and date convention for one locale
+type LocaleFormat struct {
+	Number     NumberFormat
+	DateLayout string // Go reference layout
+}
+
+// DefaultLocale is used when no locale is given or the locale is unknown
+const DefaultLocale = "en-US"
+
+// ISODateLayout is the date layout used when neither a format nor a locale is given
+const ISODateLayout = "2006-01-02"
+
+var localeFormats = map[string]LocaleFormat{
+	"en-US": {NumberFormat{".", ","}, "01/02/2006"},
+	"en-GB": {NumberFormat{".", ","}, "02/01/2006"},
+	"en-KE": {NumberFormat{".", ","}, "02/01/2006"},
+	"sw-KE": {NumberFormat{".", ","}, "02/01/2006"},
+	"de-DE": {NumberFormat{",", "."}, "02.01.2006"},
+	"fr-FR": {NumberFormat{",", " "}, "02/01/2006"},
+	"es-ES": {NumberFormat{",", "."}, "02/01/2006"},
+	"pt-BR": {NumberFormat{",", "."}, "02/01/2006"},
+	"id-ID": {NumberFormat{",", "."}, "02/01/2006"},
+}
+
+// LookupLocale returns the conventions for locale, matching "de_de" and "de" leniently
+// and falling back to DefaultLocale
+func LookupLocale(locale string) LocaleFormat {
+	tag := strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	for name, format := range localeFormats {
+		if strings.EqualFold(name, tag) {
+			return format
+		}
+	}
+	// A bare language picks the first region alphabetically, so "de" behaves like "de-DE"
+	var best string
+	for name := range localeFormats {
+		if lang, _, _ := strings.Cut(name, "-"); strings.EqualFold(lang, tag) && (best == "" || name < best) {
+			best = name
+		}
+	}
+	if best != "" {
+		return localeFormats[best]
+	}
+	return localeFormats[DefaultLocale]
+}
+
+// FormatFloat writes v with the locale's separators, using the fewest digits that round-trip
+func (n NumberFormat) FormatFloat(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	out := n.group(intPart)
+	if hasFrac {
+		out += n.Decimal + fracPart
+	}
+	return out
+}
+
+// FormatInt writes v with the locale's thousands separator
+func (n NumberFormat) FormatInt(v int64) string {
+	return n.group(strconv.FormatInt(v, 10))
+}
+
+// FormatUint writes v with the locale's thousands separator
+func (n NumberFormat) FormatUint(v uint64) string {
+	return n.group(strconv.FormatUint(v, 10))
+}
+
+// group inserts the thousands separator into a run of digits with an optional sign
+func (n NumberFormat) group(digits string) string {
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if n.Thousands == "" || len(digits) <= 3 {
+		return sign + digits
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	lead := len(digits) % 3
+	if lead > 0 {
+		b.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if i > 0 {
+			b.WriteString(n.Thousands)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// excelLayoutTokens maps Go layout elements to Excel number format codes, longest first
+var excelLayoutTokens = []struct{ goToken, excel string }{
+	{"January", "mmmm"}, {"Monday", "dddd"}, {"2006", "yyyy"},
+	{"Jan", "mmm"}, {"Mon", "ddd"}, {"MST", ""},
+	{"01", "mm"}, {"02", "dd"}, {"06", "yy"}, {"15", "hh"}, {"03", "hh"},
+	{"04", "mm"}, {"05", "ss"}, {"PM", "AM/PM"}, {"pm", "am/pm"},
+	{"1", "m"}, {"2", "d"}, {"3", "h"}, {"4", "m"}, {"5", "s"},
+}
+
+// ExcelDateFormat converts a Go reference layout to an Excel number format
+func ExcelDateFormat(layout string) string {
+	var b strings.Builder
+	for i := 0; i < len(layout); {
+		matched := false
+		for _, t := range excelLayoutTokens {
+			if strings.HasPrefix(layout[i:], t.goToken) {
+				b.WriteString(t.excel)
+				i += len(t.goToken)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteByte(layout[i])
+			i++
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
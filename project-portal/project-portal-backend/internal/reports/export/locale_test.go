@@ -0,0 +1,103 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func localeRows() []map[string]interface{} {
+	return []map[string]interface{}{
+		{"project": "Kasigau", "credits": 1234567.5, "count": int64(4200), "issued": time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestCSVExportLocales(t *testing.T) {
+	columns := []string{"project", "credits", "count", "issued"}
+	tests := []struct {
+		locale string
+		layout string
+		want   string
+	}{
+		{"", ISODateLayout, "project,credits,count,issued\r\nKasigau,1234567.5,4200,2026-03-09\r\n"},
+		{"en-US", "01/02/2006", "project,credits,count,issued\r\nKasigau,\"1,234,567.5\",\"4,200\",03/09/2026\r\n"},
+		{"de-DE", "02.01.2006", "project;credits;count;issued\r\nKasigau;1.234.567,5;4.200;09.03.2026\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			config := DefaultCSVConfig()
+			config.Locale = tt.locale
+			config.DateFormat = tt.layout
+
+			out, err := NewCSVExporter(config).Export(context.Background(), localeRows(), columns)
+			if err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+			if string(out) != tt.want {
+				t.Errorf("unexpected CSV\n got: %q\nwant: %q", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	if got := LookupLocale("de_de").Number.Decimal; got != "," {
+		t.Errorf("expected de_de to match de-DE, got decimal %q", got)
+	}
+	if got := LookupLocale("de").DateLayout; got != "02.01.2006" {
+		t.Errorf("expected bare language to match de-DE, got %q", got)
+	}
+	if got := LookupLocale("xx-YY"); got != localeFormats[DefaultLocale] {
+		t.Errorf("expected unknown locale to fall back to %s, got %+v", DefaultLocale, got)
+	}
+}
+
+func TestNumberFormatGrouping(t *testing.T) {
+	de := LookupLocale("de-DE").Number
+	cases := map[float64]string{0: "0", 999: "999", 1000: "1.000", -1234567.25: "-1.234.567,25", 0.5: "0,5"}
+	for v, want := range cases {
+		if got := de.FormatFloat(v); got != want {
+			t.Errorf("FormatFloat(%v) = %q, want %q", v, got, want)
+		}
+	}
+}
+
+func TestExcelDateFormat(t *testing.T) {
+	cases := map[string]string{
+		"2006-01-02":          "yyyy-mm-dd",
+		"02.01.2006":          "dd.mm.yyyy",
+		"01/02/2006 15:04:05": "mm/dd/yyyy hh:mm:ss",
+		"2 Jan 2006":          "d mmm yyyy",
+	}
+	for layout, want := range cases {
+		if got := ExcelDateFormat(layout); got != want {
+			t.Errorf("ExcelDateFormat(%q) = %q, want %q", layout, got, want)
+		}
+	}
+}
+
+func TestExcelExportAppliesDateFormat(t *testing.T) {
+	config := DefaultExcelConfig()
+	config.DateFormat = "dd.mm.yyyy"
+
+	out, err := NewExcelExporter(config).Export(context.Background(), localeRows(), []string{"project", "issued"})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("OpenReader: %v", err)
+	}
+	got, err := f.GetCellValue(config.SheetName, "B2")
+	if err != nil {
+		t.Fatalf("GetCellValue: %v", err)
+	}
+	if got != "09.03.2026" {
+		t.Errorf("expected date cell formatted as 09.03.2026, got %q", got)
+	}
+}
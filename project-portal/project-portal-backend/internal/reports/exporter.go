@@ -0,0 +1,169 @@
+package reports
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/reports/export"
+)
+
+// fileExporter renders execution results with the CSV, Excel and PDF writers
+type fileExporter struct{}
+
+// NewExporter returns the default Exporter
+func NewExporter() Exporter {
+	return fileExporter{}
+}
+
+func (fileExporter) ExportCSV(ctx context.Context, data []map[string]interface{}, config ExportConfig) ([]byte, error) {
+	csvConfig := export.DefaultCSVConfig()
+	csvConfig.IncludeHeader = config.IncludeHeader
+	csvConfig.Locale = config.Locale
+	csvConfig.DateFormat = exportDateLayout(config)
+	return export.NewCSVExporter(csvConfig).Export(ctx, exportRows(data, config.Fields), exportColumns(config.Fields))
+}
+
+func (fileExporter) ExportExcel(ctx context.Context, data []map[string]interface{}, config ExportConfig) ([]byte, error) {
+	excelConfig := export.DefaultExcelConfig()
+	excelConfig.IncludeHeader = config.IncludeHeader
+	excelConfig.DateFormat = export.ExcelDateFormat(exportDateLayout(config))
+	return export.NewExcelExporter(excelConfig).Export(ctx, exportRows(data, config.Fields), exportColumns(config.Fields))
+}
+
+func (fileExporter) ExportPDF(ctx context.Context, data []map[string]interface{}, config ExportConfig) ([]byte, error) {
+	pdfConfig := export.DefaultPDFConfig()
+	pdfConfig.IncludeHeader = config.IncludeHeader
+	pdfConfig.DateFormat = exportDateLayout(config)
+	if config.Title != "" {
+		pdfConfig.Title = config.Title
+	}
+	if config.PageSize != "" {
+		pdfConfig.PageSize = config.PageSize
+	}
+	if config.Orientation != "" {
+		pdfConfig.Orientation = config.Orientation
+	}
+	return export.NewPDFExporter(pdfConfig).Export(ctx, exportRows(data, config.Fields), exportColumns(config.Fields), nil)
+}
+
+// exportDateLayout prefers the explicit format, then the locale's convention, then ISO dates
+func exportDateLayout(config ExportConfig) string {
+	if config.DateFormat != "" {
+		return config.DateFormat
+	}
+	if config.Locale != "" {
+		return export.LookupLocale(config.Locale).DateLayout
+	}
+	return export.ISODateLayout
+}
+
+// exportColumn is the result column a field is read back as
+func exportColumn(field FieldConfig) string {
+	if field.Alias != "" {
+		return field.Alias
+	}
+	if field.Aggregate != "" {
+		// Postgres names an unaliased aggregate after the function
+		return strings.ToLower(string(field.Aggregate))
+	}
+	if i := strings.LastIndex(field.Name, "."); i >= 0 {
+		return field.Name[i+1:]
+	}
+	return field.Name
+}
+
+// exportFields returns the visible fields in display order. Fields with a
+// SortOrder come first, ascending; the rest keep their config order.
+func exportFields(fields []FieldConfig) []FieldConfig {
+	visible := make([]FieldConfig, 0, len(fields))
+	for _, field := range fields {
+		if !field.IsHidden {
+			visible = append(visible, field)
+		}
+	}
+	sort.SliceStable(visible, func(i, j int) bool {
+		a, b := visible[i].SortOrder, visible[j].SortOrder
+		if a == 0 || b == 0 {
+			return a != 0 && b == 0
+		}
+		return a < b
+	})
+	return visible
+}
+
+func exportColumns(fields []FieldConfig) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+	visible := exportFields(fields)
+	columns := make([]string, len(visible))
+	for i, field := range visible {
+		columns[i] = exportColumn(field)
+	}
+	return columns
+}
+
+// visibleRows drops hidden fields' values for formats that write whole rows
+func visibleRows(data []map[string]interface{}, fields []FieldConfig) []map[string]interface{} {
+	hidden := make(map[string]bool)
+	for _, field := range fields {
+		if field.IsHidden {
+			hidden[exportColumn(field)] = true
+		}
+	}
+	if len(hidden) == 0 {
+		return data
+	}
+
+	rows := make([]map[string]interface{}, len(data))
+	for i, row := range data {
+		kept := make(map[string]interface{}, len(row))
+		for k, v := range row {
+			if !hidden[k] {
+				kept[k] = v
+			}
+		}
+		rows[i] = kept
+	}
+	return rows
+}
+
+// exportRows converts NUMERIC values, which the driver returns as text, to
+// float64 for number fields so they are written in the requested locale
+func exportRows(data []map[string]interface{}, fields []FieldConfig) []map[string]interface{} {
+	var numeric []string
+	for _, field := range fields {
+		if field.DataType == "number" || field.Aggregate != "" {
+			numeric = append(numeric, exportColumn(field))
+		}
+	}
+	if len(numeric) == 0 {
+		return data
+	}
+
+	rows := make([]map[string]interface{}, len(data))
+	for i, row := range data {
+		converted := make(map[string]interface{}, len(row))
+		for k, v := range row {
+			converted[k] = v
+		}
+		for _, col := range numeric {
+			var text string
+			switch v := row[col].(type) {
+			case string:
+				text = v
+			case []byte:
+				text = string(v)
+			default:
+				continue
+			}
+			if f, err := strconv.ParseFloat(text, 64); err == nil {
+				converted[col] = f
+			}
+		}
+		rows[i] = converted
+	}
+	return rows
+}
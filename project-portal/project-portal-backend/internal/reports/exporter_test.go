@@ -0,0 +1,100 @@
+package reports
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestExporterCSVUsesLocaleAndFieldColumns(t *testing.T) {
+	data := []map[string]interface{}{
+		// NUMERIC columns come back from the driver as text
+		{"region": "Kenya", "sum": "1234.5", "created_at": time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+	}
+	config := ExportConfig{
+		Fields: []FieldConfig{
+			{Name: "projects.region"},
+			{Name: "quantity", Aggregate: AggregateSum},
+			{Name: "created_at", DataType: "date"},
+		},
+		Locale:        "de-DE",
+		IncludeHeader: true,
+	}
+
+	out, err := NewExporter().ExportCSV(context.Background(), data, config)
+	if err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+
+	want := "region;sum;created_at\r\nKenya;1.234,5;16.10.2026\r\n"
+	if string(out) != want {
+		t.Errorf("unexpected CSV\n got: %q\nwant: %q", out, want)
+	}
+}
+
+func TestExporterDateFormatOverridesLocale(t *testing.T) {
+	data := []map[string]interface{}{{"created_at": time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)}}
+	config := ExportConfig{
+		Fields:     []FieldConfig{{Name: "created_at"}},
+		Locale:     "en-US",
+		DateFormat: "2006/01/02",
+	}
+
+	out, err := NewExporter().ExportCSV(context.Background(), data, config)
+	if err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+	if string(out) != "2026/10/16\r\n" {
+		t.Errorf("expected explicit date format, got %q", out)
+	}
+}
+
+func TestExporterCSVOrdersAndHidesColumns(t *testing.T) {
+	data := []map[string]interface{}{
+		{"name": "Kasigau", "status": "active", "region": "Kenya", "organization_id": "org-1"},
+	}
+	config := ExportConfig{
+		Fields: []FieldConfig{
+			{Name: "name", SortOrder: 2},
+			{Name: "organization_id", IsHidden: true},
+			{Name: "status"},
+			{Name: "region", SortOrder: 1},
+		},
+		IncludeHeader: true,
+	}
+
+	out, err := NewExporter().ExportCSV(context.Background(), data, config)
+	if err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+
+	want := "region,name,status\r\nKenya,Kasigau,active\r\n"
+	if string(out) != want {
+		t.Errorf("unexpected CSV\n got: %q\nwant: %q", out, want)
+	}
+}
+
+func TestVisibleRowsDropsHiddenFields(t *testing.T) {
+	data := []map[string]interface{}{{"name": "Kasigau", "organization_id": "org-1"}}
+	rows := visibleRows(data, []FieldConfig{{Name: "name"}, {Name: "organization_id", IsHidden: true}})
+
+	if _, ok := rows[0]["organization_id"]; ok {
+		t.Error("expected hidden field to be dropped")
+	}
+	if rows[0]["name"] != "Kasigau" {
+		t.Error("expected visible field to be kept")
+	}
+	if _, ok := data[0]["organization_id"]; !ok {
+		t.Error("source rows must not be modified")
+	}
+}
+
+func TestValidateReportConfigRequiresVisibleField(t *testing.T) {
+	err := validateReportConfig(ReportConfig{
+		Dataset: "projects",
+		Fields:  []FieldConfig{{Name: "organization_id", IsHidden: true}},
+	})
+	if err == nil {
+		t.Fatal("expected a config with only hidden fields to be rejected")
+	}
+}
@@ -0,0 +1,265 @@
+package reports
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Calculation expressions are plain arithmetic:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/") unary }
+//	unary   = "-" unary | primary
+//	primary = number | field | "(" expr ")"
+//
+// A field is a column, a qualified dataset.column, a field alias or an earlier
+// calculation. Anything else, including function calls and subqueries, fails to parse.
+
+// Limits on one expression, so a config cannot make the parser or the query blow up
+const (
+	MaxExpressionLength = 500
+	MaxExpressionDepth  = 32
+)
+
+// exprNode is one node of a parsed calculation expression
+type exprNode interface{}
+
+type exprNumber struct{ literal string }
+
+type exprField struct{ name string }
+
+type exprNegate struct{ operand exprNode }
+
+type exprBinary struct {
+	op          byte
+	left, right exprNode
+}
+
+type exprTokenKind int
+
+const (
+	tokenEnd exprTokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+	tokenOpen
+	tokenClose
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+	pos  int
+}
+
+// tokenizeExpression splits an expression into tokens, rejecting any character
+// outside the grammar
+func tokenizeExpression(expression string) ([]exprToken, error) {
+	var tokens []exprToken
+	for i := 0; i < len(expression); {
+		ch := expression[i]
+		switch {
+		case ch == ' ' || ch == '\t':
+			i++
+		case ch == '+' || ch == '-' || ch == '*' || ch == '/':
+			tokens = append(tokens, exprToken{kind: tokenOperator, text: string(ch), pos: i})
+			i++
+		case ch == '(':
+			tokens = append(tokens, exprToken{kind: tokenOpen, text: "(", pos: i})
+			i++
+		case ch == ')':
+			tokens = append(tokens, exprToken{kind: tokenClose, text: ")", pos: i})
+			i++
+		case isDigit(ch) || ch == '.':
+			start := i
+			for i < len(expression) && (isDigit(expression[i]) || expression[i] == '.') {
+				i++
+			}
+			literal := expression[start:i]
+			if _, err := strconv.ParseFloat(literal, 64); err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", literal, start)
+			}
+			tokens = append(tokens, exprToken{kind: tokenNumber, text: literal, pos: start})
+		case isIdentStart(ch):
+			start := i
+			for i < len(expression) && (isIdentStart(expression[i]) || isDigit(expression[i]) || expression[i] == '.') {
+				i++
+			}
+			name := expression[start:i]
+			if !columnPattern.MatchString(name) {
+				return nil, fmt.Errorf("invalid field name %q at position %d", name, start)
+			}
+			tokens = append(tokens, exprToken{kind: tokenIdent, text: name, pos: start})
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", ch, i)
+		}
+	}
+	return append(tokens, exprToken{kind: tokenEnd, pos: len(expression)}), nil
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isIdentStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+// exprParser is a recursive descent parser over the tokens of one expression
+type exprParser struct {
+	tokens []exprToken
+	next   int
+	depth  int
+}
+
+// parseExpression parses a calculation expression into a tree
+func parseExpression(expression string) (exprNode, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, errors.New("expression is empty")
+	}
+	if len(expression) > MaxExpressionLength {
+		return nil, fmt.Errorf("expression must be at most %d characters", MaxExpressionLength)
+	}
+
+	tokens, err := tokenizeExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	node, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if token := p.peek(); token.kind != tokenEnd {
+		return nil, fmt.Errorf("unexpected %q at position %d", token.text, token.pos)
+	}
+	return node, nil
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.next]
+}
+
+func (p *exprParser) advance() exprToken {
+	token := p.tokens[p.next]
+	if token.kind != tokenEnd {
+		p.next++
+	}
+	return token
+}
+
+func (p *exprParser) expr() (exprNode, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for token := p.peek(); token.kind == tokenOperator && (token.text == "+" || token.text == "-"); token = p.peek() {
+		p.advance()
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = exprBinary{op: token.text[0], left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) term() (exprNode, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for token := p.peek(); token.kind == tokenOperator && (token.text == "*" || token.text == "/"); token = p.peek() {
+		p.advance()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = exprBinary{op: token.text[0], left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) unary() (exprNode, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > MaxExpressionDepth {
+		return nil, fmt.Errorf("expression is nested more than %d levels deep", MaxExpressionDepth)
+	}
+
+	if token := p.peek(); token.kind == tokenOperator && token.text == "-" {
+		p.advance()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return exprNegate{operand: operand}, nil
+	}
+	return p.primary()
+}
+
+func (p *exprParser) primary() (exprNode, error) {
+	token := p.advance()
+	switch token.kind {
+	case tokenNumber:
+		return exprNumber{literal: token.text}, nil
+	case tokenIdent:
+		if p.peek().kind == tokenOpen {
+			return nil, fmt.Errorf("function calls are not allowed (%s at position %d)", token.text, token.pos)
+		}
+		return exprField{name: token.text}, nil
+	case tokenOpen:
+		node, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != tokenClose {
+			return nil, fmt.Errorf("expected ) at position %d", closing.pos)
+		}
+		return node, nil
+	case tokenEnd:
+		return nil, errors.New("expression ends unexpectedly")
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", token.text, token.pos)
+	}
+}
+
+// expressionFields returns the field names an expression refers to, in order
+func expressionFields(node exprNode) []string {
+	switch n := node.(type) {
+	case exprField:
+		return []string{n.name}
+	case exprNegate:
+		return expressionFields(n.operand)
+	case exprBinary:
+		return append(expressionFields(n.left), expressionFields(n.right)...)
+	}
+	return nil
+}
+
+// fieldResolver returns the SQL and arguments a field name stands for
+type fieldResolver func(name string) (string, []interface{})
+
+// compileExpression renders a parsed expression as SQL. Numbers become numeric
+// parameters and division by zero yields NULL rather than failing the whole query.
+func compileExpression(node exprNode, resolve fieldResolver) (string, []interface{}) {
+	switch n := node.(type) {
+	case exprNumber:
+		return "CAST(? AS numeric)", []interface{}{n.literal}
+	case exprField:
+		return resolve(n.name)
+	case exprNegate:
+		sql, args := compileExpression(n.operand, resolve)
+		return "(-" + sql + ")", args
+	case exprBinary:
+		left, leftArgs := compileExpression(n.left, resolve)
+		right, rightArgs := compileExpression(n.right, resolve)
+		if n.op == '/' {
+			right = "NULLIF(" + right + ", 0)"
+		}
+		return fmt.Sprintf("(%s %c %s)", left, n.op, right), append(leftArgs, rightArgs...)
+	}
+	return "", nil
+}
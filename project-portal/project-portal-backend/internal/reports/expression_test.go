@@ -0,0 +1,171 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
+	"github.com/google/uuid"
+)
+
+func TestBuildDynamicQuery_CompilesCalculations(t *testing.T) {
+	config := ReportConfig{
+		Dataset: "carbon_credits",
+		Fields: []FieldConfig{
+			{Name: "status"},
+			{Name: "quantity", Aggregate: AggregateSum, Alias: "total"},
+		},
+		Filters:   []FilterConfig{{Field: "status", Operator: "eq", Value: "issued"}},
+		Groupings: []GroupConfig{{Field: "status"}},
+		Calculations: []CalculationConfig{
+			{Name: "value", Expression: "total * 12.5"},
+			{Name: "per_unit", Expression: "-(value - 3) / total"},
+		},
+	}
+
+	query, args, err := buildDynamicQuery(config)
+	if err != nil {
+		t.Fatalf("buildDynamicQuery failed: %v", err)
+	}
+
+	want := "SELECT status, SUM(quantity) AS total, " +
+		"(SUM(quantity) * CAST(? AS numeric)) AS value, " +
+		"((-((SUM(quantity) * CAST(? AS numeric)) - CAST(? AS numeric))) / NULLIF(SUM(quantity), 0)) AS per_unit " +
+		"FROM carbon_credits WHERE status = ?"
+	if !strings.HasPrefix(query, want) {
+		t.Errorf("unexpected query:\n got %s\nwant %s", query, want)
+	}
+	// Constants are bound before the filter values, in the order they appear
+	if wantArgs := []interface{}{"12.5", "12.5", "3", "issued"}; !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+}
+
+func TestParseExpression_RejectsUnsafeInput(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"(SELECT password FROM users)",
+		"pg_sleep(10)",
+		"sum (quantity)",
+		"quantity; DROP TABLE projects",
+		"quantity /* comment */",
+		"'1' + quantity",
+		"quantity + ",
+		"(quantity",
+		"quantity)",
+		"1.2.3 * quantity",
+		"projects.credits.amount",
+		strings.Repeat("(", MaxExpressionDepth+1) + "1" + strings.Repeat(")", MaxExpressionDepth+1),
+	} {
+		if _, err := parseExpression(expression); err == nil {
+			t.Errorf("expected %q to be rejected", expression)
+		}
+	}
+
+	for _, expression := range []string{"1", "-quantity", "(a + b) * c / 2", "projects.estimated_credits - .5"} {
+		if _, err := parseExpression(expression); err != nil {
+			t.Errorf("expected %q to parse, got %v", expression, err)
+		}
+	}
+}
+
+func TestValidateReportConfig_ExpressionsUseNumericFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		code       string
+	}{
+		{"function call", "abs(estimated_credits)", ProblemInvalidExpression},
+		{"text column", "region * 2", ProblemInvalidExpression},
+		{"text alias", "label + 1", ProblemInvalidExpression},
+		{"unknown column", "budget / 2", ProblemUnknownField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := validateConfig(t, ReportConfig{
+				Dataset:      "projects",
+				Fields:       []FieldConfig{{Name: "name", Alias: "label"}},
+				Calculations: []CalculationConfig{{Name: "x", Expression: tt.expression}},
+			})
+			if !hasProblem(response.Problems, "calculations[0].expression", tt.code) {
+				t.Errorf("expected %s, got %+v", tt.code, response.Problems)
+			}
+		})
+	}
+
+	response := validateConfig(t, ReportConfig{
+		Dataset: "projects",
+		Fields:  []FieldConfig{{Name: "name"}, {Name: "total_area_hectares", Alias: "area"}},
+		Calculations: []CalculationConfig{
+			{Name: "density", Expression: "estimated_credits / area"},
+			{Name: "percent", Expression: "density * 100"},
+		},
+	})
+	if !response.Valid {
+		t.Errorf("expected numeric expressions to be valid, got %+v", response.Problems)
+	}
+}
+
+func TestCreateReport_RejectsUnsafeExpression(t *testing.T) {
+	svc := NewService(newFakeRepository(), nil)
+
+	_, err := svc.CreateReport(context.Background(), uuid.New(), CreateReportRequest{
+		Name: "Leaky",
+		Config: ReportConfig{
+			Dataset:      "projects",
+			Fields:       []FieldConfig{{Name: "name"}},
+			Calculations: []CalculationConfig{{Name: "other", Expression: "(SELECT count(*) FROM projects)"}},
+		},
+	})
+	if !errors.Is(err, apperrors.ErrValidation) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if fields := apperrors.Fields(err); len(fields) != 1 || fields[0].Field != "calculations[0].expression" {
+		t.Errorf("expected the expression to be pointed at, got %+v", fields)
+	}
+}
+
+func TestSaveReport_RejectsInvalidIdentifiersAndAggregates(t *testing.T) {
+	svc := NewService(newFakeRepository(), nil)
+	ctx := context.Background()
+	owner := uuid.New()
+
+	base := ReportConfig{Dataset: "carbon_credits", Fields: []FieldConfig{{Name: "quantity", Aggregate: AggregateSum, Alias: "total"}}}
+	report, err := svc.CreateReport(ctx, owner, CreateReportRequest{Name: "Credits", Config: base})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	tests := map[string]struct {
+		field FieldConfig
+		sorts []SortConfig
+		calcs []CalculationConfig
+		path  string
+	}{
+		"aggregate":   {field: FieldConfig{Name: "quantity", Aggregate: "(SELECT 1) + SUM"}, path: "fields[0].aggregate"},
+		"alias":       {field: FieldConfig{Name: "quantity", Alias: "q, (SELECT 1) AS x"}, path: "fields[0].alias"},
+		"field name":  {field: FieldConfig{Name: "quantity; DROP TABLE projects"}, path: "fields[0].name"},
+		"sort field":  {field: FieldConfig{Name: "quantity"}, sorts: []SortConfig{{Field: "1; --"}}, path: "sorts[0].field"},
+		"calculation": {field: FieldConfig{Name: "quantity"}, calcs: []CalculationConfig{{Name: "x y", Expression: "quantity * 2"}}, path: "calculations[0].name"},
+	}
+	for name, tt := range tests {
+		config := ReportConfig{Dataset: "carbon_credits", Fields: []FieldConfig{tt.field}, Sorts: tt.sorts, Calculations: tt.calcs}
+
+		_, createErr := svc.CreateReport(ctx, owner, CreateReportRequest{Name: name, Config: config})
+		_, updateErr := svc.UpdateReport(ctx, owner, report.ID, UpdateReportRequest{Config: &config})
+		for op, err := range map[string]error{"create": createErr, "update": updateErr} {
+			if !errors.Is(err, apperrors.ErrValidation) {
+				t.Errorf("%s %s: expected a validation error, got %v", name, op, err)
+				continue
+			}
+			if fields := apperrors.Fields(err); len(fields) != 1 || fields[0].Field != tt.path {
+				t.Errorf("%s %s: expected %s to be pointed at, got %+v", name, op, tt.path, fields)
+			}
+		}
+	}
+}
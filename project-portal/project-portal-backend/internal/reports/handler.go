@@ -1,10 +1,16 @@
 package reports
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -19,18 +25,34 @@ func NewHandler(service Service) *Handler {
 	return &Handler{service: service}
 }
 
-// RegisterRoutes registers all report routes with the Gin router
-func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
-	reports := router.Group("/reports")
+// RegisterRoutes registers all report routes with the Gin router. authenticate runs
+// before every route except signed downloads, and must set user_id, role and
+// organization_id from the caller's verified credentials.
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authenticate ...gin.HandlerFunc) {
+	// A signed download link is its own authorization, so it opens without a session
+	router.GET("/reports/executions/:executionId/download", h.DownloadExecution)
+
+	reports := router.Group("/reports", authenticate...)
 	{
 		// Report Definitions
 		reports.POST("/builder", h.CreateReport)
+		reports.POST("/validate", h.ValidateReportConfig)
 		reports.GET("", h.ListReports)
 		reports.GET("/:id", h.GetReport)
 		reports.PUT("/:id", h.UpdateReport)
 		reports.DELETE("/:id", h.DeleteReport)
 		reports.POST("/:id/clone", h.CloneReport)
 
+		// Sharing
+		reports.POST("/:id/share", h.ShareReport)
+		reports.POST("/:id/unshare", h.UnshareReport)
+		reports.PUT("/:id/visibility", h.SetReportVisibility)
+
+		// Version History
+		reports.GET("/:id/versions", h.ListReportVersions)
+		reports.GET("/:id/versions/diff", h.DiffReportVersions)
+		reports.POST("/:id/revert", h.RevertReport)
+
 		// Report Execution
 		reports.POST("/:id/execute", h.ExecuteReport)
 		reports.GET("/:id/export", h.ExportReport)
@@ -40,9 +62,17 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 		reports.GET("/executions/:executionId", h.GetExecution)
 		reports.POST("/executions/:executionId/cancel", h.CancelExecution)
 
+		// Operator recovery (platform admins only)
+		reports.GET("/admin/executions/stuck", h.ListStuckExecutions)
+		reports.POST("/admin/executions/:executionId/recover", h.RecoverExecution)
+		reports.GET("/admin/executions/:executionId/plan", h.GetExecutionPlan)
+
 		// Templates
 		reports.GET("/templates", h.ListTemplates)
 
+		// Search across reports, schedules and benchmarks
+		reports.GET("/search", h.Search)
+
 		// Datasets
 		reports.GET("/datasets", h.GetDatasets)
 
@@ -53,6 +83,14 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 		reports.POST("/dashboard/widgets", h.CreateWidget)
 		reports.PUT("/dashboard/widgets/:widgetId", h.UpdateWidget)
 		reports.DELETE("/dashboard/widgets/:widgetId", h.DeleteWidget)
+		reports.GET("/dashboard/widgets/:widgetId/value", h.GetWidgetValue)
+		reports.GET("/dashboard/views", h.ListDashboardViews)
+		reports.POST("/dashboard/views", h.CreateDashboardView)
+		reports.GET("/dashboard/views/default", h.GetDefaultDashboardView)
+		reports.GET("/dashboard/views/:viewId", h.GetDashboardView)
+		reports.PUT("/dashboard/views/:viewId", h.UpdateDashboardView)
+		reports.DELETE("/dashboard/views/:viewId", h.DeleteDashboardView)
+		reports.POST("/dashboard/views/:viewId/default", h.SetDefaultDashboardView)
 
 		// Schedules
 		reports.POST("/schedules", h.CreateSchedule)
@@ -61,34 +99,66 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 		reports.PUT("/schedules/:scheduleId", h.UpdateSchedule)
 		reports.DELETE("/schedules/:scheduleId", h.DeleteSchedule)
 		reports.POST("/schedules/:scheduleId/toggle", h.ToggleSchedule)
+		reports.POST("/schedules/:scheduleId/subscribe", h.SubscribeToSchedule)
+		reports.POST("/schedules/:scheduleId/unsubscribe", h.UnsubscribeFromSchedule)
 
 		// Benchmarks
 		reports.POST("/benchmark/comparison", h.CompareBenchmark)
+		reports.POST("/benchmark/cohort-comparison", h.CompareCohorts)
 		reports.GET("/benchmarks", h.ListBenchmarks)
 		reports.POST("/benchmarks", h.CreateBenchmark)
 		reports.PUT("/benchmarks/:benchmarkId", h.UpdateBenchmark)
 	}
 }
 
-// getUserID extracts the user ID from the request context
-// In production, this would come from JWT middleware
+// getUserID returns the caller set by the auth middleware, or uuid.Nil without one
 func getUserID(c *gin.Context) uuid.UUID {
-	// Try to get from context (set by auth middleware)
-	if userID, exists := c.Get("user_id"); exists {
-		if uid, ok := userID.(uuid.UUID); ok {
-			return uid
-		}
+	if uid := contextUUID(c, "user_id"); uid != nil {
+		return *uid
 	}
+	return uuid.Nil
+}
+
+// getOrganizationID returns the caller's organization from the auth claims. It is
+// never read from the request itself, so a caller cannot pick another tenant.
+func getOrganizationID(c *gin.Context) *uuid.UUID {
+	return contextUUID(c, "organization_id")
+}
 
-	// Fallback: try to get from header
-	if userIDStr := c.GetHeader("X-User-ID"); userIDStr != "" {
-		if uid, err := uuid.Parse(userIDStr); err == nil {
-			return uid
+// contextUUID reads a UUID the auth middleware stored under key, as a UUID or a string
+func contextUUID(c *gin.Context, key string) *uuid.UUID {
+	value, exists := c.Get(key)
+	if !exists {
+		return nil
+	}
+	switch v := value.(type) {
+	case uuid.UUID:
+		return &v
+	case string:
+		if uid, err := uuid.Parse(v); err == nil {
+			return &uid
 		}
 	}
+	return nil
+}
 
-	// Default to a nil UUID (should handle this as unauthorized in production)
-	return uuid.Nil
+// requestContext returns the request context scoped to the caller's tenant.
+// Only platform admins (role from the auth claims) may opt into cross-org views.
+func requestContext(c *gin.Context) context.Context {
+	tenant := Tenant{OrganizationID: getOrganizationID(c)}
+	if role, _ := c.Get("role"); role == RolePlatformAdmin && c.Query("all_orgs") == "true" {
+		tenant.CrossOrg = true
+	}
+	return WithTenant(c.Request.Context(), tenant)
+}
+
+// requirePlatformAdmin rejects callers whose role is not platform admin
+func requirePlatformAdmin(c *gin.Context) bool {
+	if role, _ := c.Get("role"); role != RolePlatformAdmin {
+		c.Error(apperrors.Forbidden("platform admin role required"))
+		return false
+	}
+	return true
 }
 
 // ========== Report Definitions ==========
@@ -107,14 +177,14 @@ func getUserID(c *gin.Context) uuid.UUID {
 func (h *Handler) CreateReport(c *gin.Context) {
 	var req CreateReportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	userID := getUserID(c)
-	report, err := h.service.CreateReport(c.Request.Context(), userID, req)
+	report, err := h.service.CreateReport(requestContext(c), userID, req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -155,9 +225,9 @@ func (h *Handler) ListReports(c *gin.Context) {
 		filter.PageSize = pageSize
 	}
 
-	response, err := h.service.ListReports(c.Request.Context(), userID, filter)
+	response, err := h.service.ListReports(requestContext(c), userID, filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -176,14 +246,14 @@ func (h *Handler) ListReports(c *gin.Context) {
 func (h *Handler) GetReport(c *gin.Context) {
 	reportID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		c.Error(apperrors.Validation("invalid report ID"))
 		return
 	}
 
 	userID := getUserID(c)
-	report, err := h.service.GetReport(c.Request.Context(), userID, reportID)
+	report, err := h.service.GetReport(requestContext(c), userID, reportID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -205,20 +275,20 @@ func (h *Handler) GetReport(c *gin.Context) {
 func (h *Handler) UpdateReport(c *gin.Context) {
 	reportID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		c.Error(apperrors.Validation("invalid report ID"))
 		return
 	}
 
 	var req UpdateReportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	userID := getUserID(c)
-	report, err := h.service.UpdateReport(c.Request.Context(), userID, reportID, req)
+	report, err := h.service.UpdateReport(requestContext(c), userID, reportID, req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -236,13 +306,13 @@ func (h *Handler) UpdateReport(c *gin.Context) {
 func (h *Handler) DeleteReport(c *gin.Context) {
 	reportID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		c.Error(apperrors.Validation("invalid report ID"))
 		return
 	}
 
 	userID := getUserID(c)
-	if err := h.service.DeleteReport(c.Request.Context(), userID, reportID); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	if err := h.service.DeleteReport(requestContext(c), userID, reportID); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -262,7 +332,7 @@ func (h *Handler) DeleteReport(c *gin.Context) {
 func (h *Handler) CloneReport(c *gin.Context) {
 	reportID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		c.Error(apperrors.Validation("invalid report ID"))
 		return
 	}
 
@@ -270,20 +340,257 @@ func (h *Handler) CloneReport(c *gin.Context) {
 		Name string `json:"name" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	userID := getUserID(c)
-	report, err := h.service.CloneReport(c.Request.Context(), userID, reportID, req.Name)
+	report, err := h.service.CloneReport(requestContext(c), userID, reportID, req.Name)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, report)
 }
 
+// ShareReport shares a report with additional users
+// @Summary Share a report
+// @Description Add users to a report's share list (owner only)
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param id path string true "Report ID"
+// @Param request body ShareReportRequest true "Users to share with"
+// @Success 200 {object} ShareReportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/reports/{id}/share [post]
+func (h *Handler) ShareReport(c *gin.Context) {
+	reportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid report ID"))
+		return
+	}
+
+	var req ShareReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+
+	userID := getUserID(c)
+	shared, err := h.service.ShareReport(requestContext(c), userID, reportID, req.UserIDs)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ShareReportResponse{ReportID: reportID, SharedWithUsers: shared})
+}
+
+// UnshareReport removes users from a report's share list
+// @Summary Unshare a report
+// @Description Remove users from a report's share list (owner only)
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param id path string true "Report ID"
+// @Param request body ShareReportRequest true "Users to remove"
+// @Success 200 {object} ShareReportResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/reports/{id}/unshare [post]
+func (h *Handler) UnshareReport(c *gin.Context) {
+	reportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid report ID"))
+		return
+	}
+
+	var req ShareReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+
+	userID := getUserID(c)
+	shared, err := h.service.UnshareReport(requestContext(c), userID, reportID, req.UserIDs)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ShareReportResponse{ReportID: reportID, SharedWithUsers: shared})
+}
+
+// SetReportVisibility changes who can see a report
+// @Summary Set report visibility
+// @Description Change a report's visibility to private, shared, or public (owner only)
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param id path string true "Report ID"
+// @Param request body SetVisibilityRequest true "New visibility"
+// @Success 200 {object} ReportDefinition
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/reports/{id}/visibility [put]
+func (h *Handler) SetReportVisibility(c *gin.Context) {
+	reportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid report ID"))
+		return
+	}
+
+	var req SetVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+
+	userID := getUserID(c)
+	report, err := h.service.SetReportVisibility(requestContext(c), userID, reportID, req.Visibility)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ListReportVersions lists the stored versions of a report
+// @Summary List report versions
+// @Description Get the immutable config snapshots of a report, newest first
+// @Tags reports
+// @Produce json
+// @Param id path string true "Report ID"
+// @Success 200 {array} ReportVersion
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/reports/{id}/versions [get]
+func (h *Handler) ListReportVersions(c *gin.Context) {
+	reportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid report ID"))
+		return
+	}
+
+	userID := getUserID(c)
+	versions, err := h.service.ListReportVersions(requestContext(c), userID, reportID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"versions": versions})
+}
+
+// DiffReportVersions compares the configs of two report versions
+// @Summary Diff report versions
+// @Description List the config fields that differ between two versions of a report
+// @Tags reports
+// @Produce json
+// @Param id path string true "Report ID"
+// @Param from query int true "Base version"
+// @Param to query int true "Compared version"
+// @Success 200 {object} ReportVersionDiffResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/reports/{id}/versions/diff [get]
+func (h *Handler) DiffReportVersions(c *gin.Context) {
+	reportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid report ID"))
+		return
+	}
+
+	fromVersion, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid from version"))
+		return
+	}
+	toVersion, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid to version"))
+		return
+	}
+
+	userID := getUserID(c)
+	diff, err := h.service.DiffReportVersions(requestContext(c), userID, reportID, fromVersion, toVersion)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RevertReport restores an earlier version of a report as a new version
+// @Summary Revert a report
+// @Description Create a new version of a report from an earlier snapshot
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param id path string true "Report ID"
+// @Param request body RevertReportRequest true "Version to restore"
+// @Success 200 {object} ReportDefinition
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/reports/{id}/revert [post]
+func (h *Handler) RevertReport(c *gin.Context) {
+	reportID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid report ID"))
+		return
+	}
+
+	var req RevertReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+
+	userID := getUserID(c)
+	report, err := h.service.RevertReport(requestContext(c), userID, reportID, req.Version)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Search finds reports, schedules and benchmarks by name or description
+// @Summary Search report entities
+// @Description Search report definitions, schedules and benchmarks visible to the caller
+// @Tags reports
+// @Produce json
+// @Param q query string false "Text matched against name and description"
+// @Param type query string false "Comma-separated entity types: report, schedule, benchmark"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Items per page"
+// @Success 200 {object} SearchResponse
+// @Router /api/v1/reports/search [get]
+func (h *Handler) Search(c *gin.Context) {
+	filter := SearchFilter{Query: strings.TrimSpace(c.Query("q"))}
+	for _, entity := range strings.Split(c.Query("type"), ",") {
+		if entity = strings.TrimSpace(entity); entity != "" {
+			filter.Types = append(filter.Types, SearchEntityType(entity))
+		}
+	}
+	if page, err := strconv.Atoi(c.DefaultQuery("page", "1")); err == nil {
+		filter.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "20")); err == nil {
+		filter.PageSize = pageSize
+	}
+
+	response, err := h.service.Search(requestContext(c), getUserID(c), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // ListTemplates lists available report templates
 // @Summary List templates
 // @Description List all available report templates
@@ -292,9 +599,9 @@ func (h *Handler) CloneReport(c *gin.Context) {
 // @Success 200 {array} ReportDefinition
 // @Router /api/v1/reports/templates [get]
 func (h *Handler) ListTemplates(c *gin.Context) {
-	templates, err := h.service.GetTemplates(c.Request.Context())
+	templates, err := h.service.GetTemplates(requestContext(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -305,7 +612,8 @@ func (h *Handler) ListTemplates(c *gin.Context) {
 
 // ExecuteReport executes a report and returns an execution record
 // @Summary Execute a report
-// @Description Execute a report and start generating the output
+// @Description Execute a report and start generating the output. Setting explain
+// @Description captures the query plan and is limited to platform admins.
 // @Tags reports
 // @Accept json
 // @Produce json
@@ -316,17 +624,20 @@ func (h *Handler) ListTemplates(c *gin.Context) {
 func (h *Handler) ExecuteReport(c *gin.Context) {
 	reportID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		c.Error(apperrors.Validation("invalid report ID"))
 		return
 	}
 
 	var req ExecuteReportRequest
 	c.ShouldBindJSON(&req) // Optional parameters
+	if req.Explain && !requirePlatformAdmin(c) {
+		return
+	}
 
 	userID := getUserID(c)
-	execution, err := h.service.ExecuteReport(c.Request.Context(), userID, reportID, req)
+	execution, err := h.service.ExecuteReport(requestContext(c), userID, reportID, req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -335,29 +646,51 @@ func (h *Handler) ExecuteReport(c *gin.Context) {
 
 // ExportReport exports a report in the specified format
 // @Summary Export a report
-// @Description Export a report in CSV, Excel, PDF, or JSON format
+// @Description Export a report in CSV, Excel, PDF, or JSON format. The format
+// @Description query param wins over the Accept header; without either, CSV is used.
 // @Tags reports
 // @Produce application/octet-stream
 // @Param id path string true "Report ID"
 // @Param format query string false "Export format (csv, excel, pdf, json)" default(csv)
+// @Param Accept header string false "Export media type, e.g. text/csv or application/pdf"
+// @Param locale query string false "Locale for number and date formatting, e.g. de-DE"
+// @Param date_format query string false "Go date layout, e.g. 02.01.2006"
 // @Success 200 {file} file
 // @Router /api/v1/reports/{id}/export [get]
 func (h *Handler) ExportReport(c *gin.Context) {
 	reportID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report ID"})
+		c.Error(apperrors.Validation("invalid report ID"))
 		return
 	}
 
-	format := ExportFormat(c.DefaultQuery("format", "csv"))
+	var query struct {
+		Format     ExportFormat `form:"format" binding:"omitempty,oneof=csv excel pdf json"`
+		Locale     string       `form:"locale" binding:"max=35"`
+		DateFormat string       `form:"date_format" binding:"max=64"`
+	}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+	if query.Format == "" {
+		format, ok := negotiateExportFormat(c.GetHeader("Accept"))
+		if !ok {
+			c.Error(apperrors.NotAcceptable("none of the accepted media types can be exported; use text/csv, application/json, application/pdf or application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"))
+			return
+		}
+		query.Format = format
+	}
 	userID := getUserID(c)
 
 	// Execute the report with the specified format
-	execution, err := h.service.ExecuteReport(c.Request.Context(), userID, reportID, ExecuteReportRequest{
-		Format: format,
+	execution, err := h.service.ExecuteReport(requestContext(c), userID, reportID, ExecuteReportRequest{
+		Format:     query.Format,
+		Locale:     query.Locale,
+		DateFormat: query.DateFormat,
 	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -365,6 +698,7 @@ func (h *Handler) ExportReport(c *gin.Context) {
 	c.JSON(http.StatusAccepted, gin.H{
 		"execution_id": execution.ID,
 		"status":       execution.Status,
+		"format":       query.Format,
 		"message":      "Report generation started. Use the execution ID to check status.",
 	})
 }
@@ -404,9 +738,9 @@ func (h *Handler) ListExecutions(c *gin.Context) {
 		filter.PageSize = pageSize
 	}
 
-	response, err := h.service.ListExecutions(c.Request.Context(), filter)
+	response, err := h.service.ListExecutions(requestContext(c), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -424,19 +758,61 @@ func (h *Handler) ListExecutions(c *gin.Context) {
 func (h *Handler) GetExecution(c *gin.Context) {
 	executionID, err := uuid.Parse(c.Param("executionId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid execution ID"})
+		c.Error(apperrors.Validation("invalid execution ID"))
 		return
 	}
 
-	execution, err := h.service.GetExecution(c.Request.Context(), executionID)
+	execution, err := h.service.GetExecution(requestContext(c), getUserID(c), executionID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	// Each fetch hands out a fresh short-lived link rather than a stored one
+	if query, expiresAt, ok := h.service.SignDownload(execution); ok {
+		execution.DownloadURL = c.Request.URL.Path + "/download?" + query.Encode()
+		execution.DownloadExpiresAt = &expiresAt
+	}
+
 	c.JSON(http.StatusOK, execution)
 }
 
+// DownloadExecution serves the file of a completed execution
+// @Summary Download execution file
+// @Description Download a generated report through the signed link returned with the execution
+// @Tags reports
+// @Param executionId path string true "Execution ID"
+// @Param expires query int true "Link expiry as a Unix timestamp"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file
+// @Router /api/v1/reports/executions/{executionId}/download [get]
+func (h *Handler) DownloadExecution(c *gin.Context) {
+	executionID, err := uuid.Parse(c.Param("executionId"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid execution ID"))
+		return
+	}
+
+	var query struct {
+		Expires   int64  `form:"expires" binding:"required"`
+		Signature string `form:"signature" binding:"required"`
+	}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+
+	file, err := h.service.OpenDownload(c.Request.Context(), executionID, query.Expires, query.Signature)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Name))
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
+
 // CancelExecution cancels a pending execution
 // @Summary Cancel execution
 // @Description Cancel a pending or processing execution
@@ -447,18 +823,117 @@ func (h *Handler) GetExecution(c *gin.Context) {
 func (h *Handler) CancelExecution(c *gin.Context) {
 	executionID, err := uuid.Parse(c.Param("executionId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid execution ID"})
+		c.Error(apperrors.Validation("invalid execution ID"))
 		return
 	}
 
-	if err := h.service.CancelExecution(c.Request.Context(), executionID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.service.CancelExecution(requestContext(c), getUserID(c), executionID); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "execution cancelled"})
 }
 
+// ListStuckExecutions lists executions left in processing by a crashed worker
+// @Summary List stuck executions
+// @Description List executions still processing after older_than (platform admins only)
+// @Tags reports
+// @Produce json
+// @Param older_than query string false "Minimum age as a Go duration, e.g. 2h" default(35m)
+// @Success 200 {array} ReportExecution
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/reports/admin/executions/stuck [get]
+func (h *Handler) ListStuckExecutions(c *gin.Context) {
+	if !requirePlatformAdmin(c) {
+		return
+	}
+
+	olderThan := DefaultStuckExecutionAge
+	if raw := c.Query("older_than"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			c.Error(apperrors.Field("older_than", "must be a duration such as 2h"))
+			return
+		}
+		olderThan = parsed
+	}
+
+	executions, err := h.service.ListStuckExecutions(requestContext(c), olderThan)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"executions": executions, "older_than": olderThan.String()})
+}
+
+// RecoverExecution requeues or fails a stuck execution
+// @Summary Recover stuck execution
+// @Description Rerun a stuck execution or mark it failed (platform admins only)
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param executionId path string true "Execution ID"
+// @Param request body RecoverExecutionRequest true "Recovery action"
+// @Success 200 {object} ReportExecution
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/reports/admin/executions/{executionId}/recover [post]
+func (h *Handler) RecoverExecution(c *gin.Context) {
+	if !requirePlatformAdmin(c) {
+		return
+	}
+
+	executionID, err := uuid.Parse(c.Param("executionId"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid execution ID"))
+		return
+	}
+
+	var req RecoverExecutionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+
+	execution, err := h.service.RecoverExecution(requestContext(c), executionID, req.Action)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, execution)
+}
+
+// GetExecutionPlan returns the captured query plan of an execution
+// @Summary Get execution query plan
+// @Description Get the scrubbed EXPLAIN output of an execution run with explain (platform admins only)
+// @Tags reports
+// @Produce json
+// @Param executionId path string true "Execution ID"
+// @Success 200 {object} ExecutionPlanResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/reports/admin/executions/{executionId}/plan [get]
+func (h *Handler) GetExecutionPlan(c *gin.Context) {
+	if !requirePlatformAdmin(c) {
+		return
+	}
+
+	executionID, err := uuid.Parse(c.Param("executionId"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid execution ID"))
+		return
+	}
+
+	plan, err := h.service.GetExecutionPlan(requestContext(c), executionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
 // GetDatasets returns available datasets and their fields
 // @Summary Get datasets
 // @Description Get available datasets and their field metadata
@@ -467,15 +942,40 @@ func (h *Handler) CancelExecution(c *gin.Context) {
 // @Success 200 {array} DatasetMetadata
 // @Router /api/v1/reports/datasets [get]
 func (h *Handler) GetDatasets(c *gin.Context) {
-	datasets, err := h.service.GetAvailableDatasets(c.Request.Context())
+	datasets, err := h.service.GetAvailableDatasets(requestContext(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"datasets": datasets})
 }
 
+// ValidateReportConfig checks a report config without executing it
+// @Summary Validate a report config
+// @Description Dry-run a report config against the dataset metadata and list every problem found
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param request body ReportConfig true "Report configuration"
+// @Success 200 {object} ValidateConfigResponse
+// @Router /api/v1/reports/validate [post]
+func (h *Handler) ValidateReportConfig(c *gin.Context) {
+	var config ReportConfig
+	if err := c.ShouldBindJSON(&config); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+
+	response, err := h.service.ValidateReportConfig(requestContext(c), config)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // ========== Dashboard ==========
 
 // GetDashboardSummary returns aggregated dashboard data
@@ -492,9 +992,9 @@ func (h *Handler) GetDashboardSummary(c *gin.Context) {
 		userIDPtr = &userID
 	}
 
-	summary, err := h.service.GetDashboardSummary(c.Request.Context(), userIDPtr)
+	summary, err := h.service.GetDashboardSummary(requestContext(c), userIDPtr)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -515,7 +1015,7 @@ func (h *Handler) GetDashboardSummary(c *gin.Context) {
 func (h *Handler) GetTimeSeriesData(c *gin.Context) {
 	metric := c.Query("metric")
 	if metric == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "metric is required"})
+		c.Error(apperrors.Validation("metric is required"))
 		return
 	}
 
@@ -524,21 +1024,21 @@ func (h *Handler) GetTimeSeriesData(c *gin.Context) {
 
 	startTime, err := time.Parse(time.RFC3339, startTimeStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_time"})
+		c.Error(apperrors.Validation("invalid start_time"))
 		return
 	}
 
 	endTime, err := time.Parse(time.RFC3339, endTimeStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_time"})
+		c.Error(apperrors.Validation("invalid end_time"))
 		return
 	}
 
 	interval := c.DefaultQuery("interval", "day")
 
-	data, err := h.service.GetTimeSeriesData(c.Request.Context(), metric, startTime, endTime, interval)
+	data, err := h.service.GetTimeSeriesData(requestContext(c), metric, startTime, endTime, interval)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -557,9 +1057,9 @@ func (h *Handler) GetWidgets(c *gin.Context) {
 	userID := getUserID(c)
 	section := c.Query("section")
 
-	widgets, err := h.service.GetWidgets(c.Request.Context(), userID, section)
+	widgets, err := h.service.GetWidgets(requestContext(c), userID, section)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -578,16 +1078,13 @@ func (h *Handler) GetWidgets(c *gin.Context) {
 func (h *Handler) CreateWidget(c *gin.Context) {
 	var widget DashboardWidget
 	if err := c.ShouldBindJSON(&widget); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
-	userID := getUserID(c)
-	widget.UserID = &userID
-
-	saved, err := h.service.SaveWidget(c.Request.Context(), &widget)
+	saved, err := h.service.SaveWidget(requestContext(c), getUserID(c), &widget)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -603,25 +1100,26 @@ func (h *Handler) CreateWidget(c *gin.Context) {
 // @Param widgetId path string true "Widget ID"
 // @Param request body DashboardWidget true "Widget configuration"
 // @Success 200 {object} DashboardWidget
+// @Failure 404 {object} ErrorResponse
 // @Router /api/v1/reports/dashboard/widgets/{widgetId} [put]
 func (h *Handler) UpdateWidget(c *gin.Context) {
 	widgetID, err := uuid.Parse(c.Param("widgetId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid widget ID"})
+		c.Error(apperrors.Validation("invalid widget ID"))
 		return
 	}
 
 	var widget DashboardWidget
 	if err := c.ShouldBindJSON(&widget); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	widget.ID = widgetID
 
-	saved, err := h.service.SaveWidget(c.Request.Context(), &widget)
+	saved, err := h.service.SaveWidget(requestContext(c), getUserID(c), &widget)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -634,22 +1132,209 @@ func (h *Handler) UpdateWidget(c *gin.Context) {
 // @Tags reports
 // @Param widgetId path string true "Widget ID"
 // @Success 204 "No Content"
+// @Failure 404 {object} ErrorResponse
 // @Router /api/v1/reports/dashboard/widgets/{widgetId} [delete]
 func (h *Handler) DeleteWidget(c *gin.Context) {
 	widgetID, err := uuid.Parse(c.Param("widgetId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid widget ID"})
+		c.Error(apperrors.Validation("invalid widget ID"))
+		return
+	}
+
+	if err := h.service.DeleteWidget(requestContext(c), getUserID(c), widgetID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWidgetValue returns the current value of a single-value widget
+// @Summary Get widget value
+// @Description Compute a single-value widget's metric for the current period and its change since the previous one
+// @Tags reports
+// @Produce json
+// @Param widgetId path string true "Widget ID"
+// @Success 200 {object} WidgetValueResponse
+// @Router /api/v1/reports/dashboard/widgets/{widgetId}/value [get]
+func (h *Handler) GetWidgetValue(c *gin.Context) {
+	widgetID, err := uuid.Parse(c.Param("widgetId"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid widget ID"))
+		return
+	}
+
+	value, err := h.service.GetWidgetValue(requestContext(c), getUserID(c), widgetID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, value)
+}
+
+// ListDashboardViews returns the current user's saved dashboard views
+// @Summary List dashboard views
+// @Description List the saved dashboard views of the current user
+// @Tags reports
+// @Produce json
+// @Success 200 {array} DashboardView
+// @Router /api/v1/reports/dashboard/views [get]
+func (h *Handler) ListDashboardViews(c *gin.Context) {
+	views, err := h.service.ListDashboardViews(requestContext(c), getUserID(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"views": views})
+}
+
+// GetDefaultDashboardView returns the view the dashboard loads with
+// @Summary Get default dashboard view
+// @Description Get the current user's default dashboard view with its widgets
+// @Tags reports
+// @Produce json
+// @Success 200 {object} DashboardView
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/reports/dashboard/views/default [get]
+func (h *Handler) GetDefaultDashboardView(c *gin.Context) {
+	view, err := h.service.GetDefaultDashboardView(requestContext(c), getUserID(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// GetDashboardView returns a saved dashboard view
+// @Summary Get dashboard view
+// @Description Get a saved dashboard view with its widgets
+// @Tags reports
+// @Produce json
+// @Param viewId path string true "View ID"
+// @Success 200 {object} DashboardView
+// @Router /api/v1/reports/dashboard/views/{viewId} [get]
+func (h *Handler) GetDashboardView(c *gin.Context) {
+	viewID, err := uuid.Parse(c.Param("viewId"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid view ID"))
 		return
 	}
 
-	if err := h.service.DeleteWidget(c.Request.Context(), widgetID); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	view, err := h.service.GetDashboardView(requestContext(c), getUserID(c), viewID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// CreateDashboardView saves a named set of widgets, filters and time range
+// @Summary Create dashboard view
+// @Description Save a dashboard view, optionally as the user's default
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param request body SaveDashboardViewRequest true "View configuration"
+// @Success 201 {object} DashboardView
+// @Router /api/v1/reports/dashboard/views [post]
+func (h *Handler) CreateDashboardView(c *gin.Context) {
+	var req SaveDashboardViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+
+	view, err := h.service.CreateDashboardView(requestContext(c), getUserID(c), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, view)
+}
+
+// UpdateDashboardView replaces a saved dashboard view
+// @Summary Update dashboard view
+// @Description Update a saved dashboard view
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param viewId path string true "View ID"
+// @Param request body SaveDashboardViewRequest true "View configuration"
+// @Success 200 {object} DashboardView
+// @Router /api/v1/reports/dashboard/views/{viewId} [put]
+func (h *Handler) UpdateDashboardView(c *gin.Context) {
+	viewID, err := uuid.Parse(c.Param("viewId"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid view ID"))
+		return
+	}
+
+	var req SaveDashboardViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+
+	view, err := h.service.UpdateDashboardView(requestContext(c), getUserID(c), viewID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// DeleteDashboardView deletes a saved dashboard view
+// @Summary Delete dashboard view
+// @Description Delete a saved dashboard view
+// @Tags reports
+// @Param viewId path string true "View ID"
+// @Success 204 "No Content"
+// @Router /api/v1/reports/dashboard/views/{viewId} [delete]
+func (h *Handler) DeleteDashboardView(c *gin.Context) {
+	viewID, err := uuid.Parse(c.Param("viewId"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid view ID"))
+		return
+	}
+
+	if err := h.service.DeleteDashboardView(requestContext(c), getUserID(c), viewID); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// SetDefaultDashboardView makes a view the one the dashboard loads with
+// @Summary Set default dashboard view
+// @Description Make a saved view the user's default, replacing the previous default
+// @Tags reports
+// @Produce json
+// @Param viewId path string true "View ID"
+// @Success 200 {object} DashboardView
+// @Router /api/v1/reports/dashboard/views/{viewId}/default [post]
+func (h *Handler) SetDefaultDashboardView(c *gin.Context) {
+	viewID, err := uuid.Parse(c.Param("viewId"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid view ID"))
+		return
+	}
+
+	view, err := h.service.SetDefaultDashboardView(requestContext(c), getUserID(c), viewID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
 // ========== Schedules ==========
 
 // CreateSchedule creates a new scheduled report
@@ -664,14 +1349,14 @@ func (h *Handler) DeleteWidget(c *gin.Context) {
 func (h *Handler) CreateSchedule(c *gin.Context) {
 	var req CreateScheduleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
 	userID := getUserID(c)
-	schedule, err := h.service.CreateSchedule(c.Request.Context(), userID, req)
+	schedule, err := h.service.CreateSchedule(requestContext(c), userID, req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -708,9 +1393,9 @@ func (h *Handler) ListSchedules(c *gin.Context) {
 		filter.PageSize = pageSize
 	}
 
-	schedules, total, err := h.service.ListSchedules(c.Request.Context(), filter)
+	schedules, total, err := h.service.ListSchedules(requestContext(c), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -728,13 +1413,13 @@ func (h *Handler) ListSchedules(c *gin.Context) {
 func (h *Handler) GetSchedule(c *gin.Context) {
 	scheduleID, err := uuid.Parse(c.Param("scheduleId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule ID"})
+		c.Error(apperrors.Validation("invalid schedule ID"))
 		return
 	}
 
-	schedule, err := h.service.GetSchedule(c.Request.Context(), scheduleID)
+	schedule, err := h.service.GetSchedule(requestContext(c), getUserID(c), scheduleID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -754,19 +1439,19 @@ func (h *Handler) GetSchedule(c *gin.Context) {
 func (h *Handler) UpdateSchedule(c *gin.Context) {
 	scheduleID, err := uuid.Parse(c.Param("scheduleId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule ID"})
+		c.Error(apperrors.Validation("invalid schedule ID"))
 		return
 	}
 
 	var req CreateScheduleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
-	schedule, err := h.service.UpdateSchedule(c.Request.Context(), scheduleID, req)
+	schedule, err := h.service.UpdateSchedule(requestContext(c), getUserID(c), scheduleID, req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -783,12 +1468,12 @@ func (h *Handler) UpdateSchedule(c *gin.Context) {
 func (h *Handler) DeleteSchedule(c *gin.Context) {
 	scheduleID, err := uuid.Parse(c.Param("scheduleId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule ID"})
+		c.Error(apperrors.Validation("invalid schedule ID"))
 		return
 	}
 
-	if err := h.service.DeleteSchedule(c.Request.Context(), scheduleID); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	if err := h.service.DeleteSchedule(requestContext(c), getUserID(c), scheduleID); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -808,7 +1493,7 @@ func (h *Handler) DeleteSchedule(c *gin.Context) {
 func (h *Handler) ToggleSchedule(c *gin.Context) {
 	scheduleID, err := uuid.Parse(c.Param("scheduleId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule ID"})
+		c.Error(apperrors.Validation("invalid schedule ID"))
 		return
 	}
 
@@ -816,18 +1501,68 @@ func (h *Handler) ToggleSchedule(c *gin.Context) {
 		Active bool `json:"active"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
-	if err := h.service.ToggleSchedule(c.Request.Context(), scheduleID, req.Active); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.service.ToggleSchedule(requestContext(c), getUserID(c), scheduleID, req.Active); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "schedule updated", "active": req.Active})
 }
 
+// SubscribeToSchedule adds the caller to a schedule's recipients
+// @Summary Subscribe to a schedule
+// @Description Receive an existing email schedule's deliveries at the caller's account email (requires access to the report)
+// @Tags reports
+// @Produce json
+// @Param scheduleId path string true "Schedule ID"
+// @Success 200 {object} ScheduleSubscriptionResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/reports/schedules/{scheduleId}/subscribe [post]
+func (h *Handler) SubscribeToSchedule(c *gin.Context) {
+	h.changeSubscription(c, true)
+}
+
+// UnsubscribeFromSchedule removes the caller from a schedule's recipients
+// @Summary Unsubscribe from a schedule
+// @Description Stop receiving a schedule's deliveries at the caller's account email
+// @Tags reports
+// @Produce json
+// @Param scheduleId path string true "Schedule ID"
+// @Success 200 {object} ScheduleSubscriptionResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/reports/schedules/{scheduleId}/unsubscribe [post]
+func (h *Handler) UnsubscribeFromSchedule(c *gin.Context) {
+	h.changeSubscription(c, false)
+}
+
+func (h *Handler) changeSubscription(c *gin.Context, subscribe bool) {
+	scheduleID, err := uuid.Parse(c.Param("scheduleId"))
+	if err != nil {
+		c.Error(apperrors.Validation("invalid schedule ID"))
+		return
+	}
+
+	// Deliveries only ever go to the address in the caller's token; other
+	// recipients are managed by the schedule's owner through UpdateSchedule
+	userID := getUserID(c)
+	email := c.GetString("email")
+	change := h.service.UnsubscribeFromSchedule
+	if subscribe {
+		change = h.service.SubscribeToSchedule
+	}
+	if _, err := change(requestContext(c), userID, scheduleID, email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ScheduleSubscriptionResponse{ScheduleID: scheduleID, Subscribed: subscribe, Email: email})
+}
+
 // ========== Benchmarks ==========
 
 // CompareBenchmark compares project against benchmarks
@@ -842,13 +1577,38 @@ func (h *Handler) ToggleSchedule(c *gin.Context) {
 func (h *Handler) CompareBenchmark(c *gin.Context) {
 	var req BenchmarkComparisonRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
-	result, err := h.service.CompareBenchmark(c.Request.Context(), req)
+	result, err := h.service.CompareBenchmark(requestContext(c), req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CompareCohorts compares a project against several benchmark cohorts
+// @Summary Compare benchmark cohorts
+// @Description Compare a project against the benchmarks of several cohorts, e.g. by region, methodology and vintage
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param request body CohortComparisonRequest true "Project and cohorts"
+// @Success 200 {object} CohortComparisonResponse
+// @Router /api/v1/reports/benchmark/cohort-comparison [post]
+func (h *Handler) CompareCohorts(c *gin.Context) {
+	var req CohortComparisonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(middleware.BindError(err))
+		return
+	}
+
+	result, err := h.service.CompareCohorts(requestContext(c), req)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -882,9 +1642,9 @@ func (h *Handler) ListBenchmarks(c *gin.Context) {
 	isActive := true
 	filter.IsActive = &isActive
 
-	benchmarks, err := h.service.ListBenchmarks(c.Request.Context(), filter)
+	benchmarks, err := h.service.ListBenchmarks(requestContext(c), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -903,13 +1663,13 @@ func (h *Handler) ListBenchmarks(c *gin.Context) {
 func (h *Handler) CreateBenchmark(c *gin.Context) {
 	var dataset BenchmarkDataset
 	if err := c.ShouldBindJSON(&dataset); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
-	saved, err := h.service.CreateBenchmark(c.Request.Context(), &dataset)
+	saved, err := h.service.CreateBenchmark(requestContext(c), &dataset)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -929,19 +1689,19 @@ func (h *Handler) CreateBenchmark(c *gin.Context) {
 func (h *Handler) UpdateBenchmark(c *gin.Context) {
 	benchmarkID, err := uuid.Parse(c.Param("benchmarkId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid benchmark ID"})
+		c.Error(apperrors.Validation("invalid benchmark ID"))
 		return
 	}
 
 	var dataset BenchmarkDataset
 	if err := c.ShouldBindJSON(&dataset); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(middleware.BindError(err))
 		return
 	}
 
-	saved, err := h.service.UpdateBenchmark(c.Request.Context(), benchmarkID, &dataset)
+	saved, err := h.service.UpdateBenchmark(requestContext(c), benchmarkID, &dataset)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -949,15 +1709,41 @@ func (h *Handler) UpdateBenchmark(c *gin.Context) {
 }
 
 // ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error string `json:"error"`
-}
+type ErrorResponse = middleware.ErrorResponse
 
 // CloneReportRequest represents a clone request
 type CloneReportRequest struct {
 	Name string `json:"name" binding:"required"`
 }
 
+// ShareReportRequest represents a share or unshare request
+type ShareReportRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids" binding:"required,min=1,max=500"`
+}
+
+// ScheduleSubscriptionResponse reports the caller's subscription state
+type ScheduleSubscriptionResponse struct {
+	ScheduleID uuid.UUID `json:"schedule_id"`
+	Subscribed bool      `json:"subscribed"`
+	Email      string    `json:"email,omitempty"`
+}
+
+// ShareReportResponse represents the updated share list of a report
+type ShareReportResponse struct {
+	ReportID        uuid.UUID   `json:"report_id"`
+	SharedWithUsers []uuid.UUID `json:"shared_with_users"`
+}
+
+// SetVisibilityRequest represents a visibility change request
+type SetVisibilityRequest struct {
+	Visibility ReportVisibility `json:"visibility" binding:"required"`
+}
+
+// RecoverExecutionRequest names what to do with a stuck execution
+type RecoverExecutionRequest struct {
+	Action RecoveryAction `json:"action" binding:"required,oneof=requeue fail"`
+}
+
 // ToggleScheduleRequest represents a toggle request
 type ToggleScheduleRequest struct {
 	Active bool `json:"active"`
@@ -0,0 +1,114 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+)
+
+// datasetJoinKeys are the only column pairs datasets can be joined on. Each follows a
+// foreign key, so a join relates rows instead of pairing every row with every other.
+var datasetJoinKeys = []JoinCondition{
+	{Left: "projects.id", Right: "carbon_credits.project_id"},
+	{Left: "projects.id", Right: "monitoring_data.project_id"},
+	{Left: "carbon_credits.id", Right: "transactions.credit_id"},
+}
+
+// joinKeysOf returns the declared join keys that involve dataset
+func joinKeysOf(dataset string) []JoinCondition {
+	var keys []JoinCondition
+	for _, key := range datasetJoinKeys {
+		if datasetOf(key.Left) == dataset || datasetOf(key.Right) == dataset {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// isJoinKey reports whether on equates the columns of a declared key, in either order
+func isJoinKey(on JoinCondition) bool {
+	for _, key := range datasetJoinKeys {
+		if key == on || key == (JoinCondition{Left: on.Right, Right: on.Left}) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinable reports whether a key is declared between two datasets
+func joinable(a, b string) bool {
+	for _, key := range joinKeysOf(a) {
+		if datasetOf(key.Left) == b || datasetOf(key.Right) == b {
+			return true
+		}
+	}
+	return false
+}
+
+// datasetOf returns the dataset of a qualified column such as projects.id
+func datasetOf(column string) string {
+	dataset, _, _ := strings.Cut(column, ".")
+	return dataset
+}
+
+// checkJoins returns the problems of joins. Each join needs an on condition over a
+// declared key that links it to the report's dataset or to a dataset joined before it.
+func checkJoins(dataset string, joins []JoinConfig) []ConfigProblem {
+	var problems []ConfigProblem
+	add := func(path, code, format string, args ...any) {
+		problems = append(problems, ConfigProblem{Path: path, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	joined := map[string]bool{dataset: true}
+	for i, join := range joins {
+		path := fmt.Sprintf("joins[%d]", i)
+		switch {
+		case join.Type != "" && join.Type != "inner" && join.Type != "left":
+			add(path+".type", ProblemIllegalJoin, "join type must be inner or left")
+		case joined[join.Dataset]:
+			add(path+".dataset", ProblemIllegalJoin, "dataset %q is already part of the report", join.Dataset)
+		case join.On == nil || join.On.Left == "" || join.On.Right == "":
+			add(path+".on", ProblemMissingJoinCondition, "joining %q needs an on condition over one of its join keys", join.Dataset)
+		case !isJoinKey(*join.On):
+			add(path+".on", ProblemIllegalJoin, "%s = %s is not a declared join key", join.On.Left, join.On.Right)
+		default:
+			other := datasetOf(join.On.Left)
+			if other == join.Dataset {
+				other = datasetOf(join.On.Right)
+			} else if datasetOf(join.On.Right) != join.Dataset {
+				add(path+".on", ProblemIllegalJoin, "on condition must use a column of %q", join.Dataset)
+				break
+			}
+			if !joined[other] {
+				add(path+".on", ProblemIllegalJoin, "%q must be part of the report before %q is joined to it", other, join.Dataset)
+			}
+		}
+		joined[join.Dataset] = true
+	}
+	return problems
+}
+
+// joinClause renders the joins of config, refusing any that checkJoins rejects
+func joinClause(config ReportConfig) (string, error) {
+	if problems := checkJoins(config.Dataset, config.Joins); len(problems) > 0 {
+		return "", fmt.Errorf("%s: %s", problems[0].Path, problems[0].Message)
+	}
+
+	var clause strings.Builder
+	for _, join := range config.Joins {
+		kind := "JOIN"
+		if join.Type == "left" {
+			kind = "LEFT JOIN"
+		}
+		fmt.Fprintf(&clause, " %s %s ON %s = %s", kind, join.Dataset, join.On.Left, join.On.Right)
+	}
+	return clause.String(), nil
+}
+
+// qualify prefixes a bare column with the report's dataset once other datasets are
+// joined, since columns such as id and status exist in several of them
+func qualify(config ReportConfig, column string) string {
+	if len(config.Joins) == 0 || strings.Contains(column, ".") {
+		return column
+	}
+	return config.Dataset + "." + column
+}
@@ -0,0 +1,144 @@
+package reports
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// creditsWithProjects joins the region of each credit's project
+func creditsWithProjects(on *JoinCondition) ReportConfig {
+	return ReportConfig{
+		Dataset:   "carbon_credits",
+		Joins:     []JoinConfig{{Dataset: "projects", On: on}},
+		Fields:    []FieldConfig{{Name: "projects.region"}, {Name: "quantity", Aggregate: AggregateSum, Alias: "total"}},
+		Filters:   []FilterConfig{{Field: "status", Operator: "eq", Value: "issued"}},
+		Groupings: []GroupConfig{{Field: "projects.region"}},
+		Sorts:     []SortConfig{{Field: "total", Direction: "desc"}},
+	}
+}
+
+func TestBuildDynamicQuery_JoinsOnDeclaredKey(t *testing.T) {
+	config := creditsWithProjects(&JoinCondition{Left: "projects.id", Right: "carbon_credits.project_id"})
+
+	query, _, err := buildDynamicQuery(config)
+	if err != nil {
+		t.Fatalf("buildDynamicQuery: %v", err)
+	}
+	want := "SELECT projects.region, SUM(carbon_credits.quantity) AS total FROM carbon_credits " +
+		"JOIN projects ON projects.id = carbon_credits.project_id WHERE carbon_credits.status = ? " +
+		"GROUP BY projects.region ORDER BY total DESC"
+	if query != want {
+		t.Errorf("query =\n%s\nwant\n%s", query, want)
+	}
+
+	countQuery, _, err := buildCountQuery(config)
+	if err != nil || !strings.Contains(countQuery, "JOIN projects ON projects.id = carbon_credits.project_id") {
+		t.Errorf("count query must join too: %s (%v)", countQuery, err)
+	}
+}
+
+func TestJoins_RejectMissingOrUndeclaredConditions(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ReportConfig
+		path   string
+		code   string
+	}{
+		{
+			name:   "join without on",
+			config: creditsWithProjects(nil),
+			path:   "joins[0].on", code: ProblemMissingJoinCondition,
+		},
+		{
+			name:   "join on undeclared columns",
+			config: creditsWithProjects(&JoinCondition{Left: "projects.region", Right: "carbon_credits.status"}),
+			path:   "joins[0].on", code: ProblemIllegalJoin,
+		},
+		{
+			name:   "qualified column without a join",
+			config: ReportConfig{Dataset: "carbon_credits", Fields: []FieldConfig{{Name: "projects.region"}}},
+			path:   "fields[0].name", code: ProblemMissingJoinCondition,
+		},
+		{
+			name: "join before its key's dataset",
+			config: ReportConfig{
+				Dataset: "projects",
+				Joins:   []JoinConfig{{Dataset: "transactions", On: &JoinCondition{Left: "carbon_credits.id", Right: "transactions.credit_id"}}},
+				Fields:  []FieldConfig{{Name: "name"}},
+			},
+			path: "joins[0].on", code: ProblemIllegalJoin,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := validateConfig(t, tt.config)
+			if !hasProblem(response.Problems, tt.path, tt.code) {
+				t.Errorf("expected %s at %s, got %+v", tt.code, tt.path, response.Problems)
+			}
+			if len(tt.config.Joins) == 0 {
+				return
+			}
+			if err := validateReportConfig(tt.config); err == nil {
+				t.Error("expected the config to be refused on save")
+			}
+			if _, _, err := buildDynamicQuery(tt.config); err == nil {
+				t.Error("expected the query builder to refuse the join")
+			}
+		})
+	}
+}
+
+func TestBuildDynamicQuery_ReadsOnePastRowLimit(t *testing.T) {
+	config := ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}, rowLimit: 1000}
+
+	for limit, want := range map[int]string{0: "LIMIT 1001", 5000: "LIMIT 1001", 50: "LIMIT 50"} {
+		config.Limit = limit
+		if query, _, _ := buildDynamicQuery(config); !strings.HasSuffix(query, want) {
+			t.Errorf("limit %d: expected %s, got %s", limit, want, query)
+		}
+	}
+}
+
+func TestExecuteReport_FlagsTruncatedOutput(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+	svc.(*service).rowLimit = 2
+	ctx := context.Background()
+	owner := uuid.New()
+
+	report, err := svc.CreateReport(ctx, owner, CreateReportRequest{
+		Name:   "Projects",
+		Config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	run := func() *ReportExecution {
+		t.Helper()
+		execution, err := svc.ExecuteReport(ctx, owner, report.ID, ExecuteReportRequest{})
+		if err != nil {
+			t.Fatalf("ExecuteReport failed: %v", err)
+		}
+		svc.(*service).jobs.Wait()
+		return repo.executions[execution.ID]
+	}
+
+	repo.queryRows = []map[string]interface{}{{"name": "a"}, {"name": "b"}}
+	if got := run(); got.Status != StatusCompleted || got.Truncated {
+		t.Errorf("expected output at the limit to be complete, got %+v", got)
+	}
+
+	repo.queryRows = append(repo.queryRows, map[string]interface{}{"name": "c"})
+	got := run()
+	if got.Status != StatusCompleted || !got.Truncated || got.RowsProcessed != 2 {
+		t.Errorf("expected a completed execution truncated to 2 rows, got %+v", got)
+	}
+	if !strings.Contains(got.ExecutionLog, "truncated at 2 rows") {
+		t.Errorf("expected a truncation note, got %q", got.ExecutionLog)
+	}
+}
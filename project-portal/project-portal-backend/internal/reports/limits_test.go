@@ -0,0 +1,75 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateReportConfig_CapsListLengths(t *testing.T) {
+	base := func() ReportConfig {
+		return ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*ReportConfig)
+		field  string
+	}{
+		{"fields", func(c *ReportConfig) { c.Fields = make([]FieldConfig, MaxReportFields+1) }, "fields"},
+		{"filters", func(c *ReportConfig) { c.Filters = make([]FilterConfig, MaxReportFilters+1) }, "filters"},
+		{"groupings", func(c *ReportConfig) { c.Groupings = make([]GroupConfig, MaxReportGroupings+1) }, "groupings"},
+		{"sorts", func(c *ReportConfig) { c.Sorts = make([]SortConfig, MaxReportSorts+1) }, "sorts"},
+		{"calculations", func(c *ReportConfig) { c.Calculations = make([]CalculationConfig, MaxReportCalculations+1) }, "calculations"},
+	}
+
+	if err := validateReportConfig(base()); err != nil {
+		t.Fatalf("expected base config to be valid: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := base()
+			tt.mutate(&config)
+			status, body := middleware.ErrorBody(validateReportConfig(config))
+			if status != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", status)
+			}
+			if len(body.Details) != 1 || body.Details[0].Field != tt.field {
+				t.Errorf("expected detail for %q, got %+v", tt.field, body.Details)
+			}
+		})
+	}
+}
+
+func TestShareReport_RejectsOversizedUserList(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+
+	owner := uuid.New()
+	report, err := NewService(repo, nil).CreateReport(context.Background(), owner, CreateReportRequest{
+		Name:   "Shared",
+		Config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	userIDs := make([]uuid.UUID, 501)
+	for i := range userIDs {
+		userIDs[i] = uuid.New()
+	}
+	w := doAs(router, owner, http.MethodPost, "/api/v1/reports/"+report.ID.String()+"/share", ShareReportRequest{UserIDs: userIDs})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	var body middleware.ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	if len(body.Details) != 1 || body.Details[0].Field != "user_ids" {
+		t.Errorf("expected user_ids detail, got %+v", body.Details)
+	}
+}
@@ -55,6 +55,15 @@ const (
 	StatusFailed     ExecutionStatus = "failed"
 )
 
+// SearchEntityType names the kind of entity a search result refers to
+type SearchEntityType string
+
+const (
+	EntityReport    SearchEntityType = "report"
+	EntitySchedule  SearchEntityType = "schedule"
+	EntityBenchmark SearchEntityType = "benchmark"
+)
+
 // WidgetType defines the type of dashboard widget
 type WidgetType string
 
@@ -63,6 +72,9 @@ const (
 	WidgetMetric WidgetType = "metric"
 	WidgetTable  WidgetType = "table"
 	WidgetGauge  WidgetType = "gauge"
+
+	// WidgetSingleValue shows one aggregated metric and its change since the previous period
+	WidgetSingleValue WidgetType = "single_value"
 )
 
 // WidgetSize defines the size of a dashboard widget
@@ -94,6 +106,7 @@ type ReportDefinition struct {
 	Category          ReportCategory   `gorm:"type:varchar(100)" json:"category,omitempty"`
 	Config            datatypes.JSON   `gorm:"type:jsonb;not null" json:"config"`
 	CreatedBy         *uuid.UUID       `gorm:"type:uuid" json:"created_by,omitempty"`
+	OrganizationID    *uuid.UUID       `gorm:"type:uuid;index" json:"organization_id,omitempty"`
 	Visibility        ReportVisibility `gorm:"type:varchar(50);default:'private'" json:"visibility"`
 	SharedWithUsers   []uuid.UUID      `gorm:"type:uuid[]" json:"shared_with_users,omitempty"`
 	SharedWithRoles   []string         `gorm:"type:varchar(50)[]" json:"shared_with_roles,omitempty"`
@@ -112,12 +125,32 @@ func (ReportDefinition) TableName() string {
 // ReportConfig represents the JSON configuration of a report
 type ReportConfig struct {
 	Dataset      string              `json:"dataset"`
+	Joins        []JoinConfig        `json:"joins,omitempty"`
 	Fields       []FieldConfig       `json:"fields"`
 	Filters      []FilterConfig      `json:"filters,omitempty"`
 	Groupings    []GroupConfig       `json:"groupings,omitempty"`
 	Sorts        []SortConfig        `json:"sorts,omitempty"`
 	Calculations []CalculationConfig `json:"calculations,omitempty"`
 	Limit        int                 `json:"limit,omitempty"`
+
+	// projectScope is set by the service, never by clients, to enforce row-level access
+	projectScope *projectScope
+	// rowLimit is set by the service to cap the rows an execution reads
+	rowLimit int
+}
+
+// JoinConfig joins another dataset into the report. On must equate the columns of a
+// key declared between the two datasets; a join without one is rejected.
+type JoinConfig struct {
+	Dataset string         `json:"dataset"`
+	Type    string         `json:"type,omitempty"` // inner (default) or left
+	On      *JoinCondition `json:"on,omitempty"`
+}
+
+// JoinCondition equates two qualified columns, e.g. projects.id = carbon_credits.project_id
+type JoinCondition struct {
+	Left  string `json:"left"`
+	Right string `json:"right"`
 }
 
 // FieldConfig represents a field in the report
@@ -126,8 +159,8 @@ type FieldConfig struct {
 	Alias      string            `json:"alias,omitempty"`
 	Aggregate  AggregateFunction `json:"aggregate,omitempty"`
 	Format     string            `json:"format,omitempty"`
-	IsHidden   bool              `json:"is_hidden,omitempty"`
-	SortOrder  int               `json:"sort_order,omitempty"`
+	IsHidden   bool              `json:"is_hidden,omitempty"`  // Selected for filtering and grouping but left out of exports
+	SortOrder  int               `json:"sort_order,omitempty"` // Export column position; unset fields follow in config order
 	DataType   string            `json:"data_type,omitempty"`
 	IsEditable bool              `json:"is_editable,omitempty"`
 }
@@ -177,6 +210,9 @@ type ReportSchedule struct {
 	RecipientEmails    []string       `gorm:"type:text[]" json:"recipient_emails,omitempty"`
 	RecipientUserIDs   []uuid.UUID    `gorm:"type:uuid[]" json:"recipient_user_ids,omitempty"`
 	WebhookURL         string         `gorm:"type:text" json:"webhook_url,omitempty"`
+	LastRunAt          *time.Time     `json:"last_run_at,omitempty"`
+	NextRunAt          *time.Time     `gorm:"index" json:"next_run_at,omitempty"`
+	NextRunLocal       string         `gorm:"-" json:"next_run_local,omitempty"` // NextRunAt rendered in Timezone
 	CreatedAt          time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt          time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 
@@ -221,12 +257,18 @@ type ReportExecution struct {
 	Status             ExecutionStatus `gorm:"type:varchar(50);default:'pending'" json:"status"`
 	ErrorMessage       string          `gorm:"type:text" json:"error_message,omitempty"`
 	RecordCount        int             `json:"record_count,omitempty"`
+	RowsProcessed      int64           `gorm:"default:0" json:"rows_processed"`
+	ProgressPercent    int             `gorm:"default:0" json:"progress_percent"`
 	FileSizeBytes      int64           `json:"file_size_bytes,omitempty"`
 	FileKey            string          `gorm:"type:varchar(1000)" json:"file_key,omitempty"`
 	DownloadURL        string          `gorm:"type:text" json:"download_url,omitempty"`
+	DownloadExpiresAt  *time.Time      `gorm:"-" json:"download_expires_at,omitempty"`
 	DeliveryStatus     datatypes.JSON  `gorm:"type:jsonb" json:"delivery_status,omitempty"`
 	Parameters         datatypes.JSON  `gorm:"type:jsonb" json:"parameters,omitempty"`
 	ExecutionLog       string          `gorm:"type:text" json:"execution_log,omitempty"`
+	LegalHold          bool            `gorm:"not null;default:false" json:"legal_hold"` // Exempts the execution from retention purges
+	Truncated          bool            `gorm:"not null;default:false" json:"truncated"`  // Output was cut off at MaxReportRows
+	QueryPlan          string          `gorm:"type:text" json:"-"`                       // Scrubbed EXPLAIN output, only served to platform admins
 	CreatedAt          time.Time       `gorm:"autoCreateTime" json:"created_at"`
 
 	// Associations
@@ -239,6 +281,23 @@ func (ReportExecution) TableName() string {
 	return "report_executions"
 }
 
+// ReportVersion is an immutable snapshot of a report definition at a given version
+type ReportVersion struct {
+	ID                 uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	ReportDefinitionID uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_report_version" json:"report_definition_id"`
+	Version            int            `gorm:"not null;uniqueIndex:idx_report_version" json:"version"`
+	Name               string         `gorm:"type:varchar(255);not null" json:"name"`
+	Description        string         `gorm:"type:text" json:"description,omitempty"`
+	Config             datatypes.JSON `gorm:"type:jsonb;not null" json:"config"`
+	CreatedBy          *uuid.UUID     `gorm:"type:uuid" json:"created_by,omitempty"`
+	CreatedAt          time.Time      `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ReportVersion) TableName() string {
+	return "report_definition_versions"
+}
+
 // BenchmarkDataset represents industry benchmark data
 type BenchmarkDataset struct {
 	ID              uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
@@ -277,6 +336,7 @@ type BenchmarkData struct {
 type DashboardWidget struct {
 	ID                     uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	UserID                 *uuid.UUID     `gorm:"type:uuid" json:"user_id,omitempty"`
+	OrganizationID         *uuid.UUID     `gorm:"type:uuid;index" json:"organization_id,omitempty"`
 	DashboardSection       string         `gorm:"type:varchar(100)" json:"dashboard_section,omitempty"`
 	WidgetType             WidgetType     `gorm:"type:varchar(50);not null" json:"widget_type"`
 	Title                  string         `gorm:"type:varchar(255);not null" json:"title"`
@@ -319,6 +379,11 @@ type WidgetConfig struct {
 	Prefix        string `json:"prefix,omitempty"`
 	Suffix        string `json:"suffix,omitempty"`
 
+	// Single-value-specific
+	Metric      string            `json:"metric,omitempty"`      // credits, revenue, projects
+	Aggregation AggregateFunction `json:"aggregation,omitempty"` // SUM, AVG, COUNT, MIN, MAX
+	Window      string            `json:"window,omitempty"`      // day, week, month, quarter, year
+
 	// Gauge-specific
 	MinValue   float64 `json:"min_value,omitempty"`
 	MaxValue   float64 `json:"max_value,omitempty"`
@@ -335,6 +400,35 @@ type WidgetConfig struct {
 	Searchable bool          `json:"searchable,omitempty"`
 }
 
+// DashboardView is a user's saved dashboard: the widgets to show, the filters
+// applied to them and the time range they cover
+type DashboardView struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name      string         `gorm:"type:varchar(255);not null" json:"name"`
+	WidgetIDs []uuid.UUID    `gorm:"type:uuid[]" json:"widget_ids"`
+	Filters   datatypes.JSON `gorm:"type:jsonb" json:"filters,omitempty"`
+	TimeRange datatypes.JSON `gorm:"type:jsonb" json:"time_range,omitempty"`
+	IsDefault bool           `gorm:"default:false" json:"is_default"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Widgets resolves WidgetIDs, in order, when a single view is loaded
+	Widgets []DashboardWidget `gorm:"-" json:"widgets,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (DashboardView) TableName() string {
+	return "dashboard_views"
+}
+
+// DashboardTimeRange is either a relative preset or an absolute start/end window
+type DashboardTimeRange struct {
+	Preset string     `json:"preset,omitempty" binding:"omitempty,oneof=24h 7d 30d 90d 1y"`
+	Start  *time.Time `json:"start,omitempty"`
+	End    *time.Time `json:"end,omitempty"`
+}
+
 // ========== Request/Response Types ==========
 
 // CreateReportRequest represents the request to create a report
@@ -358,8 +452,11 @@ type UpdateReportRequest struct {
 
 // ExecuteReportRequest represents the request to execute a report
 type ExecuteReportRequest struct {
-	Format     ExportFormat   `json:"format,omitempty"`
+	Format     ExportFormat   `json:"format,omitempty" binding:"omitempty,oneof=csv excel pdf json"`
 	Parameters map[string]any `json:"parameters,omitempty"`
+	Locale     string         `json:"locale,omitempty" binding:"max=35"`      // BCP 47 tag such as de-DE; picks number separators and the default date format
+	DateFormat string         `json:"date_format,omitempty" binding:"max=64"` // Go reference layout such as 02.01.2006
+	Explain    bool           `json:"explain,omitempty"`                      // Capture the query plan; platform admins only, see ConfigureQueryPlans
 }
 
 // CreateScheduleRequest represents the request to create a schedule
@@ -370,14 +467,23 @@ type CreateScheduleRequest struct {
 	Timezone           string         `json:"timezone,omitempty"`
 	StartDate          *time.Time     `json:"start_date,omitempty"`
 	EndDate            *time.Time     `json:"end_date,omitempty"`
-	Format             ExportFormat   `json:"format" binding:"required"`
+	Format             ExportFormat   `json:"format" binding:"required,oneof=csv excel pdf json"`
 	DeliveryMethod     DeliveryMethod `json:"delivery_method" binding:"required"`
 	DeliveryConfig     map[string]any `json:"delivery_config" binding:"required"`
-	RecipientEmails    []string       `json:"recipient_emails,omitempty"`
-	RecipientUserIDs   []uuid.UUID    `json:"recipient_user_ids,omitempty"`
+	RecipientEmails    []string       `json:"recipient_emails,omitempty" binding:"max=100"`
+	RecipientUserIDs   []uuid.UUID    `json:"recipient_user_ids,omitempty" binding:"max=100"`
 	WebhookURL         string         `json:"webhook_url,omitempty"`
 }
 
+// SaveDashboardViewRequest represents the request to create or update a dashboard view
+type SaveDashboardViewRequest struct {
+	Name      string              `json:"name" binding:"required,max=255"`
+	WidgetIDs []uuid.UUID         `json:"widget_ids" binding:"max=50"`
+	Filters   []FilterConfig      `json:"filters,omitempty" binding:"max=50"`
+	TimeRange *DashboardTimeRange `json:"time_range,omitempty"`
+	IsDefault bool                `json:"is_default,omitempty"` // false leaves an existing default in place
+}
+
 // BenchmarkComparisonRequest represents the request for benchmark comparison
 type BenchmarkComparisonRequest struct {
 	ProjectID   uuid.UUID `json:"project_id" binding:"required"`
@@ -396,6 +502,42 @@ type BenchmarkComparisonResponse struct {
 	GapAnalysis    []GapAnalysisResult `json:"gap_analysis"`
 }
 
+// BenchmarkCohort selects the benchmark of one peer group. Name labels the cohort in
+// the response and defaults to its non-empty selectors.
+type BenchmarkCohort struct {
+	Name        string `json:"name,omitempty" binding:"max=100"`
+	Category    string `json:"category" binding:"required"`
+	Methodology string `json:"methodology,omitempty"`
+	Region      string `json:"region,omitempty"`
+	Year        int    `json:"year,omitempty"`
+}
+
+// CohortComparisonRequest compares one project against several cohorts at once
+type CohortComparisonRequest struct {
+	ProjectID uuid.UUID         `json:"project_id" binding:"required"`
+	Cohorts   []BenchmarkCohort `json:"cohorts" binding:"required,min=1,max=10,dive"`
+}
+
+// CohortComparisonResponse holds one comparison per requested cohort, in request order
+type CohortComparisonResponse struct {
+	ProjectID      uuid.UUID          `json:"project_id"`
+	ProjectMetrics map[string]float64 `json:"project_metrics"`
+	Cohorts        []CohortComparison `json:"cohorts"`
+}
+
+// CohortComparison is the project compared against one cohort's benchmark. A cohort
+// without an active benchmark is reported with Matched false rather than failing the request.
+type CohortComparison struct {
+	Cohort         BenchmarkCohort     `json:"cohort"`
+	Matched        bool                `json:"matched"`
+	BenchmarkID    *uuid.UUID          `json:"benchmark_id,omitempty"`
+	BenchmarkName  string              `json:"benchmark_name,omitempty"`
+	BenchmarkYear  int                 `json:"benchmark_year,omitempty"`
+	Benchmarks     []BenchmarkResult   `json:"benchmarks"`
+	PercentileRank map[string]float64  `json:"percentile_rank"`
+	GapAnalysis    []GapAnalysisResult `json:"gap_analysis"`
+}
+
 // BenchmarkResult represents a single benchmark comparison
 type BenchmarkResult struct {
 	Metric            string  `json:"metric"`
@@ -459,6 +601,7 @@ type DatasetMetadata struct {
 	Description string          `json:"description"`
 	Fields      []FieldMetadata `json:"fields"`
 	JoinWith    []string        `json:"join_with,omitempty"`
+	JoinKeys    []JoinCondition `json:"join_keys,omitempty"`
 }
 
 // FieldMetadata represents metadata for a dataset field
@@ -481,6 +624,37 @@ type ListReportsResponse struct {
 	TotalPages int                `json:"total_pages"`
 }
 
+// ReportVersionDiffResponse represents the config differences between two report versions
+type ReportVersionDiffResponse struct {
+	ReportID    uuid.UUID      `json:"report_id"`
+	FromVersion int            `json:"from_version"`
+	ToVersion   int            `json:"to_version"`
+	Changes     []ConfigChange `json:"changes"`
+}
+
+// RevertReportRequest represents a request to restore an earlier report version
+type RevertReportRequest struct {
+	Version int `json:"version" binding:"required,min=1"`
+}
+
+// SearchResult is one report, schedule or benchmark matching a search
+type SearchResult struct {
+	EntityType  SearchEntityType `json:"entity_type"`
+	ID          uuid.UUID        `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// SearchResponse represents one page of search results across entity types
+type SearchResponse struct {
+	Results    []SearchResult `json:"results"`
+	Total      int64          `json:"total"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+	TotalPages int            `json:"total_pages"`
+}
+
 // ListExecutionsResponse represents the response for listing executions
 type ListExecutionsResponse struct {
 	Executions []ReportExecution `json:"executions"`
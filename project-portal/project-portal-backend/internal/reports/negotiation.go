@@ -0,0 +1,103 @@
+package reports
+
+import (
+	"strconv"
+	"strings"
+)
+
+// exportMediaTypes lists the media types each export format is served as,
+// in the order preferred when the client accepts several equally
+var exportMediaTypes = []struct {
+	mediaType string
+	format    ExportFormat
+}{
+	{"text/csv", FormatCSV},
+	{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", FormatExcel},
+	{"application/pdf", FormatPDF},
+	{"application/json", FormatJSON},
+}
+
+// acceptedRange is one media range of an Accept header
+type acceptedRange struct {
+	mediaType string
+	quality   float64
+	position  int
+}
+
+// negotiateExportFormat picks the export format for an Accept header. An empty
+// header accepts anything; ok is false when no supported format is acceptable.
+func negotiateExportFormat(accept string) (format ExportFormat, ok bool) {
+	if strings.TrimSpace(accept) == "" {
+		return FormatCSV, true
+	}
+
+	ranges := parseAccept(accept)
+
+	bestQuality, bestPosition := 0.0, 0
+	for _, candidate := range exportMediaTypes {
+		match, found := matchAccept(ranges, candidate.mediaType)
+		if !found || match.quality <= 0 {
+			continue
+		}
+		// Higher quality wins, then whichever range the client listed first
+		if !ok || match.quality > bestQuality || (match.quality == bestQuality && match.position < bestPosition) {
+			format, ok = candidate.format, true
+			bestQuality, bestPosition = match.quality, match.position
+		}
+	}
+	return format, ok
+}
+
+// parseAccept splits an Accept header into media ranges, dropping malformed ones
+func parseAccept(accept string) []acceptedRange {
+	var ranges []acceptedRange
+	for i, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		if !strings.Contains(mediaType, "/") {
+			continue
+		}
+
+		quality, valid := 1.0, true
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.ToLower(strings.TrimSpace(key)) != "q" {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				valid = false
+				break
+			}
+			quality = q
+		}
+		if valid {
+			ranges = append(ranges, acceptedRange{mediaType: mediaType, quality: quality, position: i})
+		}
+	}
+	return ranges
+}
+
+// matchAccept returns the most specific range covering mediaType, so an exact
+// "text/csv;q=0" excludes CSV even when "*/*" is also accepted
+func matchAccept(ranges []acceptedRange, mediaType string) (acceptedRange, bool) {
+	mainType, _, _ := strings.Cut(mediaType, "/")
+
+	var best acceptedRange
+	bestSpecificity := -1
+	for _, r := range ranges {
+		specificity := -1
+		switch r.mediaType {
+		case mediaType:
+			specificity = 2
+		case mainType + "/*":
+			specificity = 1
+		case "*/*":
+			specificity = 0
+		}
+		if specificity > bestSpecificity {
+			best, bestSpecificity = r, specificity
+		}
+	}
+	return best, bestSpecificity >= 0
+}
@@ -0,0 +1,130 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
+	"github.com/google/uuid"
+)
+
+func TestNegotiateExportFormat(t *testing.T) {
+	const xlsx = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	tests := []struct {
+		accept string
+		want   ExportFormat
+		ok     bool
+	}{
+		{"", FormatCSV, true},
+		{"*/*", FormatCSV, true},
+		{"text/csv", FormatCSV, true},
+		{"application/json", FormatJSON, true},
+		{"application/pdf", FormatPDF, true},
+		{xlsx, FormatExcel, true},
+		{"Application/PDF; charset=binary", FormatPDF, true},
+		{"application/json;q=0.5, application/pdf", FormatPDF, true},
+		{"application/pdf, application/json", FormatPDF, true},
+		{"text/*", FormatCSV, true},
+		{"*/*, text/csv;q=0", FormatExcel, true},
+		{"text/html, application/pdf;q=0.1", FormatPDF, true},
+		{"application/pdf;q=abc, application/json", FormatJSON, true},
+		{"text/html", "", false},
+		{"image/*", "", false},
+		{"text/csv;q=0", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := negotiateExportFormat(tt.accept)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("negotiateExportFormat(%q) = %q, %v; want %q, %v", tt.accept, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestExportReport_FormatSelection(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+	owner := uuid.New()
+
+	report, err := NewService(repo, nil).CreateReport(context.Background(), owner, CreateReportRequest{
+		Name:   "Credits",
+		Config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+	exportPath := "/api/v1/reports/" + report.ID.String() + "/export"
+
+	tests := []struct {
+		name   string
+		query  string
+		accept string
+		status int
+		format ExportFormat
+	}{
+		{"default", "", "", http.StatusAccepted, FormatCSV},
+		{"accept header", "", "application/pdf", http.StatusAccepted, FormatPDF},
+		{"accept spreadsheet", "", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", http.StatusAccepted, FormatExcel},
+		{"query param", "?format=json", "", http.StatusAccepted, FormatJSON},
+		{"query param wins", "?format=excel", "application/pdf", http.StatusAccepted, FormatExcel},
+		{"query param overrides unsupported header", "?format=csv", "image/png", http.StatusAccepted, FormatCSV},
+		{"unsupported header", "", "image/png", http.StatusNotAcceptable, ""},
+		{"unknown query param", "?format=xml", "", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, exportPath+tt.query, nil)
+			req.Header.Set("X-User-ID", owner.String())
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d %s", tt.status, w.Code, w.Body.String())
+			}
+			if tt.format == "" {
+				return
+			}
+			var body struct {
+				Format ExportFormat `json:"format"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if body.Format != tt.format {
+				t.Errorf("expected format %q, got %q", tt.format, body.Format)
+			}
+		})
+	}
+}
+
+func TestExecuteReport_RejectsUnknownFormat(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+	owner := uuid.New()
+
+	report, err := svc.CreateReport(context.Background(), owner, CreateReportRequest{
+		Name:   "Credits",
+		Config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	_, err = svc.ExecuteReport(context.Background(), owner, report.ID, ExecuteReportRequest{Format: "xml"})
+	if !errors.Is(err, apperrors.ErrValidation) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if len(repo.executions) != 0 {
+		t.Error("no execution should be created for an unknown format")
+	}
+}
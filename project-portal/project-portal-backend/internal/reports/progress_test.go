@@ -0,0 +1,110 @@
+package reports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestExecutionProgress_ReportsIntermediateProgress(t *testing.T) {
+	repo := newFakeRepository()
+	for i := 0; i < ProgressReportInterval*3+10; i++ {
+		repo.queryRows = append(repo.queryRows, map[string]interface{}{"id": i})
+	}
+	svc := NewService(repo, nil)
+
+	owner := uuid.New()
+	ctx := context.Background()
+	report, err := svc.CreateReport(ctx, owner, CreateReportRequest{
+		Name:   "Large report",
+		Config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "id"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	execution, err := svc.ExecuteReport(ctx, owner, report.ID, ExecuteReportRequest{Format: FormatJSON})
+	if err != nil {
+		t.Fatalf("ExecuteReport failed: %v", err)
+	}
+
+	var final *ReportExecution
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		final, err = svc.GetExecution(ctx, owner, execution.ID)
+		if err == nil && final.Status == StatusCompleted {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if final == nil || final.Status != StatusCompleted {
+		t.Fatalf("execution did not complete in time")
+	}
+
+	repo.mu.Lock()
+	updates := append([]int(nil), repo.progressUpdates...)
+	repo.mu.Unlock()
+
+	if len(updates) != 3 {
+		t.Fatalf("expected 3 intermediate progress updates, got %v", updates)
+	}
+	for i, percent := range updates {
+		if percent <= 0 || percent >= 100 {
+			t.Errorf("expected intermediate progress between 0 and 100, got %d", percent)
+		}
+		if i > 0 && percent <= updates[i-1] {
+			t.Errorf("expected progress to increase, got %v", updates)
+		}
+	}
+
+	if final.ProgressPercent != 100 {
+		t.Errorf("expected completed execution at 100%%, got %d", final.ProgressPercent)
+	}
+	if final.RowsProcessed != int64(len(repo.queryRows)) {
+		t.Errorf("expected %d rows processed, got %d", len(repo.queryRows), final.RowsProcessed)
+	}
+}
+
+func TestQueryProgressPercent(t *testing.T) {
+	tests := []struct {
+		processed, total int64
+		want             int
+	}{
+		{0, 0, 0},
+		{50, 200, 25},
+		{200, 200, 99},
+		{300, 200, 99},
+	}
+	for _, tt := range tests {
+		if got := queryProgressPercent(tt.processed, tt.total); got != tt.want {
+			t.Errorf("queryProgressPercent(%d, %d) = %d, want %d", tt.processed, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestBuildCountQuery_CountsGroups(t *testing.T) {
+	grouped := ReportConfig{
+		Dataset:   "carbon_credits",
+		Fields:    []FieldConfig{{Name: "vintage"}, {Name: "quantity", Aggregate: AggregateSum, Alias: "total"}},
+		Filters:   []FilterConfig{{Field: "status", Operator: "eq", Value: "issued"}},
+		Groupings: []GroupConfig{{Field: "vintage"}},
+		Sorts:     []SortConfig{{Field: "total", Direction: "desc"}},
+		Limit:     10,
+	}
+	query, args, err := buildCountQuery(grouped)
+	if err != nil {
+		t.Fatalf("buildCountQuery: %v", err)
+	}
+	want := "SELECT COUNT(*) FROM (SELECT vintage, SUM(quantity) AS total FROM carbon_credits " +
+		"WHERE status = ? GROUP BY vintage) t"
+	if query != want || len(args) != 1 {
+		t.Errorf("query =\n%s (%v)\nwant\n%s", query, args, want)
+	}
+
+	plain := ReportConfig{Dataset: "carbon_credits", Fields: []FieldConfig{{Name: "vintage"}}}
+	if query, _, _ := buildCountQuery(plain); query != "SELECT COUNT(*) FROM carbon_credits" {
+		t.Errorf("ungrouped reports should count source rows, got %s", query)
+	}
+}
@@ -0,0 +1,84 @@
+package reports
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strings"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+	"carbon-scribe/project-portal/project-portal-backend/pkg/requestid"
+
+	"github.com/google/uuid"
+)
+
+// ExecutionPlanResponse is the captured query plan of an execution
+type ExecutionPlanResponse struct {
+	ExecutionID uuid.UUID `json:"execution_id"`
+	QueryPlan   string    `json:"query_plan"`
+}
+
+var (
+	// planString matches a quoted literal such as 'issued'::text or '{...}'::uuid[]
+	planString = regexp.MustCompile(`'(?:[^']|'')*'`)
+	// planToken splits a condition into identifiers, parameters and numbers
+	planToken = regexp.MustCompile(`[\w$.]+`)
+)
+
+// ConfigureQueryPlans lets executions capture the plan of their query. It is off by
+// default because a capture adds a planning round trip to every execution.
+func (s *service) ConfigureQueryPlans(enabled bool) {
+	s.queryPlans = enabled
+}
+
+// captureQueryPlan stores the scrubbed plan of config on execution. A failed capture
+// is only logged; the report itself has already succeeded.
+func (s *service) captureQueryPlan(ctx context.Context, execution *ReportExecution, config ReportConfig) {
+	plan, err := s.repo.ExplainDynamicQuery(ctx, config)
+	if err != nil {
+		log.Printf("[%s] query plan of execution %s not captured: %v", requestid.FromContext(ctx), execution.ID, err)
+		execution.ExecutionLog += "query plan capture failed\n"
+		return
+	}
+	execution.QueryPlan = scrubQueryPlan(plan)
+}
+
+func (s *service) GetExecutionPlan(ctx context.Context, executionID uuid.UUID) (*ExecutionPlanResponse, error) {
+	execution, _, err := s.getTenantExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	if execution.QueryPlan == "" {
+		return nil, apperrors.NotFound("no query plan was captured for this execution")
+	}
+
+	return &ExecutionPlanResponse{ExecutionID: execution.ID, QueryPlan: execution.QueryPlan}, nil
+}
+
+// scrubQueryPlan replaces the literal values postgres inlines into a plan with '?'
+// and ?, so filter values never reach the operators reading it. Numbers are only
+// replaced in conditions, leaving costs and row estimates intact.
+func scrubQueryPlan(plan string) string {
+	lines := strings.Split(plan, "\n")
+	for i, line := range lines {
+		line = planString.ReplaceAllString(line, "'?'")
+
+		if colon := strings.Index(line, ": "); colon > 0 {
+			label := strings.TrimSpace(line[:colon])
+			condition := strings.HasSuffix(label, "Cond") || strings.HasSuffix(label, "Filter") || strings.HasSuffix(label, "Key")
+			if condition && !strings.HasPrefix(label, "Rows Removed") {
+				line = line[:colon] + planToken.ReplaceAllStringFunc(line[colon:], scrubNumber)
+			}
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// scrubNumber hides a numeric literal but keeps identifiers such as t1.col2 and $1
+func scrubNumber(token string) string {
+	if token[0] < '0' || token[0] > '9' {
+		return token
+	}
+	return "?"
+}
@@ -0,0 +1,143 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const samplePlan = `Hash Join  (cost=12.50..40.75 rows=4 width=64) (actual time=0.210..0.230 rows=3 loops=1)
+  Hash Cond: (c.project_id = p.id)
+  ->  Seq Scan on carbon_credits c  (cost=0.00..25.88 rows=6 width=48) (actual time=0.010..0.012 rows=3 loops=1)
+        Filter: (((status)::text = 'issued'::text) AND (vintage_year >= 2020) AND (quantity > 1.5))
+        Rows Removed by Filter: 2
+  ->  Hash  (cost=10.00..10.00 rows=200 width=16) (actual time=0.050..0.051 rows=1 loops=1)
+        ->  Index Scan using projects_pkey on projects p  (cost=0.15..10.00 rows=1 width=16)
+              Index Cond: (id = ANY ('{8f14e45f-ceea-467f-a8f1-2b2f0e5b6c3d}'::uuid[]))
+Planning Time: 0.120 ms
+Execution Time: 0.300 ms`
+
+func TestScrubQueryPlan(t *testing.T) {
+	scrubbed := scrubQueryPlan(samplePlan)
+
+	for _, literal := range []string{"issued", "2020", "1.5", "8f14e45f"} {
+		if strings.Contains(scrubbed, literal) {
+			t.Errorf("literal %q survived scrubbing:\n%s", literal, scrubbed)
+		}
+	}
+	for _, kept := range []string{
+		"(cost=12.50..40.75 rows=4 width=64)",
+		"Hash Cond: (c.project_id = p.id)",
+		"Filter: (((status)::text = '?'::text) AND (vintage_year >= ?) AND (quantity > ?))",
+		"Rows Removed by Filter: 2",
+		"Execution Time: 0.300 ms",
+	} {
+		if !strings.Contains(scrubbed, kept) {
+			t.Errorf("expected %q in the scrubbed plan:\n%s", kept, scrubbed)
+		}
+	}
+}
+
+func TestExecuteReport_CapturesQueryPlan(t *testing.T) {
+	repo := newFakeRepository()
+	repo.queryPlan = samplePlan
+	svc := NewService(repo, nil)
+	svc.ConfigureQueryPlans(true)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	NewHandler(svc).RegisterRoutes(router.Group("/api/v1"), testAuth())
+
+	owner := uuid.New()
+	report, err := svc.CreateReport(context.Background(), owner, CreateReportRequest{
+		Name:   "Issued credits",
+		Config: ReportConfig{Dataset: "carbon_credits", Fields: []FieldConfig{{Name: "quantity"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	do := func(role, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User-ID", owner.String())
+		req.Header.Set("X-Test-Role", role)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	execute := func(role string) *httptest.ResponseRecorder {
+		return do(role, http.MethodPost, "/api/v1/reports/"+report.ID.String()+"/execute", `{"explain": true}`)
+	}
+
+	if w := execute("analyst"); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a non-admin asking for a plan, got %d", w.Code)
+	}
+
+	w := execute(RolePlatformAdmin)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	var execution ReportExecution
+	if err := json.Unmarshal(w.Body.Bytes(), &execution); err != nil {
+		t.Fatalf("invalid execution response: %v", err)
+	}
+	if err := svc.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	stored := repo.executions[execution.ID]
+	if stored.Status != StatusCompleted || stored.QueryPlan == "" {
+		t.Fatalf("expected a completed execution with a plan, got %+v", stored)
+	}
+	if strings.Contains(stored.QueryPlan, "issued") {
+		t.Errorf("stored plan was not scrubbed:\n%s", stored.QueryPlan)
+	}
+
+	path := "/api/v1/reports/admin/executions/" + execution.ID.String() + "/plan"
+	if w := do("analyst", http.MethodGet, path, ""); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 reading a plan as non-admin, got %d", w.Code)
+	}
+	w = do(RolePlatformAdmin, http.MethodGet, path, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var plan ExecutionPlanResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &plan); err != nil {
+		t.Fatalf("invalid plan response: %v", err)
+	}
+	if plan.ExecutionID != execution.ID || plan.QueryPlan != stored.QueryPlan {
+		t.Errorf("unexpected plan response: %+v", plan)
+	}
+}
+
+func TestExecuteReport_RejectsExplainWhenDisabled(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+	ctx := context.Background()
+	owner := uuid.New()
+
+	report, err := svc.CreateReport(ctx, owner, CreateReportRequest{
+		Name:   "Projects",
+		Config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	if _, err := svc.ExecuteReport(ctx, owner, report.ID, ExecuteReportRequest{Explain: true}); err == nil {
+		t.Fatal("expected explain to be refused while query plans are disabled")
+	}
+	if len(repo.executions) != 0 {
+		t.Error("a refused execution must not be created")
+	}
+}
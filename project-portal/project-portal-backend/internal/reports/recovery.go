@@ -0,0 +1,174 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryAction is what an operator does with a stuck execution
+type RecoveryAction string
+
+const (
+	RecoveryRequeue RecoveryAction = "requeue"
+	RecoveryFail    RecoveryAction = "fail"
+)
+
+// DefaultStuckExecutionAge is how long an execution may sit in processing before it
+// counts as orphaned. A live execution is failed at ExecutionTimeout, so anything
+// older belongs to a worker that died.
+const DefaultStuckExecutionAge = ExecutionTimeout + 5*time.Minute
+
+// ListStuckExecutions returns processing executions triggered more than olderThan ago
+func (s *service) ListStuckExecutions(ctx context.Context, olderThan time.Duration) ([]ReportExecution, error) {
+	if olderThan < ExecutionTimeout {
+		return nil, apperrors.Field("older_than", fmt.Sprintf("must be at least %s so live executions are never touched", ExecutionTimeout))
+	}
+
+	executions, err := s.repo.ListStuckExecutions(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stuck executions: %w", err)
+	}
+
+	stuck := executions[:0]
+	for _, execution := range executions {
+		if !s.isRunning(execution.ID) {
+			stuck = append(stuck, execution)
+		}
+	}
+	return stuck, nil
+}
+
+// SweepStuckExecutions marks orphaned processing executions as failed. It runs at
+// startup, before this process has started any execution of its own.
+func (s *service) SweepStuckExecutions(ctx context.Context, olderThan time.Duration) (int, error) {
+	executions, err := s.ListStuckExecutions(ctx, olderThan)
+	if err != nil {
+		return 0, err
+	}
+
+	swept := 0
+	for i := range executions {
+		execution := &executions[i]
+		failStuckExecution(execution, "orphaned: still processing at startup")
+		if err := s.repo.UpdateExecution(ctx, execution); err != nil {
+			return swept, fmt.Errorf("failed to mark execution %s failed: %w", execution.ID, err)
+		}
+		swept++
+	}
+	return swept, nil
+}
+
+// RecoverExecution fails a stuck execution or runs it again under the same ID
+func (s *service) RecoverExecution(ctx context.Context, executionID uuid.UUID, action RecoveryAction) (*ReportExecution, error) {
+	execution, err := s.repo.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "execution not found", err)
+	}
+
+	if execution.Status != StatusProcessing || time.Since(execution.TriggeredAt) < ExecutionTimeout || s.isRunning(execution.ID) {
+		return nil, apperrors.Conflict(fmt.Sprintf("execution %s is not stuck", execution.ID))
+	}
+
+	switch action {
+	case RecoveryFail:
+		failStuckExecution(execution, "marked failed by an operator")
+		if err := s.repo.UpdateExecution(ctx, execution); err != nil {
+			return nil, fmt.Errorf("failed to update execution: %w", err)
+		}
+		return execution, nil
+	case RecoveryRequeue:
+		return s.requeueExecution(ctx, execution)
+	default:
+		return nil, apperrors.Field("action", "must be requeue or fail")
+	}
+}
+
+// requeueExecution restarts a stuck execution from scratch. Schedules rerun in their
+// configured format; ad-hoc runs use the default format since the requested one is not stored.
+func (s *service) requeueExecution(ctx context.Context, execution *ReportExecution) (*ReportExecution, error) {
+	if execution.ReportDefinitionID == nil {
+		return nil, apperrors.Conflict("execution has no report to rerun")
+	}
+	report, err := s.repo.GetReportDefinition(ctx, *execution.ReportDefinitionID)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "report not found", err)
+	}
+
+	var config ReportConfig
+	if err := json.Unmarshal(report.Config, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse report config: %w", err)
+	}
+
+	runAs := ownerOf(report)
+	if execution.ScheduleID == nil && execution.TriggeredBy != nil {
+		runAs = *execution.TriggeredBy
+	}
+	if err := s.scopeToMemberProjects(ctx, &config, runAs); err != nil {
+		return nil, err
+	}
+
+	var req ExecuteReportRequest
+	if execution.Schedule != nil {
+		req.Format = execution.Schedule.Format
+	}
+	if len(execution.Parameters) > 0 {
+		if err := json.Unmarshal(execution.Parameters, &req.Parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse execution parameters: %w", err)
+		}
+	}
+
+	now := time.Now()
+	execution.ExecutionLog += fmt.Sprintf("requeued at %s after stalling since %s\n",
+		now.UTC().Format(time.RFC3339), execution.TriggeredAt.UTC().Format(time.RFC3339))
+	execution.TriggeredAt = now
+	execution.RowsProcessed = 0
+	execution.ProgressPercent = 0
+	execution.ErrorMessage = ""
+	execution.ReportDefinition = nil
+	execution.Schedule = nil
+
+	runCtx, done, err := s.startExecution(ctx, execution.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateExecution(ctx, execution); err != nil {
+		done()
+		return nil, fmt.Errorf("failed to update execution: %w", err)
+	}
+
+	log.Printf("Requeued stuck report execution %s", execution.ID)
+
+	snapshot := *execution
+	go func() {
+		defer done()
+		s.processReportExecution(runCtx, execution, config, req)
+	}()
+
+	return &snapshot, nil
+}
+
+// failStuckExecution records that an execution never finished and why it was closed
+func failStuckExecution(execution *ReportExecution, reason string) {
+	now := time.Now()
+	execution.Status = StatusFailed
+	execution.ErrorMessage = fmt.Sprintf("Execution stalled since %s; %s", execution.TriggeredAt.UTC().Format(time.RFC3339), reason)
+	execution.CompletedAt = &now
+	execution.ReportDefinition = nil
+	execution.Schedule = nil
+}
+
+// isRunning reports whether this process is still working on the execution
+func (s *service) isRunning(executionID uuid.UUID) bool {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+	_, ok := s.running[executionID]
+	return ok
+}
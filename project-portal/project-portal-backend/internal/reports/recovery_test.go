@@ -0,0 +1,146 @@
+package reports
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func seedExecution(repo *fakeRepository, status ExecutionStatus, age time.Duration) *ReportExecution {
+	execution := &ReportExecution{
+		ID:          uuid.New(),
+		Status:      status,
+		TriggeredAt: time.Now().Add(-age),
+	}
+	repo.executions[execution.ID] = execution
+	return execution
+}
+
+func TestSweepStuckExecutions_FailsOnlyOrphanedProcessing(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+
+	orphaned := seedExecution(repo, StatusProcessing, 2*time.Hour)
+	recent := seedExecution(repo, StatusProcessing, 10*time.Minute)
+	finished := seedExecution(repo, StatusCompleted, 3*time.Hour)
+	pending := seedExecution(repo, StatusPending, 3*time.Hour)
+
+	swept, err := svc.SweepStuckExecutions(context.Background(), DefaultStuckExecutionAge)
+	if err != nil {
+		t.Fatalf("SweepStuckExecutions failed: %v", err)
+	}
+	if swept != 1 {
+		t.Fatalf("expected 1 swept execution, got %d", swept)
+	}
+
+	got := repo.executions[orphaned.ID]
+	if got.Status != StatusFailed || got.CompletedAt == nil || !strings.Contains(got.ErrorMessage, "orphaned") {
+		t.Errorf("orphaned execution not failed with a note: %+v", got)
+	}
+	for _, kept := range []*ReportExecution{recent, finished, pending} {
+		if repo.executions[kept.ID].Status == StatusFailed {
+			t.Errorf("execution %s (%s) must not be swept", kept.ID, kept.Status)
+		}
+	}
+}
+
+func TestListStuckExecutions_RefusesThresholdBelowTimeout(t *testing.T) {
+	svc := NewService(newFakeRepository(), nil)
+	if _, err := svc.ListStuckExecutions(context.Background(), time.Minute); err == nil {
+		t.Fatal("expected a threshold below ExecutionTimeout to be rejected")
+	}
+}
+
+func TestRecoverExecution(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+	ctx := context.Background()
+
+	report, err := svc.CreateReport(ctx, uuid.New(), CreateReportRequest{
+		Name:   "Credits",
+		Config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		stuck := seedExecution(repo, StatusProcessing, time.Hour)
+		execution, err := svc.RecoverExecution(ctx, stuck.ID, RecoveryFail)
+		if err != nil {
+			t.Fatalf("RecoverExecution failed: %v", err)
+		}
+		if execution.Status != StatusFailed || !strings.Contains(execution.ErrorMessage, "operator") {
+			t.Errorf("unexpected execution: %+v", execution)
+		}
+	})
+
+	t.Run("not stuck", func(t *testing.T) {
+		for _, execution := range []*ReportExecution{
+			seedExecution(repo, StatusProcessing, time.Minute),
+			seedExecution(repo, StatusCompleted, time.Hour),
+		} {
+			if _, err := svc.RecoverExecution(ctx, execution.ID, RecoveryFail); err == nil {
+				t.Errorf("expected %s execution aged %s to be refused", execution.Status, time.Since(execution.TriggeredAt).Round(time.Minute))
+			}
+		}
+	})
+
+	t.Run("requeue", func(t *testing.T) {
+		stuck := seedExecution(repo, StatusProcessing, time.Hour)
+		stuck.ReportDefinitionID = &report.ID
+
+		execution, err := svc.RecoverExecution(ctx, stuck.ID, RecoveryRequeue)
+		if err != nil {
+			t.Fatalf("RecoverExecution failed: %v", err)
+		}
+		if execution.ID != stuck.ID || time.Since(execution.TriggeredAt) > time.Minute {
+			t.Errorf("expected the same execution restarted now, got %+v", execution)
+		}
+
+		// Shutdown waits for the rerun to finish
+		if err := svc.Shutdown(ctx); err != nil {
+			t.Fatalf("Shutdown failed: %v", err)
+		}
+		got, _ := repo.GetExecution(ctx, stuck.ID)
+		if got.Status != StatusCompleted || !strings.Contains(got.ExecutionLog, "requeued") {
+			t.Errorf("expected a completed rerun with a requeue note, got %+v", got)
+		}
+	})
+}
+
+func TestStuckExecutionRoutes_RequirePlatformAdmin(t *testing.T) {
+	repo := newFakeRepository()
+	seedExecution(repo, StatusProcessing, 2*time.Hour)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	NewHandler(NewService(repo, nil)).RegisterRoutes(router.Group("/api/v1"), testAuth())
+
+	get := func(role, query string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/reports/admin/executions/stuck"+query, nil)
+		req.Header.Set("X-Test-Role", role)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := get("analyst", ""); code != http.StatusForbidden {
+		t.Errorf("expected 403 for non-admin, got %d", code)
+	}
+	if code := get(RolePlatformAdmin, ""); code != http.StatusOK {
+		t.Errorf("expected 200 for admin, got %d", code)
+	}
+	if code := get(RolePlatformAdmin, "?older_than=5m"); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a threshold below the execution timeout, got %d", code)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Repository defines the interface for reports data access
@@ -21,6 +23,15 @@ type Repository interface {
 	DeleteReportDefinition(ctx context.Context, id uuid.UUID) error
 	ListReportDefinitions(ctx context.Context, filter ReportFilter) ([]ReportDefinition, int64, error)
 	ListTemplates(ctx context.Context) ([]ReportDefinition, error)
+	Search(ctx context.Context, filter SearchFilter) ([]SearchResult, int64, error)
+	UpdateReportSharing(ctx context.Context, id uuid.UUID, visibility ReportVisibility, sharedWithUsers []uuid.UUID) error
+	FindMissingUsers(ctx context.Context, userIDs []uuid.UUID) ([]uuid.UUID, error)
+	ListMemberProjectIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+
+	// Report Versions
+	CreateReportVersion(ctx context.Context, version *ReportVersion) error
+	GetReportVersion(ctx context.Context, reportID uuid.UUID, version int) (*ReportVersion, error)
+	ListReportVersions(ctx context.Context, reportID uuid.UUID) ([]ReportVersion, error)
 
 	// Report Schedules
 	CreateSchedule(ctx context.Context, schedule *ReportSchedule) error
@@ -30,13 +41,20 @@ type Repository interface {
 	ListSchedules(ctx context.Context, filter ScheduleFilter) ([]ReportSchedule, int64, error)
 	GetActiveSchedules(ctx context.Context) ([]ReportSchedule, error)
 	GetDueSchedules(ctx context.Context, now time.Time) ([]ReportSchedule, error)
+	UpdateScheduleRunTimes(ctx context.Context, id uuid.UUID, lastRunAt, nextRunAt *time.Time) error
+	UpdateScheduleRecipients(ctx context.Context, id uuid.UUID, emails []string, userIDs []uuid.UUID) error
 
 	// Report Executions
 	CreateExecution(ctx context.Context, execution *ReportExecution) error
 	GetExecution(ctx context.Context, id uuid.UUID) (*ReportExecution, error)
 	UpdateExecution(ctx context.Context, execution *ReportExecution) error
+	FinishExecution(ctx context.Context, execution *ReportExecution) (bool, error)
+	UpdateExecutionProgress(ctx context.Context, id uuid.UUID, rowsProcessed int64, progressPercent int) error
 	ListExecutions(ctx context.Context, filter ExecutionFilter) ([]ReportExecution, int64, error)
 	GetPendingExecutions(ctx context.Context) ([]ReportExecution, error)
+	ListStuckExecutions(ctx context.Context, startedBefore time.Time) ([]ReportExecution, error)
+	CountPurgeableExecutions(ctx context.Context, before time.Time) (int64, error)
+	PurgeExecutions(ctx context.Context, before time.Time) (int64, []string, error)
 
 	// Benchmark Datasets
 	CreateBenchmarkDataset(ctx context.Context, dataset *BenchmarkDataset) error
@@ -51,16 +69,27 @@ type Repository interface {
 	GetWidget(ctx context.Context, id uuid.UUID) (*DashboardWidget, error)
 	UpdateWidget(ctx context.Context, widget *DashboardWidget) error
 	DeleteWidget(ctx context.Context, id uuid.UUID) error
-	ListWidgetsByUser(ctx context.Context, userID uuid.UUID) ([]DashboardWidget, error)
-	ListWidgetsBySection(ctx context.Context, section string) ([]DashboardWidget, error)
+	ListWidgetsByUser(ctx context.Context, userID uuid.UUID, tenant Tenant) ([]DashboardWidget, error)
+	ListWidgetsBySection(ctx context.Context, userID uuid.UUID, section string, tenant Tenant) ([]DashboardWidget, error)
 	UpdateWidgetPositions(ctx context.Context, userID uuid.UUID, positions map[uuid.UUID]int) error
 
+	// Dashboard Views
+	CreateDashboardView(ctx context.Context, view *DashboardView) error
+	GetDashboardView(ctx context.Context, id uuid.UUID) (*DashboardView, error)
+	UpdateDashboardView(ctx context.Context, view *DashboardView) error
+	DeleteDashboardView(ctx context.Context, id uuid.UUID) error
+	ListDashboardViews(ctx context.Context, userID uuid.UUID) ([]DashboardView, error)
+	GetDefaultDashboardView(ctx context.Context, userID uuid.UUID) (*DashboardView, error)
+	SetDefaultDashboardView(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+
 	// Dashboard Data
-	GetDashboardSummary(ctx context.Context, userID *uuid.UUID) (*DashboardSummary, error)
-	GetTimeSeriesData(ctx context.Context, metric string, startTime, endTime time.Time, interval string) ([]TimeSeriesPoint, error)
+	GetDashboardSummary(ctx context.Context, userID *uuid.UUID, tenant Tenant) (*DashboardSummary, error)
+	GetTimeSeriesData(ctx context.Context, metric string, startTime, endTime time.Time, interval string, tenant Tenant) ([]TimeSeriesPoint, error)
+	AggregateMetric(ctx context.Context, metric string, aggregate AggregateFunction, start, end time.Time, tenant Tenant) (float64, error)
 
 	// Dynamic Query Execution
-	ExecuteDynamicQuery(ctx context.Context, config ReportConfig) ([]map[string]interface{}, int64, error)
+	ExecuteDynamicQuery(ctx context.Context, config ReportConfig, onProgress QueryProgressFunc) ([]map[string]interface{}, int64, error)
+	ExplainDynamicQuery(ctx context.Context, config ReportConfig) (string, error)
 }
 
 // ReportFilter defines filtering options for reports
@@ -70,15 +99,27 @@ type ReportFilter struct {
 	Visibility ReportVisibility
 	IsTemplate *bool
 	Search     string
+	Tenant     Tenant
 	Page       int
 	PageSize   int
 }
 
+// SearchFilter defines a search across report-related entities
+type SearchFilter struct {
+	Query    string
+	Types    []SearchEntityType // Empty searches every type
+	UserID   uuid.UUID
+	Tenant   Tenant
+	Page     int
+	PageSize int
+}
+
 // ScheduleFilter defines filtering options for schedules
 type ScheduleFilter struct {
 	ReportDefinitionID *uuid.UUID
 	IsActive           *bool
 	Format             ExportFormat
+	Tenant             Tenant
 	Page               int
 	PageSize           int
 }
@@ -91,6 +132,7 @@ type ExecutionFilter struct {
 	Status             ExecutionStatus
 	StartDate          *time.Time
 	EndDate            *time.Time
+	Tenant             Tenant
 	Page               int
 	PageSize           int
 }
@@ -157,6 +199,7 @@ func (r *repository) ListReportDefinitions(ctx context.Context, filter ReportFil
 	if filter.IsTemplate != nil {
 		query = query.Where("is_template = ?", *filter.IsTemplate)
 	}
+	query = scopeToTenant(query, filter.Tenant, "organization_id")
 	if filter.Search != "" {
 		searchPattern := "%" + filter.Search + "%"
 		query = query.Where("name ILIKE ? OR description ILIKE ?", searchPattern, searchPattern)
@@ -193,6 +236,188 @@ func (r *repository) ListTemplates(ctx context.Context) ([]ReportDefinition, err
 	return templates, nil
 }
 
+// Search matches report definitions, schedules and benchmarks by name or description
+// in one paginated query, newest first. Reports and schedules are limited to those
+// whose report the user may see; inactive benchmarks only show for cross-org admins.
+func (r *repository) Search(ctx context.Context, filter SearchFilter) ([]SearchResult, int64, error) {
+	query := searchQuery(r.db.WithContext(ctx), filter)
+	if query == nil {
+		return nil, 0, nil
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order("updated_at DESC, id ASC")
+	if filter.PageSize > 0 {
+		query = query.Limit(filter.PageSize)
+		if filter.Page > 0 {
+			query = query.Offset((filter.Page - 1) * filter.PageSize)
+		}
+	}
+
+	var results []SearchResult
+	if err := query.Scan(&results).Error; err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+// searchQuery unions the per-entity queries into one table of SearchResult rows;
+// it is nil when no entity type is requested
+func searchQuery(db *gorm.DB, filter SearchFilter) *gorm.DB {
+	// Each branch must start from a clean statement rather than extend the previous one
+	db = db.Session(&gorm.Session{NewDB: true})
+	branches := searchBranches(db, filter)
+	if len(branches) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(branches))
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+	union := db.Raw(stringJoin(placeholders, " UNION ALL "), branches...)
+	return db.Table("(?) AS search_results", union)
+}
+
+// searchBranches builds one SELECT per requested entity type, each projecting the
+// columns of SearchResult
+func searchBranches(db *gorm.DB, filter SearchFilter) []interface{} {
+	wanted := func(entity SearchEntityType) bool {
+		if len(filter.Types) == 0 {
+			return true
+		}
+		for _, t := range filter.Types {
+			if t == entity {
+				return true
+			}
+		}
+		return false
+	}
+	pattern := "%" + filter.Query + "%"
+
+	var branches []interface{}
+	if wanted(EntityReport) {
+		query := db.Model(&ReportDefinition{}).
+			Select("'report' AS entity_type, report_definitions.id, report_definitions.name, COALESCE(report_definitions.description, '') AS description, report_definitions.updated_at")
+		query = visibleReports(query, filter.UserID)
+		query = scopeToTenant(query, filter.Tenant, "report_definitions.organization_id")
+		if filter.Query != "" {
+			query = query.Where("report_definitions.name ILIKE ? OR report_definitions.description ILIKE ?", pattern, pattern)
+		}
+		branches = append(branches, query)
+	}
+	if wanted(EntitySchedule) {
+		query := db.Model(&ReportSchedule{}).
+			Select("'schedule' AS entity_type, report_schedules.id, report_schedules.name, '' AS description, report_schedules.updated_at").
+			Joins("JOIN report_definitions ON report_definitions.id = report_schedules.report_definition_id")
+		query = visibleReports(query, filter.UserID)
+		query = scopeToTenant(query, filter.Tenant, "report_definitions.organization_id")
+		if filter.Query != "" {
+			query = query.Where("report_schedules.name ILIKE ?", pattern)
+		}
+		branches = append(branches, query)
+	}
+	if wanted(EntityBenchmark) {
+		query := db.Model(&BenchmarkDataset{}).
+			Select("'benchmark' AS entity_type, benchmark_datasets.id, benchmark_datasets.name, COALESCE(benchmark_datasets.description, '') AS description, benchmark_datasets.updated_at")
+		if !filter.Tenant.CrossOrg {
+			query = query.Where("benchmark_datasets.is_active = ?", true)
+		}
+		if filter.Query != "" {
+			query = query.Where("benchmark_datasets.name ILIKE ? OR benchmark_datasets.description ILIKE ?", pattern, pattern)
+		}
+		branches = append(branches, query)
+	}
+	return branches
+}
+
+// visibleReports restricts a query joined to report_definitions to reports the user
+// created, can see publicly, or had shared with them
+func visibleReports(query *gorm.DB, userID uuid.UUID) *gorm.DB {
+	return query.Where("(report_definitions.created_by = ? OR report_definitions.visibility = ? OR ? = ANY(report_definitions.shared_with_users))",
+		userID, VisibilityPublic, userID)
+}
+
+func (r *repository) UpdateReportSharing(ctx context.Context, id uuid.UUID, visibility ReportVisibility, sharedWithUsers []uuid.UUID) error {
+	// Sharing changes are access metadata only and must not bump the config version
+	return r.db.WithContext(ctx).Model(&ReportDefinition{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"visibility":        visibility,
+			"shared_with_users": pq.Array(sharedWithUsers),
+			"updated_at":        time.Now(),
+		}).Error
+}
+
+func (r *repository) FindMissingUsers(ctx context.Context, userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	var found []uuid.UUID
+	if err := r.db.WithContext(ctx).Table("users").
+		Where("id IN ?", userIDs).
+		Pluck("id", &found).Error; err != nil {
+		return nil, err
+	}
+
+	existing := make(map[uuid.UUID]bool, len(found))
+	for _, id := range found {
+		existing[id] = true
+	}
+
+	var missing []uuid.UUID
+	for _, id := range userIDs {
+		if !existing[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+// ListMemberProjectIDs returns the projects userID belongs to, from the collaboration memberships
+func (r *repository) ListMemberProjectIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var projectIDs []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Table("project_members").
+		Where("user_id = ? AND deleted_at IS NULL", userID.String()).
+		Distinct().
+		Pluck("project_id::uuid", &projectIDs).Error; err != nil {
+		return nil, err
+	}
+	return projectIDs, nil
+}
+
+// ========== Report Versions ==========
+
+func (r *repository) CreateReportVersion(ctx context.Context, version *ReportVersion) error {
+	return r.db.WithContext(ctx).Create(version).Error
+}
+
+func (r *repository) GetReportVersion(ctx context.Context, reportID uuid.UUID, version int) (*ReportVersion, error) {
+	var snapshot ReportVersion
+	if err := r.db.WithContext(ctx).
+		First(&snapshot, "report_definition_id = ? AND version = ?", reportID, version).Error; err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+func (r *repository) ListReportVersions(ctx context.Context, reportID uuid.UUID) ([]ReportVersion, error) {
+	var versions []ReportVersion
+	if err := r.db.WithContext(ctx).
+		Where("report_definition_id = ?", reportID).
+		Order("version DESC").
+		Find(&versions).Error; err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
 // ========== Report Schedules ==========
 
 func (r *repository) CreateSchedule(ctx context.Context, schedule *ReportSchedule) error {
@@ -232,6 +457,7 @@ func (r *repository) ListSchedules(ctx context.Context, filter ScheduleFilter) (
 	if filter.Format != "" {
 		query = query.Where("format = ?", filter.Format)
 	}
+	query = scopeToTenant(query, filter.Tenant, reportOrganizationColumn)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -268,9 +494,39 @@ func (r *repository) GetActiveSchedules(ctx context.Context) ([]ReportSchedule,
 }
 
 func (r *repository) GetDueSchedules(ctx context.Context, now time.Time) ([]ReportSchedule, error) {
-	// This is a simplified implementation. In production, you'd use a proper
-	// cron parsing library to determine which schedules are due
-	return r.GetActiveSchedules(ctx)
+	var schedules []ReportSchedule
+
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Where("next_run_at IS NOT NULL AND next_run_at <= ?", now).
+		Where("start_date IS NULL OR start_date <= ?", now).
+		Where("end_date IS NULL OR end_date >= ?", now).
+		Order("next_run_at ASC").
+		Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func (r *repository) UpdateScheduleRunTimes(ctx context.Context, id uuid.UUID, lastRunAt, nextRunAt *time.Time) error {
+	updates := map[string]interface{}{"next_run_at": nextRunAt}
+	if lastRunAt != nil {
+		updates["last_run_at"] = lastRunAt
+	}
+	return r.db.WithContext(ctx).Model(&ReportSchedule{}).
+		Where("id = ?", id).
+		Updates(updates).Error
+}
+
+func (r *repository) UpdateScheduleRecipients(ctx context.Context, id uuid.UUID, emails []string, userIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&ReportSchedule{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"recipient_emails":   pq.Array(emails),
+			"recipient_user_ids": pq.Array(userIDs),
+			"updated_at":         time.Now(),
+		}).Error
 }
 
 // ========== Report Executions ==========
@@ -294,6 +550,28 @@ func (r *repository) UpdateExecution(ctx context.Context, execution *ReportExecu
 	return r.db.WithContext(ctx).Save(execution).Error
 }
 
+// FinishExecution writes the final state of an execution only while it is still pending or
+// processing, so a run that was cancelled meanwhile cannot be marked completed. It reports
+// whether the row was written.
+func (r *repository) FinishExecution(ctx context.Context, execution *ReportExecution) (bool, error) {
+	result := r.db.WithContext(ctx).Model(execution).
+		Where("status IN ?", []ExecutionStatus{StatusPending, StatusProcessing}).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(execution)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) UpdateExecutionProgress(ctx context.Context, id uuid.UUID, rowsProcessed int64, progressPercent int) error {
+	// Only touch the progress columns so a concurrent status change is not overwritten
+	return r.db.WithContext(ctx).Model(&ReportExecution{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"rows_processed":   rowsProcessed,
+			"progress_percent": progressPercent,
+		}).Error
+}
+
 func (r *repository) ListExecutions(ctx context.Context, filter ExecutionFilter) ([]ReportExecution, int64, error) {
 	var executions []ReportExecution
 	var total int64
@@ -320,6 +598,7 @@ func (r *repository) ListExecutions(ctx context.Context, filter ExecutionFilter)
 	if filter.EndDate != nil {
 		query = query.Where("triggered_at <= ?", filter.EndDate)
 	}
+	query = scopeToTenant(query, filter.Tenant, reportOrganizationColumn)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -352,6 +631,50 @@ func (r *repository) GetPendingExecutions(ctx context.Context) ([]ReportExecutio
 	return executions, nil
 }
 
+func (r *repository) ListStuckExecutions(ctx context.Context, startedBefore time.Time) ([]ReportExecution, error) {
+	var executions []ReportExecution
+	if err := r.db.WithContext(ctx).
+		Preload("ReportDefinition").
+		Preload("Schedule").
+		Where("status = ? AND triggered_at < ?", StatusProcessing, startedBefore).
+		Order("triggered_at ASC").
+		Find(&executions).Error; err != nil {
+		return nil, err
+	}
+	return executions, nil
+}
+
+// purgeableExecutions selects finished executions triggered before cutoff that are not on legal hold
+func purgeableExecutions(query *gorm.DB, before time.Time) *gorm.DB {
+	return query.Model(&ReportExecution{}).
+		Where("status IN ? AND triggered_at < ? AND NOT legal_hold", []ExecutionStatus{StatusCompleted, StatusFailed}, before)
+}
+
+func (r *repository) CountPurgeableExecutions(ctx context.Context, before time.Time) (int64, error) {
+	var count int64
+	err := purgeableExecutions(r.db.WithContext(ctx), before).Count(&count).Error
+	return count, err
+}
+
+// PurgeExecutions deletes purgeable executions and returns how many it deleted
+// and the file keys they referenced
+func (r *repository) PurgeExecutions(ctx context.Context, before time.Time) (int64, []string, error) {
+	var deleted []ReportExecution
+	if err := purgeableExecutions(r.db.WithContext(ctx), before).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "file_key"}}}).
+		Delete(&deleted).Error; err != nil {
+		return 0, nil, err
+	}
+
+	fileKeys := make([]string, 0, len(deleted))
+	for _, execution := range deleted {
+		if execution.FileKey != "" {
+			fileKeys = append(fileKeys, execution.FileKey)
+		}
+	}
+	return int64(len(deleted)), fileKeys, nil
+}
+
 // ========== Benchmark Datasets ==========
 
 func (r *repository) CreateBenchmarkDataset(ctx context.Context, dataset *BenchmarkDataset) error {
@@ -448,9 +771,9 @@ func (r *repository) DeleteWidget(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&DashboardWidget{}, "id = ?", id).Error
 }
 
-func (r *repository) ListWidgetsByUser(ctx context.Context, userID uuid.UUID) ([]DashboardWidget, error) {
+func (r *repository) ListWidgetsByUser(ctx context.Context, userID uuid.UUID, tenant Tenant) ([]DashboardWidget, error) {
 	var widgets []DashboardWidget
-	if err := r.db.WithContext(ctx).
+	if err := scopeToTenant(r.db.WithContext(ctx), tenant, "dashboard_widgets.organization_id").
 		Where("user_id = ? OR user_id IS NULL", userID).
 		Order("position ASC").
 		Find(&widgets).Error; err != nil {
@@ -459,9 +782,10 @@ func (r *repository) ListWidgetsByUser(ctx context.Context, userID uuid.UUID) ([
 	return widgets, nil
 }
 
-func (r *repository) ListWidgetsBySection(ctx context.Context, section string) ([]DashboardWidget, error) {
+func (r *repository) ListWidgetsBySection(ctx context.Context, userID uuid.UUID, section string, tenant Tenant) ([]DashboardWidget, error) {
 	var widgets []DashboardWidget
-	if err := r.db.WithContext(ctx).
+	if err := scopeToTenant(r.db.WithContext(ctx), tenant, "dashboard_widgets.organization_id").
+		Where("user_id = ? OR user_id IS NULL", userID).
 		Where("dashboard_section = ?", section).
 		Order("position ASC").
 		Find(&widgets).Error; err != nil {
@@ -483,42 +807,126 @@ func (r *repository) UpdateWidgetPositions(ctx context.Context, userID uuid.UUID
 	})
 }
 
+// ========== Dashboard Views ==========
+
+func (r *repository) CreateDashboardView(ctx context.Context, view *DashboardView) error {
+	return r.db.WithContext(ctx).Create(view).Error
+}
+
+func (r *repository) GetDashboardView(ctx context.Context, id uuid.UUID) (*DashboardView, error) {
+	var view DashboardView
+	if err := r.db.WithContext(ctx).First(&view, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// UpdateDashboardView saves everything but the default flag, which only SetDefaultDashboardView changes
+func (r *repository) UpdateDashboardView(ctx context.Context, view *DashboardView) error {
+	return r.db.WithContext(ctx).Model(view).
+		Updates(map[string]interface{}{
+			"name":       view.Name,
+			"widget_ids": pq.Array(view.WidgetIDs),
+			"filters":    view.Filters,
+			"time_range": view.TimeRange,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *repository) DeleteDashboardView(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&DashboardView{}, "id = ?", id).Error
+}
+
+func (r *repository) ListDashboardViews(ctx context.Context, userID uuid.UUID) ([]DashboardView, error) {
+	var views []DashboardView
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("name ASC").
+		Find(&views).Error; err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+func (r *repository) GetDefaultDashboardView(ctx context.Context, userID uuid.UUID) (*DashboardView, error) {
+	var view DashboardView
+	if err := r.db.WithContext(ctx).First(&view, "user_id = ? AND is_default", userID).Error; err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// SetDefaultDashboardView moves the user's default flag to the given view in one transaction
+func (r *repository) SetDefaultDashboardView(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&DashboardView{}).
+			Where("user_id = ? AND is_default AND id <> ?", userID, id).
+			Update("is_default", false).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&DashboardView{}).
+			Where("id = ? AND user_id = ?", id, userID).
+			Update("is_default", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
 // ========== Dashboard Data ==========
 
-func (r *repository) GetDashboardSummary(ctx context.Context, userID *uuid.UUID) (*DashboardSummary, error) {
+func (r *repository) GetDashboardSummary(ctx context.Context, userID *uuid.UUID, tenant Tenant) (*DashboardSummary, error) {
 	summary := &DashboardSummary{
 		PerformanceMetrics: make(map[string]MetricSummary),
 		TimeSeriesData:     make(map[string][]TimeSeriesPoint),
 	}
 
+	// Every source table carries organization_id so counts never cross tenants
+	scoped := func(table string) *gorm.DB {
+		return scopeToTenant(r.db.WithContext(ctx).Table(table), tenant, table+".organization_id")
+	}
+
 	// Get total projects count
 	var projectCount int64
-	r.db.WithContext(ctx).Table("projects").Count(&projectCount)
+	if err := scoped("projects").Count(&projectCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count projects: %w", err)
+	}
 	summary.TotalProjects = int(projectCount)
 
-	// Get total credits (assuming a carbon_credits table exists)
+	// Get total credits
 	var totalCredits struct {
 		Total float64
 	}
-	r.db.WithContext(ctx).Table("carbon_credits").
+	if err := scoped("carbon_credits").
 		Select("COALESCE(SUM(quantity), 0) as total").
-		Scan(&totalCredits)
+		Scan(&totalCredits).Error; err != nil {
+		return nil, fmt.Errorf("failed to total credits: %w", err)
+	}
 	summary.TotalCredits = totalCredits.Total
 
-	// Get total revenue (assuming a transactions table exists)
+	// Get total revenue
 	var totalRevenue struct {
 		Total float64
 	}
-	r.db.WithContext(ctx).Table("transactions").
+	if err := scoped("transactions").
 		Select("COALESCE(SUM(amount), 0) as total").
-		Scan(&totalRevenue)
+		Scan(&totalRevenue).Error; err != nil {
+		return nil, fmt.Errorf("failed to total revenue: %w", err)
+	}
 	summary.TotalRevenue = totalRevenue.Total
 
 	// Get active monitoring areas
 	var monitoringCount int64
-	r.db.WithContext(ctx).Table("monitoring_areas").
+	if err := scoped("monitoring_areas").
 		Where("is_active = ?", true).
-		Count(&monitoringCount)
+		Count(&monitoringCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count monitoring areas: %w", err)
+	}
 	summary.ActiveMonitoringAreas = int(monitoringCount)
 
 	// Calculate performance metrics
@@ -541,27 +949,15 @@ func (r *repository) GetDashboardSummary(ctx context.Context, userID *uuid.UUID)
 	return summary, nil
 }
 
-func (r *repository) GetTimeSeriesData(ctx context.Context, metric string, startTime, endTime time.Time, interval string) ([]TimeSeriesPoint, error) {
+func (r *repository) GetTimeSeriesData(ctx context.Context, metric string, startTime, endTime time.Time, interval string, tenant Tenant) ([]TimeSeriesPoint, error) {
 	var points []TimeSeriesPoint
 
 	// Determine the table and field based on metric
-	var table, field, timeField string
-	switch metric {
-	case "credits":
-		table = "carbon_credits"
-		field = "quantity"
-		timeField = "created_at"
-	case "revenue":
-		table = "transactions"
-		field = "amount"
-		timeField = "created_at"
-	case "projects":
-		table = "projects"
-		field = "1" // count
-		timeField = "created_at"
-	default:
+	source, ok := dashboardMetrics[metric]
+	if !ok {
 		return nil, fmt.Errorf("unknown metric: %s", metric)
 	}
+	table, field, timeField := source.table, source.field, source.timeField
 
 	// Build interval expression
 	var intervalExpr string
@@ -578,17 +974,12 @@ func (r *repository) GetTimeSeriesData(ctx context.Context, metric string, start
 		intervalExpr = "date_trunc('day', %s)"
 	}
 
-	query := fmt.Sprintf(`
-		SELECT 
-			%s AS time_bucket,
-			COALESCE(SUM(%s), 0) AS value
-		FROM %s
-		WHERE %s BETWEEN ? AND ?
-		GROUP BY time_bucket
-		ORDER BY time_bucket ASC
-	`, fmt.Sprintf(intervalExpr, timeField), field, table, timeField)
-
-	rows, err := r.db.WithContext(ctx).Raw(query, startTime, endTime).Rows()
+	rows, err := scopeToTenant(r.db.WithContext(ctx).Table(table), tenant, table+".organization_id").
+		Select(fmt.Sprintf("%s AS time_bucket, COALESCE(SUM(%s), 0) AS value", fmt.Sprintf(intervalExpr, timeField), field)).
+		Where(fmt.Sprintf("%s BETWEEN ? AND ?", timeField), startTime, endTime).
+		Group("time_bucket").
+		Order("time_bucket ASC").
+		Rows()
 	if err != nil {
 		return nil, err
 	}
@@ -605,15 +996,59 @@ func (r *repository) GetTimeSeriesData(ctx context.Context, metric string, start
 	return points, nil
 }
 
+// metricSource is the column a dashboard metric aggregates
+type metricSource struct {
+	table     string
+	field     string
+	timeField string
+}
+
+// dashboardMetrics are the metrics time series and single-value widgets can show
+var dashboardMetrics = map[string]metricSource{
+	"credits":  {table: "carbon_credits", field: "quantity", timeField: "created_at"},
+	"revenue":  {table: "transactions", field: "amount", timeField: "created_at"},
+	"projects": {table: "projects", field: "1", timeField: "created_at"}, // SUM(1) counts
+}
+
+// AggregateMetric returns one aggregate of a metric over [start, end), or 0 when no rows fall in it
+func (r *repository) AggregateMetric(ctx context.Context, metric string, aggregate AggregateFunction, start, end time.Time, tenant Tenant) (float64, error) {
+	source, ok := dashboardMetrics[metric]
+	if !ok {
+		return 0, fmt.Errorf("unknown metric: %s", metric)
+	}
+	if !valueAggregations[aggregate] {
+		return 0, fmt.Errorf("unknown aggregate: %s", aggregate)
+	}
+
+	var result struct {
+		Value float64
+	}
+	err := scopeToTenant(r.db.WithContext(ctx).Table(source.table), tenant, source.table+".organization_id").
+		Select(fmt.Sprintf("COALESCE(%s(%s), 0) AS value", aggregate, source.field)).
+		Where(fmt.Sprintf("%s >= ? AND %s < ?", source.timeField, source.timeField), start, end).
+		Scan(&result).Error
+	return result.Value, err
+}
+
 // ========== Dynamic Query Execution ==========
 
-func (r *repository) ExecuteDynamicQuery(ctx context.Context, config ReportConfig) ([]map[string]interface{}, int64, error) {
+func (r *repository) ExecuteDynamicQuery(ctx context.Context, config ReportConfig, onProgress QueryProgressFunc) ([]map[string]interface{}, int64, error) {
 	// Build dynamic query based on config
 	query, args, err := buildDynamicQuery(config)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	// Get total count (without pagination) up front so progress has a denominator
+	var total int64
+	countQuery, countArgs, err := buildCountQuery(config)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := r.db.WithContext(ctx).Raw(countQuery, countArgs...).Scan(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count report rows: %w", err)
+	}
+
 	// Execute query
 	rows, err := r.db.WithContext(ctx).Raw(query, args...).Rows()
 	if err != nil {
@@ -647,44 +1082,115 @@ func (r *repository) ExecuteDynamicQuery(ctx context.Context, config ReportConfi
 			row[col] = values[i]
 		}
 		results = append(results, row)
+
+		if onProgress != nil && len(results)%ProgressReportInterval == 0 {
+			onProgress(int64(len(results)), total)
+		}
 	}
 
-	// Get total count (without pagination)
-	var total int64
-	countQuery, countArgs, _ := buildCountQuery(config)
-	r.db.WithContext(ctx).Raw(countQuery, countArgs...).Scan(&total)
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
 
 	return results, total, nil
 }
 
+// ExplainDynamicQuery returns the EXPLAIN output of the query built for config. The
+// report has already run by then, so the plan is estimated rather than analyzed to
+// avoid executing the query a second time.
+func (r *repository) ExplainDynamicQuery(ctx context.Context, config ReportConfig) (string, error) {
+	query, args, err := buildDynamicQuery(config)
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := r.db.WithContext(ctx).Raw("EXPLAIN (FORMAT TEXT) "+query, args...).Rows()
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var lines []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return "", err
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// ProgressReportInterval is how many rows are read between progress callbacks
+const ProgressReportInterval = 500
+
+// QueryProgressFunc receives the number of rows read so far and the expected total
+type QueryProgressFunc func(rowsProcessed, total int64)
+
 // buildDynamicQuery constructs a SQL query from ReportConfig
 func buildDynamicQuery(config ReportConfig) (string, []interface{}, error) {
 	var args []interface{}
 
-	// Build SELECT clause
-	selectFields := make([]string, 0, len(config.Fields))
-	for _, field := range config.Fields {
-		fieldExpr := field.Name
+	// Build SELECT clause. SQL cannot refer to a select alias from the same select list,
+	// so calculations get the expression behind an alias or earlier calculation inlined.
+	selectFields := make([]string, 0, len(config.Fields)+len(config.Calculations))
+	outputs := make(map[string]string)
+	outputArgs := make(map[string][]interface{})
+	for i, field := range config.Fields {
+		fieldExpr := qualify(config, field.Name)
 		if field.Aggregate != "" {
-			fieldExpr = fmt.Sprintf("%s(%s)", field.Aggregate, field.Name)
+			aggregate, err := sqlAggregate(field.Aggregate)
+			if err != nil {
+				return "", nil, fmt.Errorf("fields[%d]: %w", i, err)
+			}
+			fieldExpr = fmt.Sprintf("%s(%s)", aggregate, fieldExpr)
 		}
 		if field.Alias != "" {
+			if !namePattern.MatchString(field.Alias) {
+				return "", nil, fmt.Errorf("fields[%d]: invalid alias %q", i, field.Alias)
+			}
+			outputs[field.Alias] = fieldExpr
 			fieldExpr = fmt.Sprintf("%s AS %s", fieldExpr, field.Alias)
 		}
 		selectFields = append(selectFields, fieldExpr)
 	}
 
 	// Add calculated fields
-	for _, calc := range config.Calculations {
-		selectFields = append(selectFields, fmt.Sprintf("(%s) AS %s", calc.Expression, calc.Name))
+	resolve := func(name string) (string, []interface{}) {
+		if sql, ok := outputs[name]; ok {
+			return sql, outputArgs[name]
+		}
+		return qualify(config, name), nil
+	}
+	for i, calc := range config.Calculations {
+		if !namePattern.MatchString(calc.Name) {
+			return "", nil, fmt.Errorf("calculations[%d]: invalid name %q", i, calc.Name)
+		}
+		node, err := parseExpression(calc.Expression)
+		if err != nil {
+			return "", nil, fmt.Errorf("calculations[%d]: %w", i, err)
+		}
+		sql, calcArgs := compileExpression(node, resolve)
+		selectFields = append(selectFields, fmt.Sprintf("%s AS %s", sql, calc.Name))
+		args = append(args, calcArgs...)
+		outputs[calc.Name], outputArgs[calc.Name] = sql, calcArgs
 	}
 
 	// Build FROM clause
-	fromClause := config.Dataset
+	fromClause, scopeArgs, err := buildFromClause(config)
+	if err != nil {
+		return "", nil, err
+	}
+	args = append(args, scopeArgs...)
 
 	// Build WHERE clause
 	whereConditions := make([]string, 0, len(config.Filters))
 	for _, filter := range config.Filters {
+		filter.Field = qualify(config, filter.Field)
 		condition, filterArgs := buildFilterCondition(filter)
 		whereConditions = append(whereConditions, condition)
 		args = append(args, filterArgs...)
@@ -694,9 +1200,13 @@ func buildDynamicQuery(config ReportConfig) (string, []interface{}, error) {
 	groupByFields := make([]string, 0, len(config.Groupings))
 	for _, group := range config.Groupings {
 		if group.TimeGrain != "" {
-			groupByFields = append(groupByFields, fmt.Sprintf("date_trunc('%s', %s)", group.TimeGrain, group.Field))
+			grain, err := sqlTimeGrain(group.TimeGrain)
+			if err != nil {
+				return "", nil, err
+			}
+			groupByFields = append(groupByFields, fmt.Sprintf("date_trunc('%s', %s)", grain, qualify(config, group.Field)))
 		} else {
-			groupByFields = append(groupByFields, group.Field)
+			groupByFields = append(groupByFields, qualify(config, group.Field))
 		}
 	}
 
@@ -707,7 +1217,11 @@ func buildDynamicQuery(config ReportConfig) (string, []interface{}, error) {
 		if sort.Direction == "desc" {
 			direction = "DESC"
 		}
-		orderByFields = append(orderByFields, fmt.Sprintf("%s %s", sort.Field, direction))
+		field := sort.Field
+		if _, ok := outputs[field]; !ok {
+			field = qualify(config, field)
+		}
+		orderByFields = append(orderByFields, fmt.Sprintf("%s %s", field, direction))
 	}
 
 	// Construct query
@@ -727,21 +1241,92 @@ func buildDynamicQuery(config ReportConfig) (string, []interface{}, error) {
 		query += fmt.Sprintf(" ORDER BY %s", stringJoin(orderByFields, ", "))
 	}
 
-	if config.Limit > 0 {
-		query += fmt.Sprintf(" LIMIT %d", config.Limit)
+	// A row past the hard cap lets the caller tell a truncated result from a full one
+	limit := config.Limit
+	if config.rowLimit > 0 && (limit == 0 || limit > config.rowLimit) {
+		limit = config.rowLimit + 1
+	}
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
 	}
 
 	return query, args, nil
 }
 
+// buildFromClause returns the report's dataset, scoped to the caller's projects, and its joins
+func buildFromClause(config ReportConfig) (string, []interface{}, error) {
+	if !knownDataset(config.Dataset) {
+		return "", nil, fmt.Errorf("unknown dataset %q", config.Dataset)
+	}
+	joins, err := joinClause(config)
+	if err != nil {
+		return "", nil, err
+	}
+	fromClause, args := config.projectScope.source(config.Dataset)
+	return fromClause + joins, args, nil
+}
+
+// sqlTimeGrain returns the date_trunc unit for a time grain. The unit is written into
+// the SQL, so it comes from this list rather than from the config.
+func sqlTimeGrain(grain string) (string, error) {
+	switch grain {
+	case "hour":
+		return "hour", nil
+	case "day":
+		return "day", nil
+	case "week":
+		return "week", nil
+	case "month":
+		return "month", nil
+	case "quarter":
+		return "quarter", nil
+	case "year":
+		return "year", nil
+	}
+	return "", fmt.Errorf("unknown time grain %q", grain)
+}
+
+// sqlAggregate maps an aggregate onto the SQL function. The function name is written
+// into the query, so only the ones listed here are accepted.
+func sqlAggregate(aggregate AggregateFunction) (string, error) {
+	switch strings.ToUpper(string(aggregate)) {
+	case "COUNT":
+		return "COUNT", nil
+	case "SUM":
+		return "SUM", nil
+	case "AVG":
+		return "AVG", nil
+	case "MIN":
+		return "MIN", nil
+	case "MAX":
+		return "MAX", nil
+	}
+	return "", fmt.Errorf("unknown aggregate %q", aggregate)
+}
+
 // buildCountQuery constructs a count query from ReportConfig
 func buildCountQuery(config ReportConfig) (string, []interface{}, error) {
-	var args []interface{}
+	// Grouped and aggregated reports return one row per group, so count the rows of the
+	// report query itself rather than the source rows
+	if aggregatesRows(config) {
+		config.Sorts = nil
+		config.Limit = 0
+		config.rowLimit = 0
+		query, args, err := buildDynamicQuery(config)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("SELECT COUNT(*) FROM (%s) t", query), args, nil
+	}
 
-	fromClause := config.Dataset
+	fromClause, args, err := buildFromClause(config)
+	if err != nil {
+		return "", nil, err
+	}
 
 	whereConditions := make([]string, 0, len(config.Filters))
 	for _, filter := range config.Filters {
+		filter.Field = qualify(config, filter.Field)
 		condition, filterArgs := buildFilterCondition(filter)
 		whereConditions = append(whereConditions, condition)
 		args = append(args, filterArgs...)
@@ -756,6 +1341,19 @@ func buildCountQuery(config ReportConfig) (string, []interface{}, error) {
 	return query, args, nil
 }
 
+// aggregatesRows reports whether the report query groups or aggregates its source rows
+func aggregatesRows(config ReportConfig) bool {
+	if len(config.Groupings) > 0 {
+		return true
+	}
+	for _, field := range config.Fields {
+		if field.Aggregate != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // buildFilterCondition creates a SQL condition from a FilterConfig
 func buildFilterCondition(filter FilterConfig) (string, []interface{}) {
 	var condition string
@@ -805,6 +1403,22 @@ func buildFilterCondition(filter FilterConfig) (string, []interface{}) {
 	return condition, args
 }
 
+// reportOrganizationColumn resolves the owning organization of rows that
+// reference a report definition (schedules and executions)
+const reportOrganizationColumn = "(SELECT organization_id FROM report_definitions WHERE report_definitions.id = report_definition_id)"
+
+// scopeToTenant restricts query to rows owned by the tenant's organization.
+// Tenants without an organization only see unowned rows; cross-org admins see everything.
+func scopeToTenant(query *gorm.DB, tenant Tenant, column string) *gorm.DB {
+	if tenant.CrossOrg {
+		return query
+	}
+	if tenant.OrganizationID == nil {
+		return query.Where(column + " IS NULL")
+	}
+	return query.Where(column+" = ?", *tenant.OrganizationID)
+}
+
 // stringJoin is a helper to join strings
 func stringJoin(elems []string, sep string) string {
 	if len(elems) == 0 {
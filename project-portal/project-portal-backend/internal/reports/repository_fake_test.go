@@ -0,0 +1,779 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeRepository is an in-memory Repository used by the service and handler tests
+type fakeRepository struct {
+	mu sync.Mutex
+
+	reports    map[uuid.UUID]*ReportDefinition
+	schedules  map[uuid.UUID]*ReportSchedule
+	executions map[uuid.UUID]*ReportExecution
+	benchmarks map[uuid.UUID]*BenchmarkDataset
+	widgets    map[uuid.UUID]*DashboardWidget
+	views      map[uuid.UUID]*DashboardView
+	users      map[uuid.UUID]bool
+	versions   map[uuid.UUID][]ReportVersion
+
+	// memberships lists the projects each user belongs to
+	memberships map[uuid.UUID][]uuid.UUID
+
+	// Captured arguments for assertions
+	lastReportFilter    ReportFilter
+	lastScheduleFilter  ScheduleFilter
+	lastExecutionFilter ExecutionFilter
+	lastDashboardTenant *Tenant
+	progressUpdates     []int
+
+	// Dynamic query behaviour
+	queryRows []map[string]interface{}
+	queryErr  error
+	queryFunc func(ctx context.Context, config ReportConfig) ([]map[string]interface{}, int64, error)
+	queryPlan string
+
+	// timeSeries holds the points GetTimeSeriesData returns, by owning organization
+	timeSeries map[uuid.UUID][]TimeSeriesPoint
+
+	// metricFunc answers AggregateMetric
+	metricFunc func(metric string, aggregate AggregateFunction, start, end time.Time) float64
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{
+		reports:    make(map[uuid.UUID]*ReportDefinition),
+		schedules:  make(map[uuid.UUID]*ReportSchedule),
+		executions: make(map[uuid.UUID]*ReportExecution),
+		benchmarks: make(map[uuid.UUID]*BenchmarkDataset),
+		widgets:    make(map[uuid.UUID]*DashboardWidget),
+		views:      make(map[uuid.UUID]*DashboardView),
+		users:      make(map[uuid.UUID]bool),
+		versions:   make(map[uuid.UUID][]ReportVersion),
+
+		memberships: make(map[uuid.UUID][]uuid.UUID),
+	}
+}
+
+var errFakeNotFound = fmt.Errorf("record not found")
+
+// ========== Report Definitions ==========
+
+func (f *fakeRepository) CreateReportDefinition(ctx context.Context, report *ReportDefinition) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *report
+	f.reports[report.ID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) GetReportDefinition(ctx context.Context, id uuid.UUID) (*ReportDefinition, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	report, ok := f.reports[id]
+	if !ok {
+		return nil, errFakeNotFound
+	}
+	copied := *report
+	return &copied, nil
+}
+
+func (f *fakeRepository) UpdateReportDefinition(ctx context.Context, report *ReportDefinition) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	report.Version++
+	copied := *report
+	f.reports[report.ID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) DeleteReportDefinition(ctx context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.reports, id)
+	return nil
+}
+
+func (f *fakeRepository) ListReportDefinitions(ctx context.Context, filter ReportFilter) ([]ReportDefinition, int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lastReportFilter = filter
+
+	var reports []ReportDefinition
+	for _, report := range f.reports {
+		if !filter.Tenant.canSee(report.OrganizationID) {
+			continue
+		}
+		reports = append(reports, *report)
+	}
+	return reports, int64(len(reports)), nil
+}
+
+func (f *fakeRepository) ListTemplates(ctx context.Context) ([]ReportDefinition, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var templates []ReportDefinition
+	for _, report := range f.reports {
+		if report.IsTemplate {
+			templates = append(templates, *report)
+		}
+	}
+	return templates, nil
+}
+
+// Search mirrors the access and matching rules of the SQL search
+func (f *fakeRepository) Search(ctx context.Context, filter SearchFilter) ([]SearchResult, int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	wanted := func(entity SearchEntityType) bool {
+		return len(filter.Types) == 0 || slices.Contains(filter.Types, entity)
+	}
+	matches := func(texts ...string) bool {
+		for _, text := range texts {
+			if strings.Contains(strings.ToLower(text), strings.ToLower(filter.Query)) {
+				return true
+			}
+		}
+		return false
+	}
+	visible := func(report *ReportDefinition) bool {
+		owned := report.CreatedBy != nil && *report.CreatedBy == filter.UserID
+		return (owned || report.Visibility == VisibilityPublic || slices.Contains(report.SharedWithUsers, filter.UserID)) &&
+			filter.Tenant.canSee(report.OrganizationID)
+	}
+
+	var results []SearchResult
+	if wanted(EntityReport) {
+		for _, report := range f.reports {
+			if visible(report) && matches(report.Name, report.Description) {
+				results = append(results, SearchResult{EntityReport, report.ID, report.Name, report.Description, report.UpdatedAt})
+			}
+		}
+	}
+	if wanted(EntitySchedule) {
+		for _, schedule := range f.schedules {
+			report, ok := f.reports[schedule.ReportDefinitionID]
+			if ok && visible(report) && matches(schedule.Name) {
+				results = append(results, SearchResult{EntitySchedule, schedule.ID, schedule.Name, "", schedule.UpdatedAt})
+			}
+		}
+	}
+	if wanted(EntityBenchmark) {
+		for _, benchmark := range f.benchmarks {
+			if (benchmark.IsActive || filter.Tenant.CrossOrg) && matches(benchmark.Name, benchmark.Description) {
+				results = append(results, SearchResult{EntityBenchmark, benchmark.ID, benchmark.Name, benchmark.Description, benchmark.UpdatedAt})
+			}
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if !results[i].UpdatedAt.Equal(results[j].UpdatedAt) {
+			return results[i].UpdatedAt.After(results[j].UpdatedAt)
+		}
+		return results[i].ID.String() < results[j].ID.String()
+	})
+	total := int64(len(results))
+	start := min((filter.Page-1)*filter.PageSize, len(results))
+	end := min(start+filter.PageSize, len(results))
+	return results[start:end], total, nil
+}
+
+func (f *fakeRepository) UpdateReportSharing(ctx context.Context, id uuid.UUID, visibility ReportVisibility, sharedWithUsers []uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	report, ok := f.reports[id]
+	if !ok {
+		return errFakeNotFound
+	}
+	report.Visibility = visibility
+	report.SharedWithUsers = append([]uuid.UUID(nil), sharedWithUsers...)
+	return nil
+}
+
+func (f *fakeRepository) FindMissingUsers(ctx context.Context, userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var missing []uuid.UUID
+	for _, id := range userIDs {
+		if !f.users[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+func (f *fakeRepository) ListMemberProjectIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]uuid.UUID(nil), f.memberships[userID]...), nil
+}
+
+// ========== Report Versions ==========
+
+func (f *fakeRepository) CreateReportVersion(ctx context.Context, version *ReportVersion) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, existing := range f.versions[version.ReportDefinitionID] {
+		if existing.Version == version.Version {
+			return fmt.Errorf("duplicate version %d", version.Version)
+		}
+	}
+	f.versions[version.ReportDefinitionID] = append(f.versions[version.ReportDefinitionID], *version)
+	return nil
+}
+
+func (f *fakeRepository) GetReportVersion(ctx context.Context, reportID uuid.UUID, version int) (*ReportVersion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, snapshot := range f.versions[reportID] {
+		if snapshot.Version == version {
+			copied := snapshot
+			return &copied, nil
+		}
+	}
+	return nil, errFakeNotFound
+}
+
+func (f *fakeRepository) ListReportVersions(ctx context.Context, reportID uuid.UUID) ([]ReportVersion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	versions := append([]ReportVersion(nil), f.versions[reportID]...)
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version > versions[j].Version })
+	return versions, nil
+}
+
+// ========== Report Schedules ==========
+
+func (f *fakeRepository) CreateSchedule(ctx context.Context, schedule *ReportSchedule) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *schedule
+	f.schedules[schedule.ID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) GetSchedule(ctx context.Context, id uuid.UUID) (*ReportSchedule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	schedule, ok := f.schedules[id]
+	if !ok {
+		return nil, errFakeNotFound
+	}
+	copied := *schedule
+	if report, ok := f.reports[schedule.ReportDefinitionID]; ok {
+		reportCopy := *report
+		copied.ReportDefinition = &reportCopy
+	}
+	return &copied, nil
+}
+
+func (f *fakeRepository) UpdateSchedule(ctx context.Context, schedule *ReportSchedule) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *schedule
+	copied.ReportDefinition = nil
+	f.schedules[schedule.ID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.schedules, id)
+	return nil
+}
+
+func (f *fakeRepository) ListSchedules(ctx context.Context, filter ScheduleFilter) ([]ReportSchedule, int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lastScheduleFilter = filter
+
+	var schedules []ReportSchedule
+	for _, schedule := range f.schedules {
+		schedules = append(schedules, *schedule)
+	}
+	return schedules, int64(len(schedules)), nil
+}
+
+func (f *fakeRepository) GetActiveSchedules(ctx context.Context) ([]ReportSchedule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var schedules []ReportSchedule
+	for _, schedule := range f.schedules {
+		if schedule.IsActive {
+			schedules = append(schedules, *schedule)
+		}
+	}
+	return schedules, nil
+}
+
+func (f *fakeRepository) GetDueSchedules(ctx context.Context, now time.Time) ([]ReportSchedule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var schedules []ReportSchedule
+	for _, schedule := range f.schedules {
+		if schedule.IsActive && schedule.NextRunAt != nil && !schedule.NextRunAt.After(now) {
+			schedules = append(schedules, *schedule)
+		}
+	}
+	return schedules, nil
+}
+
+func (f *fakeRepository) UpdateScheduleRunTimes(ctx context.Context, id uuid.UUID, lastRunAt, nextRunAt *time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	schedule, ok := f.schedules[id]
+	if !ok {
+		return errFakeNotFound
+	}
+	if lastRunAt != nil {
+		schedule.LastRunAt = lastRunAt
+	}
+	schedule.NextRunAt = nextRunAt
+	return nil
+}
+
+func (f *fakeRepository) UpdateScheduleRecipients(ctx context.Context, id uuid.UUID, emails []string, userIDs []uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	schedule, ok := f.schedules[id]
+	if !ok {
+		return errFakeNotFound
+	}
+	schedule.RecipientEmails = append([]string(nil), emails...)
+	schedule.RecipientUserIDs = append([]uuid.UUID(nil), userIDs...)
+	return nil
+}
+
+// ========== Report Executions ==========
+
+func (f *fakeRepository) CreateExecution(ctx context.Context, execution *ReportExecution) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *execution
+	f.executions[execution.ID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) GetExecution(ctx context.Context, id uuid.UUID) (*ReportExecution, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	execution, ok := f.executions[id]
+	if !ok {
+		return nil, errFakeNotFound
+	}
+	copied := *execution
+	if execution.ReportDefinitionID != nil {
+		if report, ok := f.reports[*execution.ReportDefinitionID]; ok {
+			reportCopy := *report
+			copied.ReportDefinition = &reportCopy
+		}
+	}
+	return &copied, nil
+}
+
+func (f *fakeRepository) UpdateExecution(ctx context.Context, execution *ReportExecution) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *execution
+	copied.ReportDefinition = nil
+	copied.Schedule = nil
+	f.executions[execution.ID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) FinishExecution(ctx context.Context, execution *ReportExecution) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	current, ok := f.executions[execution.ID]
+	if !ok {
+		return false, nil
+	}
+	if current.Status != StatusPending && current.Status != StatusProcessing {
+		return false, nil
+	}
+	copied := *execution
+	copied.ReportDefinition = nil
+	copied.Schedule = nil
+	f.executions[execution.ID] = &copied
+	return true, nil
+}
+
+func (f *fakeRepository) UpdateExecutionProgress(ctx context.Context, id uuid.UUID, rowsProcessed int64, progressPercent int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	execution, ok := f.executions[id]
+	if !ok {
+		return errFakeNotFound
+	}
+	execution.RowsProcessed = rowsProcessed
+	execution.ProgressPercent = progressPercent
+	f.progressUpdates = append(f.progressUpdates, progressPercent)
+	return nil
+}
+
+func (f *fakeRepository) ListExecutions(ctx context.Context, filter ExecutionFilter) ([]ReportExecution, int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lastExecutionFilter = filter
+
+	var executions []ReportExecution
+	for _, execution := range f.executions {
+		if filter.Status != "" && execution.Status != filter.Status {
+			continue
+		}
+		executions = append(executions, *execution)
+	}
+	return executions, int64(len(executions)), nil
+}
+
+func (f *fakeRepository) executionCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.executions)
+}
+
+func (f *fakeRepository) GetPendingExecutions(ctx context.Context) ([]ReportExecution, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var executions []ReportExecution
+	for _, execution := range f.executions {
+		if execution.Status == StatusPending {
+			executions = append(executions, *execution)
+		}
+	}
+	return executions, nil
+}
+
+func (f *fakeRepository) ListStuckExecutions(ctx context.Context, startedBefore time.Time) ([]ReportExecution, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var executions []ReportExecution
+	for _, execution := range f.executions {
+		if execution.Status == StatusProcessing && execution.TriggeredAt.Before(startedBefore) {
+			executions = append(executions, *execution)
+		}
+	}
+	sort.Slice(executions, func(i, j int) bool { return executions[i].TriggeredAt.Before(executions[j].TriggeredAt) })
+	return executions, nil
+}
+
+func (f *fakeRepository) purgeable(execution *ReportExecution, before time.Time) bool {
+	finished := execution.Status == StatusCompleted || execution.Status == StatusFailed
+	return finished && execution.TriggeredAt.Before(before) && !execution.LegalHold
+}
+
+func (f *fakeRepository) CountPurgeableExecutions(ctx context.Context, before time.Time) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var count int64
+	for _, execution := range f.executions {
+		if f.purgeable(execution, before) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeRepository) PurgeExecutions(ctx context.Context, before time.Time) (int64, []string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var deleted int64
+	var fileKeys []string
+	for id, execution := range f.executions {
+		if !f.purgeable(execution, before) {
+			continue
+		}
+		if execution.FileKey != "" {
+			fileKeys = append(fileKeys, execution.FileKey)
+		}
+		delete(f.executions, id)
+		deleted++
+	}
+	return deleted, fileKeys, nil
+}
+
+// ========== Benchmark Datasets ==========
+
+func (f *fakeRepository) CreateBenchmarkDataset(ctx context.Context, dataset *BenchmarkDataset) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *dataset
+	f.benchmarks[dataset.ID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) GetBenchmarkDataset(ctx context.Context, id uuid.UUID) (*BenchmarkDataset, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	dataset, ok := f.benchmarks[id]
+	if !ok {
+		return nil, errFakeNotFound
+	}
+	copied := *dataset
+	return &copied, nil
+}
+
+func (f *fakeRepository) UpdateBenchmarkDataset(ctx context.Context, dataset *BenchmarkDataset) error {
+	return f.CreateBenchmarkDataset(ctx, dataset)
+}
+
+func (f *fakeRepository) DeleteBenchmarkDataset(ctx context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.benchmarks, id)
+	return nil
+}
+
+func (f *fakeRepository) ListBenchmarkDatasets(ctx context.Context, filter BenchmarkFilter) ([]BenchmarkDataset, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var datasets []BenchmarkDataset
+	for _, dataset := range f.benchmarks {
+		if filter.Category != "" && dataset.Category != filter.Category {
+			continue
+		}
+		datasets = append(datasets, *dataset)
+	}
+	return datasets, nil
+}
+
+func (f *fakeRepository) GetBenchmarkByCategory(ctx context.Context, category, methodology, region string, year int) (*BenchmarkDataset, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, dataset := range f.benchmarks {
+		if dataset.Category != category || !dataset.IsActive {
+			continue
+		}
+		if methodology != "" && dataset.Methodology != methodology {
+			continue
+		}
+		if region != "" && dataset.Region != region {
+			continue
+		}
+		if year > 0 && dataset.Year != year {
+			continue
+		}
+		copied := *dataset
+		return &copied, nil
+	}
+	return nil, errFakeNotFound
+}
+
+// ========== Dashboard Widgets ==========
+
+func (f *fakeRepository) CreateWidget(ctx context.Context, widget *DashboardWidget) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *widget
+	f.widgets[widget.ID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) GetWidget(ctx context.Context, id uuid.UUID) (*DashboardWidget, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	widget, ok := f.widgets[id]
+	if !ok {
+		return nil, errFakeNotFound
+	}
+	copied := *widget
+	return &copied, nil
+}
+
+func (f *fakeRepository) UpdateWidget(ctx context.Context, widget *DashboardWidget) error {
+	return f.CreateWidget(ctx, widget)
+}
+
+func (f *fakeRepository) DeleteWidget(ctx context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.widgets, id)
+	return nil
+}
+
+func (f *fakeRepository) ListWidgetsByUser(ctx context.Context, userID uuid.UUID, tenant Tenant) ([]DashboardWidget, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var widgets []DashboardWidget
+	for _, widget := range f.widgets {
+		if (widget.UserID == nil || *widget.UserID == userID) && tenant.canSee(widget.OrganizationID) {
+			widgets = append(widgets, *widget)
+		}
+	}
+	return widgets, nil
+}
+
+func (f *fakeRepository) ListWidgetsBySection(ctx context.Context, userID uuid.UUID, section string, tenant Tenant) ([]DashboardWidget, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var widgets []DashboardWidget
+	for _, widget := range f.widgets {
+		if widget.DashboardSection == section && (widget.UserID == nil || *widget.UserID == userID) && tenant.canSee(widget.OrganizationID) {
+			widgets = append(widgets, *widget)
+		}
+	}
+	return widgets, nil
+}
+
+func (f *fakeRepository) UpdateWidgetPositions(ctx context.Context, userID uuid.UUID, positions map[uuid.UUID]int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for id, position := range positions {
+		if widget, ok := f.widgets[id]; ok {
+			widget.Position = position
+		}
+	}
+	return nil
+}
+
+// ========== Dashboard Views ==========
+
+func (f *fakeRepository) CreateDashboardView(ctx context.Context, view *DashboardView) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	copied := *view
+	f.views[view.ID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) GetDashboardView(ctx context.Context, id uuid.UUID) (*DashboardView, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	view, ok := f.views[id]
+	if !ok {
+		return nil, errFakeNotFound
+	}
+	copied := *view
+	return &copied, nil
+}
+
+func (f *fakeRepository) UpdateDashboardView(ctx context.Context, view *DashboardView) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, ok := f.views[view.ID]
+	if !ok {
+		return errFakeNotFound
+	}
+	copied := *view
+	copied.IsDefault = stored.IsDefault
+	f.views[view.ID] = &copied
+	return nil
+}
+
+func (f *fakeRepository) DeleteDashboardView(ctx context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.views, id)
+	return nil
+}
+
+func (f *fakeRepository) ListDashboardViews(ctx context.Context, userID uuid.UUID) ([]DashboardView, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var views []DashboardView
+	for _, view := range f.views {
+		if view.UserID == userID {
+			views = append(views, *view)
+		}
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].Name < views[j].Name })
+	return views, nil
+}
+
+func (f *fakeRepository) GetDefaultDashboardView(ctx context.Context, userID uuid.UUID) (*DashboardView, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, view := range f.views {
+		if view.UserID == userID && view.IsDefault {
+			copied := *view
+			return &copied, nil
+		}
+	}
+	return nil, errFakeNotFound
+}
+
+func (f *fakeRepository) SetDefaultDashboardView(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	target, ok := f.views[id]
+	if !ok || target.UserID != userID {
+		return errFakeNotFound
+	}
+	for _, view := range f.views {
+		if view.UserID == userID {
+			view.IsDefault = view.ID == id
+		}
+	}
+	return nil
+}
+
+// ========== Dashboard Data ==========
+
+func (f *fakeRepository) GetDashboardSummary(ctx context.Context, userID *uuid.UUID, tenant Tenant) (*DashboardSummary, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lastDashboardTenant = &tenant
+
+	count := 0
+	for _, report := range f.reports {
+		if tenant.canSee(report.OrganizationID) {
+			count++
+		}
+	}
+	return &DashboardSummary{TotalProjects: count}, nil
+}
+
+func (f *fakeRepository) GetTimeSeriesData(ctx context.Context, metric string, startTime, endTime time.Time, interval string, tenant Tenant) ([]TimeSeriesPoint, error) {
+	var points []TimeSeriesPoint
+	for org, series := range f.timeSeries {
+		if tenant.canSee(&org) {
+			points = append(points, series...)
+		}
+	}
+	return points, nil
+}
+
+func (f *fakeRepository) AggregateMetric(ctx context.Context, metric string, aggregate AggregateFunction, start, end time.Time, tenant Tenant) (float64, error) {
+	if f.metricFunc == nil {
+		return 0, nil
+	}
+	return f.metricFunc(metric, aggregate, start, end), nil
+}
+
+// ========== Dynamic Query Execution ==========
+
+func (f *fakeRepository) ExecuteDynamicQuery(ctx context.Context, config ReportConfig, onProgress QueryProgressFunc) ([]map[string]interface{}, int64, error) {
+	if f.queryFunc != nil {
+		return f.queryFunc(ctx, config)
+	}
+	if f.queryErr != nil {
+		return nil, 0, f.queryErr
+	}
+
+	// Simulate the row-by-row stream of the real repository
+	total := int64(len(f.queryRows))
+	for i := range f.queryRows {
+		if onProgress != nil && (i+1)%ProgressReportInterval == 0 {
+			onProgress(int64(i+1), total)
+		}
+	}
+	return f.queryRows, total, nil
+}
+
+func (f *fakeRepository) ExplainDynamicQuery(ctx context.Context, config ReportConfig) (string, error) {
+	if f.queryPlan == "" {
+		return "", errors.New("explain failed")
+	}
+	return f.queryPlan, nil
+}
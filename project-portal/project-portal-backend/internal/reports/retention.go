@@ -0,0 +1,35 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// PurgeExecutions deletes finished executions triggered before cutoff, except those on
+// legal hold, along with their stored files. A dry run only counts them.
+func (s *service) PurgeExecutions(ctx context.Context, before time.Time, dryRun bool) (int64, error) {
+	if dryRun {
+		count, err := s.repo.CountPurgeableExecutions(ctx, before)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count purgeable executions: %w", err)
+		}
+		return count, nil
+	}
+
+	deleted, fileKeys, err := s.repo.PurgeExecutions(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge executions: %w", err)
+	}
+
+	// Rows are gone either way; a file left behind is only wasted space
+	if s.files != nil {
+		for _, key := range fileKeys {
+			if err := s.files.Delete(ctx, key); err != nil {
+				log.Printf("retention: failed to delete report file %s: %v", key, err)
+			}
+		}
+	}
+	return deleted, nil
+}
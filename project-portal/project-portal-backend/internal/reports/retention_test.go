@@ -0,0 +1,91 @@
+package reports
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPurgeExecutions_RespectsWindowAndLegalHold(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+
+	dir := t.TempDir()
+	files, err := NewDiskStore(dir)
+	if err != nil {
+		t.Fatalf("NewDiskStore failed: %v", err)
+	}
+	svc.ConfigureDownloads(files, NewDownloadSigner("secret", time.Minute))
+
+	expired := seedExecution(repo, StatusCompleted, 100*24*time.Hour)
+	expired.FileKey = expired.ID.String() + ".csv"
+	if err := files.Put(context.Background(), expired.FileKey, []byte("a,b")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	expiredFailed := seedExecution(repo, StatusFailed, 100*24*time.Hour)
+	held := seedExecution(repo, StatusCompleted, 100*24*time.Hour)
+	held.LegalHold = true
+	recent := seedExecution(repo, StatusCompleted, 10*24*time.Hour)
+	running := seedExecution(repo, StatusProcessing, 100*24*time.Hour)
+
+	cutoff := time.Now().Add(-90 * 24 * time.Hour)
+
+	t.Run("dry run deletes nothing", func(t *testing.T) {
+		count, err := svc.PurgeExecutions(context.Background(), cutoff, true)
+		if err != nil {
+			t.Fatalf("PurgeExecutions failed: %v", err)
+		}
+		if count != 2 {
+			t.Errorf("expected 2 executions reported, got %d", count)
+		}
+		if len(repo.executions) != 5 {
+			t.Errorf("dry run must not delete, %d executions left", len(repo.executions))
+		}
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		deleted, err := svc.PurgeExecutions(context.Background(), cutoff, false)
+		if err != nil {
+			t.Fatalf("PurgeExecutions failed: %v", err)
+		}
+		if deleted != 2 {
+			t.Errorf("expected 2 executions deleted, got %d", deleted)
+		}
+		for _, gone := range []*ReportExecution{expired, expiredFailed} {
+			if _, ok := repo.executions[gone.ID]; ok {
+				t.Errorf("execution %s past the window should be purged", gone.ID)
+			}
+		}
+		for name, kept := range map[string]*ReportExecution{"legal hold": held, "recent": recent, "processing": running} {
+			if _, ok := repo.executions[kept.ID]; !ok {
+				t.Errorf("%s execution must be kept", name)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, expired.FileKey)); !os.IsNotExist(err) {
+			t.Errorf("expected the purged execution's file to be removed, got %v", err)
+		}
+	})
+}
+
+func TestPurgeableExecutions_SQL(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("failed to open dry-run db: %v", err)
+	}
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var deleted []ReportExecution
+		return purgeableExecutions(tx, time.Now()).Delete(&deleted)
+	})
+	for _, want := range []string{"DELETE FROM \"report_executions\"", "triggered_at <", "NOT legal_hold", "status IN ('completed','failed')"} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in %q", want, sql)
+		}
+	}
+}
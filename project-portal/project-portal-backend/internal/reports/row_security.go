@@ -0,0 +1,104 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// datasetProjectColumns names, per dataset, the expression giving the project a row
+// belongs to. Datasets listed here are only reported on for the caller's projects.
+var datasetProjectColumns = map[string]string{
+	"projects":        "projects.id",
+	"carbon_credits":  "carbon_credits.project_id",
+	"monitoring_data": "monitoring_data.project_id",
+	"transactions":    "(SELECT carbon_credits.project_id FROM carbon_credits WHERE carbon_credits.id = transactions.credit_id)",
+}
+
+// projectScope restricts a dynamic query to rows of the given projects
+type projectScope struct {
+	column     string
+	projectIDs []uuid.UUID
+}
+
+// source returns the FROM clause for dataset with the scope applied. Scoping the
+// rows before any client-supplied filter runs means no filter can widen them.
+func (p *projectScope) source(dataset string) (string, []interface{}) {
+	if p == nil {
+		return dataset, nil
+	}
+
+	ids := make([]string, len(p.projectIDs))
+	for i, id := range p.projectIDs {
+		ids[i] = id.String()
+	}
+	return fmt.Sprintf("(SELECT * FROM %s WHERE %s = ANY(?::uuid[])) AS %s", dataset, p.column, dataset),
+		[]interface{}{pq.Array(ids)}
+}
+
+// unsafeScopedProblems are the config problems that could reach rows outside a
+// project scope, e.g. a calculation selecting from another table
+var unsafeScopedProblems = map[string]bool{
+	ProblemInvalidIdentifier:    true,
+	ProblemInvalidExpression:    true,
+	ProblemUnknownDataset:       true,
+	ProblemUnknownField:         true,
+	ProblemIllegalJoin:          true,
+	ProblemInvalidTimeGrain:     true,
+	ProblemMissingJoinCondition: true,
+	ProblemUnknownAggregate:     true,
+}
+
+// ownerOf returns the user a report runs as when no one triggered it directly
+func ownerOf(report *ReportDefinition) uuid.UUID {
+	if report.CreatedBy == nil {
+		return uuid.Nil
+	}
+	return *report.CreatedBy
+}
+
+// scopeToMemberProjects limits config to the projects userID is a member of.
+// Cross-org platform admins are left unscoped. It fails closed: a dataset that is
+// not a report dataset, or has no project column to scope by, is refused.
+func (s *service) scopeToMemberProjects(ctx context.Context, config *ReportConfig, userID uuid.UUID) error {
+	if !knownDataset(config.Dataset) {
+		return apperrors.Field("dataset", fmt.Sprintf("unknown dataset %q", config.Dataset))
+	}
+	if TenantFromContext(ctx).CrossOrg {
+		return nil
+	}
+	column, ok := datasetProjectColumns[config.Dataset]
+	if !ok {
+		return apperrors.Validation(fmt.Sprintf("dataset %q cannot be scoped to your projects", config.Dataset))
+	}
+
+	datasets, err := s.GetAvailableDatasets(ctx)
+	if err != nil {
+		return err
+	}
+	var unsafe []string
+	for _, problem := range checkReportConfig(*config, datasets) {
+		if unsafeScopedProblems[problem.Code] {
+			unsafe = append(unsafe, problem.Path+": "+problem.Message)
+		}
+	}
+	if len(unsafe) > 0 {
+		return apperrors.Validation("report config cannot be scoped to your projects: " + strings.Join(unsafe, "; "))
+	}
+
+	var projectIDs []uuid.UUID
+	if userID != uuid.Nil {
+		projectIDs, err = s.repo.ListMemberProjectIDs(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to load project memberships: %w", err)
+		}
+	}
+
+	config.projectScope = &projectScope{column: column, projectIDs: projectIDs}
+	return nil
+}
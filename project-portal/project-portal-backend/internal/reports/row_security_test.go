@@ -0,0 +1,251 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+)
+
+func TestBuildDynamicQuery_ScopesRowsBeforeFilters(t *testing.T) {
+	projectA, projectB := uuid.New(), uuid.New()
+	config := ReportConfig{
+		Dataset: "carbon_credits",
+		Fields:  []FieldConfig{{Name: "status"}},
+		Filters: []FilterConfig{{Field: "status", Operator: "eq", Value: "issued"}},
+		projectScope: &projectScope{
+			column:     datasetProjectColumns["carbon_credits"],
+			projectIDs: []uuid.UUID{projectA, projectB},
+		},
+	}
+
+	wantFrom := "FROM (SELECT * FROM carbon_credits WHERE carbon_credits.project_id = ANY(?::uuid[])) AS carbon_credits WHERE status = ?"
+	wantIDs := pq.StringArray{projectA.String(), projectB.String()}
+
+	query, args, _ := buildDynamicQuery(config)
+	countQuery, countArgs, _ := buildCountQuery(config)
+
+	for name, built := range map[string]struct {
+		query string
+		args  []interface{}
+	}{"select": {query, args}, "count": {countQuery, countArgs}} {
+		if !strings.Contains(built.query, wantFrom) {
+			t.Errorf("%s query not scoped: %s", name, built.query)
+		}
+		if len(built.args) != 2 || built.args[1] != "issued" {
+			t.Fatalf("%s args = %v, want scope then filter value", name, built.args)
+		}
+		if ids, ok := built.args[0].(*pq.StringArray); !ok || !reflect.DeepEqual(*ids, wantIDs) {
+			t.Errorf("%s scope arg = %#v, want %v", name, built.args[0], wantIDs)
+		}
+	}
+
+	// Without a scope the dataset is read directly
+	config.projectScope = nil
+	if query, _, _ := buildDynamicQuery(config); !strings.Contains(query, "FROM carbon_credits WHERE") {
+		t.Errorf("unscoped query changed: %s", query)
+	}
+}
+
+// scopedRows simulates the database applying a project scope to rows tagged with project_id
+func scopedRows(rows []map[string]interface{}, seen *[]*projectScope, mu *sync.Mutex) func(context.Context, ReportConfig) ([]map[string]interface{}, int64, error) {
+	return func(ctx context.Context, config ReportConfig) ([]map[string]interface{}, int64, error) {
+		mu.Lock()
+		*seen = append(*seen, config.projectScope)
+		mu.Unlock()
+
+		if config.projectScope == nil {
+			return rows, int64(len(rows)), nil
+		}
+		var visible []map[string]interface{}
+		for _, row := range rows {
+			for _, id := range config.projectScope.projectIDs {
+				if row["project_id"] == id {
+					visible = append(visible, row)
+				}
+			}
+		}
+		return visible, int64(len(visible)), nil
+	}
+}
+
+func TestExecuteReport_ScopedToMemberProjects(t *testing.T) {
+	repo := newFakeRepository()
+	member, outsider := uuid.New(), uuid.New()
+	projectA, projectB := uuid.New(), uuid.New()
+	repo.memberships[member] = []uuid.UUID{projectA}
+
+	var mu sync.Mutex
+	var scopes []*projectScope
+	repo.queryFunc = scopedRows([]map[string]interface{}{
+		{"project_id": projectA, "quantity": 10},
+		{"project_id": projectA, "quantity": 5},
+		{"project_id": projectB, "quantity": 99},
+	}, &scopes, &mu)
+
+	svc := NewService(repo, nil)
+	ctx := context.Background()
+	report, err := svc.CreateReport(ctx, member, CreateReportRequest{
+		Name:       "Credits",
+		Visibility: VisibilityPublic,
+		Config:     ReportConfig{Dataset: "carbon_credits", Fields: []FieldConfig{{Name: "quantity"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	memberRun, err := svc.ExecuteReport(ctx, member, report.ID, ExecuteReportRequest{Format: FormatJSON})
+	if err != nil {
+		t.Fatalf("ExecuteReport failed: %v", err)
+	}
+	outsiderRun, err := svc.ExecuteReport(ctx, outsider, report.ID, ExecuteReportRequest{Format: FormatJSON})
+	if err != nil {
+		t.Fatalf("ExecuteReport failed: %v", err)
+	}
+	adminRun, err := svc.ExecuteReport(WithTenant(ctx, Tenant{CrossOrg: true}), outsider, report.ID, ExecuteReportRequest{Format: FormatJSON})
+	if err != nil {
+		t.Fatalf("ExecuteReport failed: %v", err)
+	}
+
+	// Shutdown waits for every execution to finish
+	if err := svc.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		id   uuid.UUID
+		want int
+	}{
+		{"member sees own projects", memberRun.ID, 2},
+		{"outsider sees nothing", outsiderRun.ID, 0},
+		{"cross-org admin is unscoped", adminRun.ID, 3},
+	} {
+		got, _ := repo.GetExecution(ctx, tt.id)
+		if got.Status != StatusCompleted || got.RecordCount != tt.want {
+			t.Errorf("%s: got %d rows (%s), want %d", tt.name, got.RecordCount, got.Status, tt.want)
+		}
+	}
+}
+
+func TestExecuteReport_RejectsUnscopableConfig(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+	ctx := context.Background()
+	owner := uuid.New()
+
+	report, err := svc.CreateReport(ctx, owner, CreateReportRequest{
+		Name: "Leaky",
+		Config: ReportConfig{
+			Dataset:      "projects",
+			Fields:       []FieldConfig{{Name: "name"}},
+			Calculations: []CalculationConfig{{Name: "other", Expression: "users.balance * 2"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	_, err = svc.ExecuteReport(ctx, owner, report.ID, ExecuteReportRequest{})
+	if !errors.Is(err, apperrors.ErrValidation) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if len(repo.executions) != 0 {
+		t.Error("no execution should be created for a rejected config")
+	}
+}
+
+// unscopableConfigs each tried to read rows outside the caller's projects
+var unscopableConfigs = map[string]ReportConfig{
+	"schema-qualified dataset": {Dataset: "public.carbon_credits", Fields: []FieldConfig{{Name: "quantity"}}},
+	"unlisted dataset":         {Dataset: "monitoring_areas", Fields: []FieldConfig{{Name: "name"}}},
+	"time grain with SQL": {
+		Dataset:   "carbon_credits",
+		Fields:    []FieldConfig{{Name: "issued_at"}},
+		Groupings: []GroupConfig{{Field: "issued_at", TimeGrain: "day', (SELECT max(id::text) FROM carbon_credits))::text, ('x"}},
+	},
+}
+
+func TestUnscopableConfigs_RefusedOnSaveAndBuild(t *testing.T) {
+	for name, config := range unscopableConfigs {
+		if err := validateReportConfig(config); !errors.Is(err, apperrors.ErrValidation) {
+			t.Errorf("%s: expected save to be refused, got %v", name, err)
+		}
+		if query, _, err := buildDynamicQuery(config); err == nil {
+			t.Errorf("%s: expected the query builder to refuse it, built %s", name, query)
+		}
+	}
+}
+
+func TestExecuteReport_RefusesStoredUnscopableConfig(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+	ctx := context.Background()
+	owner := uuid.New()
+
+	for name, config := range unscopableConfigs {
+		// Stored before save-time validation existed
+		raw, _ := json.Marshal(config)
+		report := &ReportDefinition{ID: uuid.New(), Name: name, Config: datatypes.JSON(raw), CreatedBy: &owner}
+		repo.reports[report.ID] = report
+
+		if _, err := svc.ExecuteReport(ctx, owner, report.ID, ExecuteReportRequest{}); !errors.Is(err, apperrors.ErrValidation) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+	if len(repo.executions) != 0 {
+		t.Error("no execution should be created for a rejected config")
+	}
+}
+
+func TestBuildDynamicQuery_RefusesSQLInAggregateOrAlias(t *testing.T) {
+	for name, field := range map[string]FieldConfig{
+		"aggregate": {Name: "quantity", Aggregate: "(SELECT max(balance) FROM users) + SUM"},
+		"alias":     {Name: "quantity", Alias: "q, (SELECT password_hash FROM users LIMIT 1) AS leak"},
+	} {
+		config := ReportConfig{Dataset: "carbon_credits", Fields: []FieldConfig{field}}
+		if query, _, err := buildDynamicQuery(config); err == nil {
+			t.Errorf("%s: expected the query builder to refuse it, built %s", name, query)
+		}
+		problems := checkReportConfig(config, nil)
+		unsafe := false
+		for _, problem := range problems {
+			unsafe = unsafe || unsafeScopedProblems[problem.Code]
+		}
+		if !unsafe {
+			t.Errorf("%s: expected an unsafe problem for a scoped run, got %v", name, problems)
+		}
+	}
+
+	// Known aggregates are accepted in any case and written out in upper case
+	config := ReportConfig{Dataset: "carbon_credits", Fields: []FieldConfig{{Name: "quantity", Aggregate: "sum", Alias: "total"}}}
+	query, _, err := buildDynamicQuery(config)
+	if err != nil {
+		t.Fatalf("buildDynamicQuery: %v", err)
+	}
+	if !strings.Contains(query, "SELECT SUM(quantity) AS total") {
+		t.Errorf("unexpected select: %s", query)
+	}
+}
+
+func TestDatasetProjectColumns_MatchReportDatasets(t *testing.T) {
+	for _, dataset := range reportDatasets {
+		if _, ok := datasetProjectColumns[dataset.Name]; !ok {
+			t.Errorf("report dataset %q has no project column", dataset.Name)
+		}
+	}
+	for name := range datasetProjectColumns {
+		if !knownDataset(name) {
+			t.Errorf("project column registered for %q, which is not a report dataset", name)
+		}
+	}
+}
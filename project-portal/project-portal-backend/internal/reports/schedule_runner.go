@@ -0,0 +1,187 @@
+package reports
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/requestid"
+
+	"github.com/google/uuid"
+)
+
+// DefaultSchedulerInterval is how often the scheduler looks for due schedules
+const DefaultSchedulerInterval = time.Minute
+
+// Scheduler periodically runs report schedules whose next run time has passed
+type Scheduler struct {
+	repo     Repository
+	service  Service
+	interval time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	inFlight map[uuid.UUID]bool
+	runs     sync.WaitGroup
+	stop     context.CancelFunc
+	stopped  chan struct{}
+}
+
+// NewScheduler creates a scheduler that checks for due schedules every interval
+func NewScheduler(repo Repository, service Service, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = DefaultSchedulerInterval
+	}
+	return &Scheduler{
+		repo:     repo,
+		service:  service,
+		interval: interval,
+		now:      time.Now,
+		inFlight: make(map[uuid.UUID]bool),
+	}
+}
+
+// Start backfills missing next run times and begins ticking in the background
+func (s *Scheduler) Start(ctx context.Context) error {
+	if err := s.backfillNextRuns(ctx); err != nil {
+		return err
+	}
+
+	loopCtx, cancel := context.WithCancel(ctx)
+	s.stop = cancel
+	s.stopped = make(chan struct{})
+
+	go func() {
+		defer close(s.stopped)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-loopCtx.Done():
+				return
+			case <-ticker.C:
+				s.tick(loopCtx)
+			}
+		}
+	}()
+
+	log.Printf("Report scheduler started (interval %s)", s.interval)
+	return nil
+}
+
+// Stop stops ticking and waits for in-flight runs to finish until ctx is done
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.stop == nil {
+		return nil
+	}
+	s.stop()
+	<-s.stopped
+
+	finished := make(chan struct{})
+	go func() {
+		s.runs.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		log.Println("Report scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// tick dispatches every schedule that is due at the current time
+func (s *Scheduler) tick(ctx context.Context) {
+	now := s.now()
+
+	schedules, err := s.repo.GetDueSchedules(ctx, now)
+	if err != nil {
+		log.Printf("Failed to load due schedules: %v", err)
+		return
+	}
+
+	for i := range schedules {
+		schedule := schedules[i]
+
+		nextRun, err := nextScheduleRun(schedule.CronExpression, schedule.Timezone, now)
+		if err != nil {
+			log.Printf("Skipping schedule %s: %v", schedule.ID, err)
+			continue
+		}
+
+		if !s.claim(schedule.ID) {
+			// Previous run is still going; skip this occurrence but keep the schedule moving
+			log.Printf("Schedule %s is still running, skipping run due at %s", schedule.ID, schedule.NextRunAt)
+			if err := s.repo.UpdateScheduleRunTimes(ctx, schedule.ID, nil, &nextRun); err != nil {
+				log.Printf("Failed to advance schedule %s: %v", schedule.ID, err)
+			}
+			continue
+		}
+
+		runAt := now
+		if err := s.repo.UpdateScheduleRunTimes(ctx, schedule.ID, &runAt, &nextRun); err != nil {
+			log.Printf("Failed to update schedule %s run times: %v", schedule.ID, err)
+			s.release(schedule.ID)
+			continue
+		}
+
+		s.runs.Add(1)
+		go func() {
+			defer s.runs.Done()
+			defer s.release(schedule.ID)
+
+			// Runs outlive the tick loop so Stop can let them finish cleanly; each gets
+			// its own request ID so the execution's log lines can be correlated
+			runCtx := requestid.WithID(context.Background(), requestid.New())
+			execution, err := s.service.RunSchedule(runCtx, &schedule)
+			if err != nil {
+				log.Printf("Failed to run schedule %s: %v", schedule.ID, err)
+				return
+			}
+			log.Printf("Schedule %s produced execution %s (%s)", schedule.ID, execution.ID, execution.Status)
+		}()
+	}
+}
+
+// backfillNextRuns sets NextRunAt on active schedules created before it was tracked
+func (s *Scheduler) backfillNextRuns(ctx context.Context) error {
+	schedules, err := s.repo.GetActiveSchedules(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	for _, schedule := range schedules {
+		if schedule.NextRunAt != nil {
+			continue
+		}
+		nextRun, err := nextScheduleRun(schedule.CronExpression, schedule.Timezone, now)
+		if err != nil {
+			log.Printf("Skipping schedule %s: %v", schedule.ID, err)
+			continue
+		}
+		if err := s.repo.UpdateScheduleRunTimes(ctx, schedule.ID, nil, &nextRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) claim(scheduleID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inFlight[scheduleID] {
+		return false
+	}
+	s.inFlight[scheduleID] = true
+	return true
+}
+
+func (s *Scheduler) release(scheduleID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inFlight, scheduleID)
+}
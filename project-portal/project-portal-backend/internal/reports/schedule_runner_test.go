@@ -0,0 +1,168 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeClock is a manually advanced clock for driving the scheduler
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newSchedulerFixture(t *testing.T, clock *fakeClock) (*fakeRepository, *Scheduler, *ReportSchedule) {
+	t.Helper()
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+
+	config, _ := json.Marshal(ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}})
+	report := &ReportDefinition{ID: uuid.New(), Name: "Hourly", Config: config}
+	repo.CreateReportDefinition(context.Background(), report)
+
+	nextRun, err := nextScheduleRun("0 * * * *", "UTC", clock.Now())
+	if err != nil {
+		t.Fatalf("nextScheduleRun failed: %v", err)
+	}
+	schedule := &ReportSchedule{
+		ID:                 uuid.New(),
+		ReportDefinitionID: report.ID,
+		Name:               "Hourly",
+		CronExpression:     "0 * * * *",
+		Timezone:           "UTC",
+		IsActive:           true,
+		Format:             FormatJSON,
+		NextRunAt:          &nextRun,
+	}
+	repo.CreateSchedule(context.Background(), schedule)
+
+	scheduler := NewScheduler(repo, svc, time.Hour)
+	scheduler.now = clock.Now
+	return repo, scheduler, schedule
+}
+
+func TestScheduler_RunsDueSchedulesAndAdvances(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)}
+	repo, scheduler, schedule := newSchedulerFixture(t, clock)
+	ctx := context.Background()
+
+	scheduler.tick(ctx)
+	scheduler.runs.Wait()
+	if n := repo.executionCount(); n != 0 {
+		t.Fatalf("expected no executions before the schedule is due, got %d", n)
+	}
+
+	clock.Advance(30 * time.Minute)
+	scheduler.tick(ctx)
+	scheduler.runs.Wait()
+
+	if n := repo.executionCount(); n != 1 {
+		t.Fatalf("expected 1 execution at 11:00, got %d", n)
+	}
+	for _, execution := range repo.executions {
+		if execution.ScheduleID == nil || *execution.ScheduleID != schedule.ID {
+			t.Errorf("expected execution to be linked to schedule %s", schedule.ID)
+		}
+		if execution.Status != StatusCompleted {
+			t.Errorf("expected completed execution, got %s", execution.Status)
+		}
+	}
+
+	updated, _ := repo.GetSchedule(ctx, schedule.ID)
+	if updated.LastRunAt == nil || !updated.LastRunAt.Equal(clock.Now()) {
+		t.Errorf("expected LastRunAt %s, got %v", clock.Now(), updated.LastRunAt)
+	}
+	wantNext := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if updated.NextRunAt == nil || !updated.NextRunAt.Equal(wantNext) {
+		t.Errorf("expected NextRunAt %s, got %v", wantNext, updated.NextRunAt)
+	}
+
+	// Ticking again within the same hour must not re-run the schedule
+	clock.Advance(10 * time.Minute)
+	scheduler.tick(ctx)
+	scheduler.runs.Wait()
+	if n := repo.executionCount(); n != 1 {
+		t.Errorf("expected still 1 execution, got %d", n)
+	}
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC)}
+	repo, scheduler, schedule := newSchedulerFixture(t, clock)
+	ctx := context.Background()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	repo.queryFunc = func(ctx context.Context, config ReportConfig) ([]map[string]interface{}, int64, error) {
+		started <- struct{}{}
+		<-release
+		return nil, 0, nil
+	}
+
+	// Make the schedule due now
+	due := clock.Now()
+	repo.UpdateScheduleRunTimes(ctx, schedule.ID, nil, &due)
+
+	scheduler.tick(ctx)
+	<-started
+
+	clock.Advance(time.Hour)
+	scheduler.tick(ctx)
+
+	if n := repo.executionCount(); n != 1 {
+		t.Errorf("expected the overlapping run to be skipped, got %d executions", n)
+	}
+	updated, _ := repo.GetSchedule(ctx, schedule.ID)
+	wantNext := time.Date(2026, 3, 1, 13, 0, 0, 0, time.UTC)
+	if updated.NextRunAt == nil || !updated.NextRunAt.Equal(wantNext) {
+		t.Errorf("expected skipped run to advance NextRunAt to %s, got %v", wantNext, updated.NextRunAt)
+	}
+
+	close(release)
+	scheduler.runs.Wait()
+
+	// Once the previous run finishes, the next due occurrence runs normally
+	clock.Advance(time.Hour)
+	scheduler.tick(ctx)
+	scheduler.runs.Wait()
+	if n := repo.executionCount(); n != 2 {
+		t.Errorf("expected 2 executions after the previous run finished, got %d", n)
+	}
+}
+
+func TestScheduler_StartStop(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)}
+	repo, scheduler, schedule := newSchedulerFixture(t, clock)
+
+	// Schedules without a next run time are backfilled on start
+	repo.schedules[schedule.ID].NextRunAt = nil
+
+	if err := scheduler.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := scheduler.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	updated, _ := repo.GetSchedule(context.Background(), schedule.ID)
+	if updated.NextRunAt == nil {
+		t.Error("expected Start to backfill NextRunAt")
+	}
+}
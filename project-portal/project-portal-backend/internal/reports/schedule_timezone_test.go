@@ -0,0 +1,109 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNextScheduleRun_Timezones(t *testing.T) {
+	tests := []struct {
+		name      string
+		timezone  string
+		from      time.Time
+		wantUTC   time.Time
+		wantLocal string
+	}{
+		{
+			name:      "new york before DST",
+			timezone:  "America/New_York",
+			from:      time.Date(2026, 3, 7, 15, 0, 0, 0, time.UTC),
+			wantUTC:   time.Date(2026, 3, 8, 13, 0, 0, 0, time.UTC),
+			wantLocal: "Sun, 08 Mar 2026 09:00 EDT",
+		},
+		{
+			name:      "new york standard time",
+			timezone:  "America/New_York",
+			from:      time.Date(2026, 1, 10, 15, 0, 0, 0, time.UTC),
+			wantUTC:   time.Date(2026, 1, 11, 14, 0, 0, 0, time.UTC),
+			wantLocal: "Sun, 11 Jan 2026 09:00 EST",
+		},
+		{
+			name:      "tokyo",
+			timezone:  "Asia/Tokyo",
+			from:      time.Date(2026, 3, 7, 15, 0, 0, 0, time.UTC),
+			wantUTC:   time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+			wantLocal: "Sun, 08 Mar 2026 09:00 JST",
+		},
+		{
+			name:      "utc",
+			timezone:  "UTC",
+			from:      time.Date(2026, 3, 7, 15, 0, 0, 0, time.UTC),
+			wantUTC:   time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC),
+			wantLocal: "Sun, 08 Mar 2026 09:00 UTC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := &ReportSchedule{CronExpression: "0 9 * * *", Timezone: tt.timezone, IsActive: true}
+			describeNextRun(schedule, tt.from)
+
+			if schedule.NextRunAt == nil || !schedule.NextRunAt.Equal(tt.wantUTC) {
+				t.Errorf("expected next run %s, got %v", tt.wantUTC, schedule.NextRunAt)
+			}
+			if schedule.NextRunAt != nil && schedule.NextRunAt.Location() != time.UTC {
+				t.Errorf("expected NextRunAt in UTC, got %s", schedule.NextRunAt.Location())
+			}
+			if schedule.NextRunLocal != tt.wantLocal {
+				t.Errorf("expected local %q, got %q", tt.wantLocal, schedule.NextRunLocal)
+			}
+		})
+	}
+}
+
+func TestCreateSchedule_RejectsInvalidTimezone(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+
+	config, _ := json.Marshal(ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}})
+	owner := uuid.New()
+	report := &ReportDefinition{ID: uuid.New(), Name: "Daily", Config: config, CreatedBy: &owner}
+	repo.CreateReportDefinition(context.Background(), report)
+
+	req := CreateScheduleRequest{
+		ReportDefinitionID: report.ID,
+		Name:               "Daily",
+		CronExpression:     "0 9 * * *",
+		Timezone:           "Mars/Olympus_Mons",
+		Format:             FormatCSV,
+		DeliveryMethod:     DeliveryEmail,
+		DeliveryConfig:     map[string]any{},
+	}
+	if _, err := svc.CreateSchedule(context.Background(), owner, req); err == nil || !strings.Contains(err.Error(), "invalid timezone") {
+		t.Fatalf("expected invalid timezone error, got %v", err)
+	}
+
+	req.Timezone = "Europe/Berlin"
+	schedule, err := svc.CreateSchedule(context.Background(), owner, req)
+	if err != nil {
+		t.Fatalf("CreateSchedule failed: %v", err)
+	}
+
+	fetched, err := svc.GetSchedule(context.Background(), owner, schedule.ID)
+	if err != nil {
+		t.Fatalf("GetSchedule failed: %v", err)
+	}
+	if fetched.NextRunLocal == "" || !strings.Contains(fetched.NextRunLocal, "09:00 CE") {
+		t.Errorf("expected next run at 09:00 Berlin time, got %q", fetched.NextRunLocal)
+	}
+
+	req.Timezone = "Not/AZone"
+	if _, err := svc.UpdateSchedule(context.Background(), owner, schedule.ID, req); err == nil || !strings.Contains(err.Error(), "invalid timezone") {
+		t.Errorf("expected invalid timezone error on update, got %v", err)
+	}
+}
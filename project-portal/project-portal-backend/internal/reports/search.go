@@ -0,0 +1,60 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
+	"github.com/google/uuid"
+)
+
+// MaxSearchPageSize caps how many results one search page returns
+const MaxSearchPageSize = 100
+
+var searchEntityTypes = map[SearchEntityType]bool{
+	EntityReport:    true,
+	EntitySchedule:  true,
+	EntityBenchmark: true,
+}
+
+// Search finds reports, schedules and benchmarks the caller can see whose name or
+// description contains the query
+func (s *service) Search(ctx context.Context, userID uuid.UUID, filter SearchFilter) (*SearchResponse, error) {
+	for _, entity := range filter.Types {
+		if !searchEntityTypes[entity] {
+			return nil, apperrors.Field("type", fmt.Sprintf("unknown entity type %q; use report, schedule or benchmark", entity))
+		}
+	}
+	if len(filter.Query) > 200 {
+		return nil, apperrors.Field("q", "must be at most 200 characters")
+	}
+
+	filter.UserID = userID
+	filter.Tenant = TenantFromContext(ctx)
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = 20
+	}
+	if filter.PageSize > MaxSearchPageSize {
+		filter.PageSize = MaxSearchPageSize
+	}
+
+	results, total, err := s.repo.Search(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	if results == nil {
+		results = []SearchResult{}
+	}
+
+	return &SearchResponse{
+		Results:    results,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+	}, nil
+}
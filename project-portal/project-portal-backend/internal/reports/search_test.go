@@ -0,0 +1,186 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// seedSearchFixtures creates a private report with a schedule for owner, plus an
+// active and an inactive benchmark, all mentioning "carbon"
+func seedSearchFixtures(t *testing.T, repo *fakeRepository, svc Service, owner uuid.UUID) (*ReportDefinition, uuid.UUID, uuid.UUID) {
+	t.Helper()
+
+	report, err := svc.CreateReport(context.Background(), owner, CreateReportRequest{
+		Name:        "Quarterly summary",
+		Description: "Carbon credits issued per project",
+		Config:      ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	now := time.Now()
+	repo.reports[report.ID].UpdatedAt = now
+	scheduleID := uuid.New()
+	repo.schedules[scheduleID] = &ReportSchedule{ID: scheduleID, ReportDefinitionID: report.ID, Name: "Weekly carbon digest", UpdatedAt: now.Add(-time.Hour)}
+
+	benchmarkID := uuid.New()
+	repo.benchmarks[benchmarkID] = &BenchmarkDataset{ID: benchmarkID, Name: "Carbon forestry 2025", IsActive: true, UpdatedAt: now.Add(-2 * time.Hour)}
+	inactiveID := uuid.New()
+	repo.benchmarks[inactiveID] = &BenchmarkDataset{ID: inactiveID, Name: "Carbon forestry 2019", IsActive: false, UpdatedAt: now.Add(-3 * time.Hour)}
+
+	return report, scheduleID, benchmarkID
+}
+
+func decodeSearch(t *testing.T, body []byte) SearchResponse {
+	t.Helper()
+	var response SearchResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatalf("invalid search response: %v", err)
+	}
+	return response
+}
+
+func TestSearch_ReturnsTaggedResultsAcrossEntities(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+	owner := uuid.New()
+	report, scheduleID, benchmarkID := seedSearchFixtures(t, repo, svc, owner)
+	router := newSharingTestRouter(repo)
+
+	w := doAs(router, owner, http.MethodGet, "/api/v1/reports/search?q=CARBON", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	response := decodeSearch(t, w.Body.Bytes())
+	if response.Total != 3 {
+		t.Fatalf("expected report, schedule and active benchmark, got %+v", response.Results)
+	}
+	want := []struct {
+		entity SearchEntityType
+		id     uuid.UUID
+	}{{EntityReport, report.ID}, {EntitySchedule, scheduleID}, {EntityBenchmark, benchmarkID}}
+	for i, expected := range want {
+		got := response.Results[i]
+		if got.EntityType != expected.entity || got.ID != expected.id {
+			t.Errorf("result %d: expected %s %s, got %s %s", i, expected.entity, expected.id, got.EntityType, got.ID)
+		}
+	}
+
+	t.Run("type filter", func(t *testing.T) {
+		w := doAs(router, owner, http.MethodGet, "/api/v1/reports/search?q=carbon&type=schedule,benchmark", nil)
+		response := decodeSearch(t, w.Body.Bytes())
+		if response.Total != 2 {
+			t.Fatalf("expected 2 results, got %+v", response.Results)
+		}
+		for _, result := range response.Results {
+			if result.EntityType == EntityReport {
+				t.Errorf("reports must be filtered out, got %+v", result)
+			}
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		w := doAs(router, owner, http.MethodGet, "/api/v1/reports/search?q=carbon&page=2&page_size=2", nil)
+		response := decodeSearch(t, w.Body.Bytes())
+		if response.Total != 3 || response.TotalPages != 2 || len(response.Results) != 1 {
+			t.Fatalf("expected the last of 3 results on page 2, got %+v", response)
+		}
+		if response.Results[0].ID != benchmarkID {
+			t.Errorf("expected the oldest result on the last page, got %+v", response.Results[0])
+		}
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		w := doAs(router, owner, http.MethodGet, "/api/v1/reports/search?q=carbon&type=invoice", nil)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+		}
+	})
+}
+
+func TestSearch_FiltersByAccess(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+	owner, other := uuid.New(), uuid.New()
+	report, scheduleID, benchmarkID := seedSearchFixtures(t, repo, svc, owner)
+	repo.users[other] = true
+	router := newSharingTestRouter(repo)
+
+	w := doAs(router, other, http.MethodGet, "/api/v1/reports/search?q=carbon", nil)
+	response := decodeSearch(t, w.Body.Bytes())
+	if response.Total != 1 || response.Results[0].ID != benchmarkID {
+		t.Fatalf("expected only the active benchmark for another user, got %+v", response.Results)
+	}
+
+	if _, err := svc.ShareReport(context.Background(), owner, report.ID, []uuid.UUID{other}); err != nil {
+		t.Fatalf("ShareReport failed: %v", err)
+	}
+	w = doAs(router, other, http.MethodGet, "/api/v1/reports/search?q=carbon", nil)
+	response = decodeSearch(t, w.Body.Bytes())
+	ids := map[uuid.UUID]bool{}
+	for _, result := range response.Results {
+		ids[result.ID] = true
+	}
+	if !ids[report.ID] || !ids[scheduleID] {
+		t.Errorf("expected the shared report and its schedule, got %+v", response.Results)
+	}
+
+	// A report of another organization stays hidden even when public
+	orgB := uuid.New()
+	ctxB := WithTenant(context.Background(), Tenant{OrganizationID: &orgB})
+	if _, err := svc.CreateReport(ctxB, uuid.New(), CreateReportRequest{
+		Name:       "Org B carbon ledger",
+		Config:     ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+		Visibility: VisibilityPublic,
+	}); err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+	results, err := svc.Search(context.Background(), other, SearchFilter{Query: "ledger"})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if results.Total != 0 {
+		t.Errorf("expected another organization's report to be hidden, got %+v", results.Results)
+	}
+}
+
+func TestSearchQuery_SQL(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("failed to open dry-run db: %v", err)
+	}
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var results []SearchResult
+		return searchQuery(tx, SearchFilter{Query: "carbon", UserID: uuid.New()}).Scan(&results)
+	})
+	if got := strings.Count(sql, "UNION ALL"); got != 2 {
+		t.Errorf("expected three branches, got %d unions in %q", got, sql)
+	}
+	if got := strings.Count(sql, "report_definitions.created_by ="); got != 2 {
+		t.Errorf("expected reports and schedules limited to visible reports, got %q", sql)
+	}
+	if got := strings.Count(sql, "report_definitions.organization_id IS NULL"); got != 2 {
+		t.Errorf("expected reports and schedules scoped to the tenant, got %q", sql)
+	}
+	if !strings.Contains(sql, "benchmark_datasets.is_active = true") {
+		t.Errorf("expected inactive benchmarks hidden, got %q", sql)
+	}
+
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var results []SearchResult
+		return searchQuery(tx, SearchFilter{Types: []SearchEntityType{EntityBenchmark}}).Scan(&results)
+	})
+	if strings.Contains(sql, "UNION ALL") || strings.Contains(sql, "report_definitions") {
+		t.Errorf("expected only the benchmark branch, got %q", sql)
+	}
+}
@@ -3,10 +3,19 @@ package reports
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+	"carbon-scribe/project-portal/project-portal-backend/pkg/requestid"
+
 	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
 	"gorm.io/datatypes"
 )
 
@@ -20,23 +29,45 @@ type Service interface {
 	ListReports(ctx context.Context, userID uuid.UUID, filter ReportFilter) (*ListReportsResponse, error)
 	GetTemplates(ctx context.Context) ([]ReportDefinition, error)
 	CloneReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, name string) (*ReportDefinition, error)
+	ShareReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, error)
+	UnshareReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, error)
+	SetReportVisibility(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, visibility ReportVisibility) (*ReportDefinition, error)
+	ListReportVersions(ctx context.Context, userID uuid.UUID, reportID uuid.UUID) ([]ReportVersion, error)
+	DiffReportVersions(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, fromVersion, toVersion int) (*ReportVersionDiffResponse, error)
+	RevertReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, version int) (*ReportDefinition, error)
+	Search(ctx context.Context, userID uuid.UUID, filter SearchFilter) (*SearchResponse, error)
 
 	// Report Execution
 	ExecuteReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, req ExecuteReportRequest) (*ReportExecution, error)
-	GetExecution(ctx context.Context, executionID uuid.UUID) (*ReportExecution, error)
+	GetExecution(ctx context.Context, userID uuid.UUID, executionID uuid.UUID) (*ReportExecution, error)
 	ListExecutions(ctx context.Context, filter ExecutionFilter) (*ListExecutionsResponse, error)
-	CancelExecution(ctx context.Context, executionID uuid.UUID) error
+	CancelExecution(ctx context.Context, userID uuid.UUID, executionID uuid.UUID) error
+	ListStuckExecutions(ctx context.Context, olderThan time.Duration) ([]ReportExecution, error)
+	RecoverExecution(ctx context.Context, executionID uuid.UUID, action RecoveryAction) (*ReportExecution, error)
+	SweepStuckExecutions(ctx context.Context, olderThan time.Duration) (int, error)
+	ConfigureQueryPlans(enabled bool)
+	GetExecutionPlan(ctx context.Context, executionID uuid.UUID) (*ExecutionPlanResponse, error)
+
+	// Report Files
+	ConfigureDownloads(files FileStore, signer *DownloadSigner)
+	SignDownload(execution *ReportExecution) (url.Values, time.Time, bool)
+	OpenDownload(ctx context.Context, executionID uuid.UUID, expires int64, signature string) (*ReportFile, error)
+	PurgeExecutions(ctx context.Context, before time.Time, dryRun bool) (int64, error)
 
 	// Scheduled Reports
 	CreateSchedule(ctx context.Context, userID uuid.UUID, req CreateScheduleRequest) (*ReportSchedule, error)
-	GetSchedule(ctx context.Context, scheduleID uuid.UUID) (*ReportSchedule, error)
-	UpdateSchedule(ctx context.Context, scheduleID uuid.UUID, req CreateScheduleRequest) (*ReportSchedule, error)
-	DeleteSchedule(ctx context.Context, scheduleID uuid.UUID) error
+	GetSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID) (*ReportSchedule, error)
+	UpdateSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID, req CreateScheduleRequest) (*ReportSchedule, error)
+	DeleteSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID) error
 	ListSchedules(ctx context.Context, filter ScheduleFilter) ([]ReportSchedule, int64, error)
-	ToggleSchedule(ctx context.Context, scheduleID uuid.UUID, active bool) error
+	ToggleSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID, active bool) error
+	RunSchedule(ctx context.Context, schedule *ReportSchedule) (*ReportExecution, error)
+	SubscribeToSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID, email string) (*ReportSchedule, error)
+	UnsubscribeFromSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID, email string) (*ReportSchedule, error)
 
 	// Benchmarks
 	CompareBenchmark(ctx context.Context, req BenchmarkComparisonRequest) (*BenchmarkComparisonResponse, error)
+	CompareCohorts(ctx context.Context, req CohortComparisonRequest) (*CohortComparisonResponse, error)
 	ListBenchmarks(ctx context.Context, filter BenchmarkFilter) ([]BenchmarkDataset, error)
 	CreateBenchmark(ctx context.Context, dataset *BenchmarkDataset) (*BenchmarkDataset, error)
 	UpdateBenchmark(ctx context.Context, datasetID uuid.UUID, dataset *BenchmarkDataset) (*BenchmarkDataset, error)
@@ -45,19 +76,64 @@ type Service interface {
 	GetDashboardSummary(ctx context.Context, userID *uuid.UUID) (*DashboardSummary, error)
 	GetTimeSeriesData(ctx context.Context, metric string, startTime, endTime time.Time, interval string) ([]TimeSeriesPoint, error)
 	GetWidgets(ctx context.Context, userID uuid.UUID, section string) ([]DashboardWidget, error)
-	SaveWidget(ctx context.Context, widget *DashboardWidget) (*DashboardWidget, error)
-	DeleteWidget(ctx context.Context, widgetID uuid.UUID) error
+	SaveWidget(ctx context.Context, userID uuid.UUID, widget *DashboardWidget) (*DashboardWidget, error)
+	DeleteWidget(ctx context.Context, userID uuid.UUID, widgetID uuid.UUID) error
+	GetWidgetValue(ctx context.Context, userID uuid.UUID, widgetID uuid.UUID) (*WidgetValueResponse, error)
+	ListDashboardViews(ctx context.Context, userID uuid.UUID) ([]DashboardView, error)
+	GetDashboardView(ctx context.Context, userID uuid.UUID, viewID uuid.UUID) (*DashboardView, error)
+	GetDefaultDashboardView(ctx context.Context, userID uuid.UUID) (*DashboardView, error)
+	CreateDashboardView(ctx context.Context, userID uuid.UUID, req SaveDashboardViewRequest) (*DashboardView, error)
+	UpdateDashboardView(ctx context.Context, userID uuid.UUID, viewID uuid.UUID, req SaveDashboardViewRequest) (*DashboardView, error)
+	DeleteDashboardView(ctx context.Context, userID uuid.UUID, viewID uuid.UUID) error
+	SetDefaultDashboardView(ctx context.Context, userID uuid.UUID, viewID uuid.UUID) (*DashboardView, error)
 
 	// Datasets
 	GetAvailableDatasets(ctx context.Context) ([]DatasetMetadata, error)
+	ValidateReportConfig(ctx context.Context, config ReportConfig) (*ValidateConfigResponse, error)
+
+	// Shutdown waits for running executions until ctx is done, then cancels them
+	Shutdown(ctx context.Context) error
 }
 
 // service implements the Service interface
 type service struct {
 	repo     Repository
 	exporter Exporter
+
+	// files and downloads are set by ConfigureDownloads; nil leaves exports unstored
+	files     FileStore
+	downloads *DownloadSigner
+
+	// queryPlans is set by ConfigureQueryPlans; executions may only ask for a plan when it is on
+	queryPlans bool
+
+	// rowLimit caps the rows of one execution, MaxReportRows unless a test lowers it
+	rowLimit int
+
+	// running holds the cancel function of each in-flight execution
+	runningMu sync.Mutex
+	running   map[uuid.UUID]context.CancelCauseFunc
+	jobs      sync.WaitGroup
+	closed    bool
+
+	// shutdown is cancelled when remaining executions must stop
+	shutdown     context.Context
+	stopShutdown context.CancelFunc
 }
 
+// ExecutionTimeout bounds how long a single report execution may run
+const ExecutionTimeout = 30 * time.Minute
+
+// MaxReportRows caps the rows one execution returns, whatever the config's limit.
+// An execution that reaches it is marked truncated rather than failed.
+const MaxReportRows = 100_000
+
+var (
+	errExecutionCancelled = errors.New("cancelled by user")
+	errExecutionTimeout   = errors.New("execution timed out")
+	errServiceShutdown    = errors.New("service shutting down")
+)
+
 // Exporter defines the interface for report export functionality
 type Exporter interface {
 	ExportCSV(ctx context.Context, data []map[string]interface{}, config ExportConfig) ([]byte, error)
@@ -79,9 +155,14 @@ type ExportConfig struct {
 
 // NewService creates a new reports service
 func NewService(repo Repository, exporter Exporter) Service {
+	shutdown, stop := context.WithCancel(context.Background())
 	return &service{
-		repo:     repo,
-		exporter: exporter,
+		repo:         repo,
+		exporter:     exporter,
+		rowLimit:     MaxReportRows,
+		running:      make(map[uuid.UUID]context.CancelCauseFunc),
+		shutdown:     shutdown,
+		stopShutdown: stop,
 	}
 }
 
@@ -90,7 +171,7 @@ func NewService(repo Repository, exporter Exporter) Service {
 func (s *service) CreateReport(ctx context.Context, userID uuid.UUID, req CreateReportRequest) (*ReportDefinition, error) {
 	// Validate the report configuration
 	if err := validateReportConfig(req.Config); err != nil {
-		return nil, fmt.Errorf("invalid report configuration: %w", err)
+		return nil, apperrors.Wrap(apperrors.ErrValidation, "invalid report configuration", err)
 	}
 
 	// Convert config to JSON
@@ -100,15 +181,16 @@ func (s *service) CreateReport(ctx context.Context, userID uuid.UUID, req Create
 	}
 
 	report := &ReportDefinition{
-		ID:          uuid.New(),
-		Name:        req.Name,
-		Description: req.Description,
-		Category:    req.Category,
-		Config:      datatypes.JSON(configJSON),
-		CreatedBy:   &userID,
-		Visibility:  req.Visibility,
-		IsTemplate:  req.IsTemplate,
-		Version:     1,
+		ID:             uuid.New(),
+		Name:           req.Name,
+		Description:    req.Description,
+		Category:       req.Category,
+		Config:         datatypes.JSON(configJSON),
+		CreatedBy:      &userID,
+		OrganizationID: TenantFromContext(ctx).OrganizationID,
+		Visibility:     req.Visibility,
+		IsTemplate:     req.IsTemplate,
+		Version:        1,
 	}
 
 	if report.Visibility == "" {
@@ -119,18 +201,22 @@ func (s *service) CreateReport(ctx context.Context, userID uuid.UUID, req Create
 		return nil, fmt.Errorf("failed to create report: %w", err)
 	}
 
+	if err := s.snapshotReport(ctx, report, userID); err != nil {
+		return nil, err
+	}
+
 	return report, nil
 }
 
 func (s *service) GetReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID) (*ReportDefinition, error) {
 	report, err := s.repo.GetReportDefinition(ctx, reportID)
 	if err != nil {
-		return nil, fmt.Errorf("report not found: %w", err)
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "report not found", err)
 	}
 
 	// Check access permission
-	if !s.canAccessReport(report, userID) {
-		return nil, fmt.Errorf("access denied to report")
+	if !s.canAccessReport(ctx, report, userID) {
+		return nil, fmt.Errorf("%w to report", ErrAccessDenied)
 	}
 
 	return report, nil
@@ -139,12 +225,12 @@ func (s *service) GetReport(ctx context.Context, userID uuid.UUID, reportID uuid
 func (s *service) UpdateReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, req UpdateReportRequest) (*ReportDefinition, error) {
 	report, err := s.repo.GetReportDefinition(ctx, reportID)
 	if err != nil {
-		return nil, fmt.Errorf("report not found: %w", err)
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "report not found", err)
 	}
 
 	// Check write permission
-	if !s.canModifyReport(report, userID) {
-		return nil, fmt.Errorf("access denied to modify report")
+	if !s.canModifyReport(ctx, report, userID) {
+		return nil, fmt.Errorf("%w to modify report", ErrAccessDenied)
 	}
 
 	// Update fields
@@ -162,7 +248,7 @@ func (s *service) UpdateReport(ctx context.Context, userID uuid.UUID, reportID u
 	}
 	if req.Config != nil {
 		if err := validateReportConfig(*req.Config); err != nil {
-			return nil, fmt.Errorf("invalid report configuration: %w", err)
+			return nil, apperrors.Wrap(apperrors.ErrValidation, "invalid report configuration", err)
 		}
 		configJSON, err := json.Marshal(req.Config)
 		if err != nil {
@@ -171,7 +257,7 @@ func (s *service) UpdateReport(ctx context.Context, userID uuid.UUID, reportID u
 		report.Config = datatypes.JSON(configJSON)
 	}
 
-	if err := s.repo.UpdateReportDefinition(ctx, report); err != nil {
+	if err := s.saveReportVersion(ctx, report, userID); err != nil {
 		return nil, fmt.Errorf("failed to update report: %w", err)
 	}
 
@@ -181,11 +267,11 @@ func (s *service) UpdateReport(ctx context.Context, userID uuid.UUID, reportID u
 func (s *service) DeleteReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID) error {
 	report, err := s.repo.GetReportDefinition(ctx, reportID)
 	if err != nil {
-		return fmt.Errorf("report not found: %w", err)
+		return apperrors.Wrap(apperrors.ErrNotFound, "report not found", err)
 	}
 
-	if !s.canModifyReport(report, userID) {
-		return fmt.Errorf("access denied to delete report")
+	if !s.canModifyReport(ctx, report, userID) {
+		return fmt.Errorf("%w to delete report", ErrAccessDenied)
 	}
 
 	return s.repo.DeleteReportDefinition(ctx, reportID)
@@ -193,6 +279,7 @@ func (s *service) DeleteReport(ctx context.Context, userID uuid.UUID, reportID u
 
 func (s *service) ListReports(ctx context.Context, userID uuid.UUID, filter ReportFilter) (*ListReportsResponse, error) {
 	filter.UserID = &userID
+	filter.Tenant = TenantFromContext(ctx)
 
 	if filter.PageSize == 0 {
 		filter.PageSize = 20
@@ -224,11 +311,11 @@ func (s *service) GetTemplates(ctx context.Context) ([]ReportDefinition, error)
 func (s *service) CloneReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, name string) (*ReportDefinition, error) {
 	original, err := s.repo.GetReportDefinition(ctx, reportID)
 	if err != nil {
-		return nil, fmt.Errorf("report not found: %w", err)
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "report not found", err)
 	}
 
-	if !s.canAccessReport(original, userID) {
-		return nil, fmt.Errorf("access denied")
+	if !s.canAccessReport(ctx, original, userID) {
+		return nil, ErrAccessDenied
 	}
 
 	clone := &ReportDefinition{
@@ -238,6 +325,7 @@ func (s *service) CloneReport(ctx context.Context, userID uuid.UUID, reportID uu
 		Category:          original.Category,
 		Config:            original.Config,
 		CreatedBy:         &userID,
+		OrganizationID:    TenantFromContext(ctx).OrganizationID,
 		Visibility:        VisibilityPrivate,
 		Version:           1,
 		IsTemplate:        false,
@@ -248,19 +336,215 @@ func (s *service) CloneReport(ctx context.Context, userID uuid.UUID, reportID uu
 		return nil, fmt.Errorf("failed to clone report: %w", err)
 	}
 
+	if err := s.snapshotReport(ctx, clone, userID); err != nil {
+		return nil, err
+	}
+
 	return clone, nil
 }
 
+func (s *service) ShareReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	report, err := s.getOwnedReport(ctx, userID, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	missing, err := s.repo.FindMissingUsers(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify users: %w", err)
+	}
+	if len(missing) > 0 {
+		return nil, apperrors.Validation(fmt.Sprintf("unknown user IDs: %v", missing), apperrors.FieldError{Field: "user_ids", Message: "contains unknown users"})
+	}
+
+	shared := report.SharedWithUsers
+	for _, id := range userIDs {
+		if !containsUUID(shared, id) {
+			shared = append(shared, id)
+		}
+	}
+
+	// Sharing a private report implicitly makes it shared
+	visibility := report.Visibility
+	if visibility == VisibilityPrivate && len(shared) > 0 {
+		visibility = VisibilityShared
+	}
+
+	if err := s.repo.UpdateReportSharing(ctx, reportID, visibility, shared); err != nil {
+		return nil, fmt.Errorf("failed to share report: %w", err)
+	}
+
+	return shared, nil
+}
+
+func (s *service) UnshareReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	report, err := s.getOwnedReport(ctx, userID, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make([]uuid.UUID, 0, len(report.SharedWithUsers))
+	for _, id := range report.SharedWithUsers {
+		if !containsUUID(userIDs, id) {
+			shared = append(shared, id)
+		}
+	}
+
+	if err := s.repo.UpdateReportSharing(ctx, reportID, report.Visibility, shared); err != nil {
+		return nil, fmt.Errorf("failed to unshare report: %w", err)
+	}
+
+	return shared, nil
+}
+
+func (s *service) SetReportVisibility(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, visibility ReportVisibility) (*ReportDefinition, error) {
+	switch visibility {
+	case VisibilityPrivate, VisibilityShared, VisibilityPublic:
+	default:
+		return nil, apperrors.Validation(fmt.Sprintf("invalid visibility: %s", visibility), apperrors.FieldError{Field: "visibility", Message: "must be private, shared or public"})
+	}
+
+	report, err := s.getOwnedReport(ctx, userID, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateReportSharing(ctx, reportID, visibility, report.SharedWithUsers); err != nil {
+		return nil, fmt.Errorf("failed to update visibility: %w", err)
+	}
+
+	report.Visibility = visibility
+	return report, nil
+}
+
+func (s *service) ListReportVersions(ctx context.Context, userID uuid.UUID, reportID uuid.UUID) ([]ReportVersion, error) {
+	if _, err := s.GetReport(ctx, userID, reportID); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.repo.ListReportVersions(ctx, reportID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+	return versions, nil
+}
+
+func (s *service) DiffReportVersions(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, fromVersion, toVersion int) (*ReportVersionDiffResponse, error) {
+	if _, err := s.GetReport(ctx, userID, reportID); err != nil {
+		return nil, err
+	}
+
+	from, err := s.repo.GetReportVersion(ctx, reportID, fromVersion)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, fmt.Sprintf("version %d not found", fromVersion), err)
+	}
+	to, err := s.repo.GetReportVersion(ctx, reportID, toVersion)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, fmt.Sprintf("version %d not found", toVersion), err)
+	}
+
+	changes, err := diffConfigs(from.Config, to.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff versions: %w", err)
+	}
+
+	return &ReportVersionDiffResponse{
+		ReportID:    reportID,
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Changes:     changes,
+	}, nil
+}
+
+func (s *service) RevertReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, version int) (*ReportDefinition, error) {
+	report, err := s.repo.GetReportDefinition(ctx, reportID)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "report not found", err)
+	}
+
+	if !s.canModifyReport(ctx, report, userID) {
+		return nil, fmt.Errorf("%w to modify report", ErrAccessDenied)
+	}
+
+	snapshot, err := s.repo.GetReportVersion(ctx, reportID, version)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, fmt.Sprintf("version %d not found", version), err)
+	}
+
+	// Reverting never rewrites history: the old snapshot becomes a new version
+	report.Name = snapshot.Name
+	report.Description = snapshot.Description
+	report.Config = snapshot.Config
+
+	if err := s.saveReportVersion(ctx, report, userID); err != nil {
+		return nil, fmt.Errorf("failed to revert report: %w", err)
+	}
+
+	return report, nil
+}
+
+// saveReportVersion persists an edited report as a new version and snapshots it.
+// Reports created before versioning get their pre-edit state snapshotted first.
+func (s *service) saveReportVersion(ctx context.Context, report *ReportDefinition, userID uuid.UUID) error {
+	if _, err := s.repo.GetReportVersion(ctx, report.ID, report.Version); err != nil {
+		previous, err := s.repo.GetReportDefinition(ctx, report.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.snapshotReport(ctx, previous, userID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.UpdateReportDefinition(ctx, report); err != nil {
+		return err
+	}
+
+	return s.snapshotReport(ctx, report, userID)
+}
+
+// snapshotReport stores the report's current config as an immutable version
+func (s *service) snapshotReport(ctx context.Context, report *ReportDefinition, userID uuid.UUID) error {
+	snapshot := &ReportVersion{
+		ID:                 uuid.New(),
+		ReportDefinitionID: report.ID,
+		Version:            report.Version,
+		Name:               report.Name,
+		Description:        report.Description,
+		Config:             report.Config,
+		CreatedBy:          &userID,
+	}
+
+	if err := s.repo.CreateReportVersion(ctx, snapshot); err != nil {
+		return fmt.Errorf("failed to snapshot report version: %w", err)
+	}
+	return nil
+}
+
+// getOwnedReport loads a report and verifies the caller owns it
+func (s *service) getOwnedReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID) (*ReportDefinition, error) {
+	report, err := s.repo.GetReportDefinition(ctx, reportID)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "report not found", err)
+	}
+
+	if !s.canModifyReport(ctx, report, userID) {
+		return nil, fmt.Errorf("%w: only the owner can manage sharing", ErrAccessDenied)
+	}
+
+	return report, nil
+}
+
 // ========== Report Execution ==========
 
 func (s *service) ExecuteReport(ctx context.Context, userID uuid.UUID, reportID uuid.UUID, req ExecuteReportRequest) (*ReportExecution, error) {
 	report, err := s.repo.GetReportDefinition(ctx, reportID)
 	if err != nil {
-		return nil, fmt.Errorf("report not found: %w", err)
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "report not found", err)
 	}
 
-	if !s.canAccessReport(report, userID) {
-		return nil, fmt.Errorf("access denied")
+	if !s.canAccessReport(ctx, report, userID) {
+		return nil, ErrAccessDenied
 	}
 
 	// Parse report config
@@ -268,6 +552,16 @@ func (s *service) ExecuteReport(ctx context.Context, userID uuid.UUID, reportID
 	if err := json.Unmarshal(report.Config, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse report config: %w", err)
 	}
+	if err := s.scopeToMemberProjects(ctx, &config, userID); err != nil {
+		return nil, err
+	}
+	if req.Explain && !s.queryPlans {
+		return nil, apperrors.Field("explain", "query plan capture is disabled")
+	}
+	// An unknown format would produce an empty export stored without an extension
+	if _, ok := exportFileExtensions[req.Format]; req.Format != "" && !ok {
+		return nil, apperrors.Field("format", fmt.Sprintf("unsupported format %q; use csv, excel, pdf or json", req.Format))
+	}
 
 	// Create execution record
 	now := time.Now()
@@ -284,29 +578,61 @@ func (s *service) ExecuteReport(ctx context.Context, userID uuid.UUID, reportID
 		execution.Parameters = datatypes.JSON(paramsJSON)
 	}
 
+	// The execution outlives the request but keeps its tenant and request ID
+	runCtx, done, err := s.startExecution(ctx, execution.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.CreateExecution(ctx, execution); err != nil {
+		done()
 		return nil, fmt.Errorf("failed to create execution: %w", err)
 	}
 
-	// Execute the report
-	go s.processReportExecution(context.Background(), execution, config, req.Format)
+	// The worker keeps updating execution, so the caller gets a snapshot
+	snapshot := *execution
 
-	return execution, nil
+	go func() {
+		defer done()
+		s.processReportExecution(runCtx, execution, config, req)
+	}()
+
+	return &snapshot, nil
 }
 
-func (s *service) processReportExecution(ctx context.Context, execution *ReportExecution, config ReportConfig, format ExportFormat) {
-	// Execute the dynamic query
-	data, recordCount, err := s.repo.ExecuteDynamicQuery(ctx, config)
+func (s *service) processReportExecution(ctx context.Context, execution *ReportExecution, config ReportConfig, req ExecuteReportRequest) {
+	// Execute the dynamic query, persisting progress as rows stream in
+	config.rowLimit = s.rowLimit
+	data, recordCount, err := s.repo.ExecuteDynamicQuery(ctx, config, func(rowsProcessed, total int64) {
+		execution.RowsProcessed = rowsProcessed
+		execution.ProgressPercent = queryProgressPercent(rowsProcessed, total)
+		s.repo.UpdateExecutionProgress(ctx, execution.ID, execution.RowsProcessed, execution.ProgressPercent)
+	})
+	if ctx.Err() != nil {
+		s.abortExecution(ctx, execution)
+		return
+	}
 	if err != nil {
 		execution.Status = StatusFailed
 		execution.ErrorMessage = err.Error()
-		s.repo.UpdateExecution(ctx, execution)
+		s.finishExecution(ctx, execution)
 		return
 	}
 
+	// The query reads one row past the cap, so more rows than it means some were left out
+	if s.rowLimit > 0 && len(data) > s.rowLimit {
+		data = data[:s.rowLimit]
+		execution.Truncated = true
+		execution.ExecutionLog += fmt.Sprintf("output truncated at %d rows\n", s.rowLimit)
+	}
+
 	execution.RecordCount = int(recordCount)
+	if req.Explain {
+		s.captureQueryPlan(ctx, execution, config)
+	}
 
 	// Export to requested format
+	format := req.Format
 	if format == "" {
 		format = FormatJSON // Default
 	}
@@ -315,6 +641,8 @@ func (s *service) processReportExecution(ctx context.Context, execution *ReportE
 	exportConfig := ExportConfig{
 		Title:         "",
 		Fields:        config.Fields,
+		DateFormat:    req.DateFormat,
+		Locale:        req.Locale,
 		IncludeHeader: true,
 	}
 
@@ -332,13 +660,25 @@ func (s *service) processReportExecution(ctx context.Context, execution *ReportE
 			exportData, err = s.exporter.ExportPDF(ctx, data, exportConfig)
 		}
 	case FormatJSON:
-		exportData, err = json.Marshal(data)
+		exportData, err = json.Marshal(visibleRows(data, config.Fields))
 	}
 
 	if err != nil {
 		execution.Status = StatusFailed
 		execution.ErrorMessage = fmt.Sprintf("export failed: %v", err)
-		s.repo.UpdateExecution(ctx, execution)
+		s.finishExecution(ctx, execution)
+		return
+	}
+
+	if ctx.Err() != nil {
+		s.abortExecution(ctx, execution)
+		return
+	}
+
+	if err := s.storeExport(ctx, execution, format, exportData); err != nil {
+		execution.Status = StatusFailed
+		execution.ErrorMessage = fmt.Sprintf("failed to store export: %v", err)
+		s.finishExecution(ctx, execution)
 		return
 	}
 
@@ -347,18 +687,67 @@ func (s *service) processReportExecution(ctx context.Context, execution *ReportE
 	execution.CompletedAt = &now
 	execution.Status = StatusCompleted
 	execution.FileSizeBytes = int64(len(exportData))
+	execution.RowsProcessed = int64(len(data))
+	execution.ProgressPercent = 100
 
-	// In production, you'd store the file in S3 and set FileKey/DownloadURL
-	// For now, we'll just mark it complete
+	s.finishExecution(ctx, execution)
+}
 
-	s.repo.UpdateExecution(ctx, execution)
+// finishExecution records how a run ended. A run cancelled in the meantime keeps its
+// cancelled row, and the export it may have stored is removed again.
+func (s *service) finishExecution(ctx context.Context, execution *ReportExecution) {
+	finished, err := s.repo.FinishExecution(ctx, execution)
+	if err != nil {
+		log.Printf("[%s] failed to record report execution %s: %v", requestid.FromContext(ctx), execution.ID, err)
+	}
+	if finished || execution.FileKey == "" || s.files == nil {
+		return
+	}
+	if err := s.files.Delete(context.WithoutCancel(ctx), execution.FileKey); err != nil {
+		log.Printf("[%s] failed to remove export of cancelled execution %s: %v", requestid.FromContext(ctx), execution.ID, err)
+	}
 }
 
-func (s *service) GetExecution(ctx context.Context, executionID uuid.UUID) (*ReportExecution, error) {
-	return s.repo.GetExecution(ctx, executionID)
+func (s *service) GetExecution(ctx context.Context, userID uuid.UUID, executionID uuid.UUID) (*ReportExecution, error) {
+	execution, report, err := s.getTenantExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.canAccessReport(ctx, report, userID) {
+		return nil, fmt.Errorf("%w: no access to the executed report", ErrAccessDenied)
+	}
+
+	return execution, nil
+}
+
+// getTenantExecution loads an execution and its report, hiding executions from other organizations
+func (s *service) getTenantExecution(ctx context.Context, executionID uuid.UUID) (*ReportExecution, *ReportDefinition, error) {
+	execution, err := s.repo.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, nil, apperrors.Wrap(apperrors.ErrNotFound, "execution not found", err)
+	}
+
+	report := execution.ReportDefinition
+	if report == nil {
+		// Without its report there is no organization to check the execution against
+		if execution.ReportDefinitionID == nil {
+			return nil, nil, apperrors.NotFound("execution not found")
+		}
+		report, err = s.repo.GetReportDefinition(ctx, *execution.ReportDefinitionID)
+		if err != nil {
+			return nil, nil, apperrors.Wrap(apperrors.ErrNotFound, "execution not found", err)
+		}
+	}
+	if !TenantFromContext(ctx).canSee(report.OrganizationID) {
+		return nil, nil, apperrors.NotFound("execution not found")
+	}
+	return execution, report, nil
 }
 
 func (s *service) ListExecutions(ctx context.Context, filter ExecutionFilter) (*ListExecutionsResponse, error) {
+	filter.Tenant = TenantFromContext(ctx)
+
 	if filter.PageSize == 0 {
 		filter.PageSize = 20
 	}
@@ -382,14 +771,29 @@ func (s *service) ListExecutions(ctx context.Context, filter ExecutionFilter) (*
 	}, nil
 }
 
-func (s *service) CancelExecution(ctx context.Context, executionID uuid.UUID) error {
-	execution, err := s.repo.GetExecution(ctx, executionID)
+// CancelExecution stops a pending or running execution. The report owner may cancel
+// any of its executions; other readers only the ones they triggered.
+func (s *service) CancelExecution(ctx context.Context, userID uuid.UUID, executionID uuid.UUID) error {
+	execution, report, err := s.getTenantExecution(ctx, executionID)
 	if err != nil {
-		return fmt.Errorf("execution not found: %w", err)
+		return err
+	}
+
+	triggeredByCaller := execution.TriggeredBy != nil && *execution.TriggeredBy == userID
+	if !s.canModifyReport(ctx, report, userID) && !(triggeredByCaller && s.canAccessReport(ctx, report, userID)) {
+		return fmt.Errorf("%w: only the report owner or whoever ran it can cancel an execution", ErrAccessDenied)
 	}
 
 	if execution.Status != StatusPending && execution.Status != StatusProcessing {
-		return fmt.Errorf("cannot cancel execution with status: %s", execution.Status)
+		return apperrors.Conflict(fmt.Sprintf("cannot cancel execution with status: %s", execution.Status))
+	}
+
+	// Abort the running query first; pgx sends a cancel request to postgres when the context is done
+	s.runningMu.Lock()
+	cancel, ok := s.running[executionID]
+	s.runningMu.Unlock()
+	if ok {
+		cancel(errExecutionCancelled)
 	}
 
 	execution.Status = StatusFailed
@@ -397,21 +801,106 @@ func (s *service) CancelExecution(ctx context.Context, executionID uuid.UUID) er
 	now := time.Now()
 	execution.CompletedAt = &now
 
-	return s.repo.UpdateExecution(ctx, execution)
+	// The run may have finished before it saw the cancellation; its outcome then stands
+	finished, err := s.repo.FinishExecution(ctx, execution)
+	if err != nil {
+		return err
+	}
+	if !finished {
+		return apperrors.Conflict("execution already finished")
+	}
+
+	return nil
+}
+
+// startExecution derives the context an execution runs under. It keeps the values of ctx
+// (tenant, request ID, trace) but not its cancellation, is bounded by ExecutionTimeout and
+// is cancelled by CancelExecution or Shutdown. The returned func must be called when the
+// execution ends.
+func (s *service) startExecution(ctx context.Context, executionID uuid.UUID) (context.Context, func(), error) {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+	if s.closed {
+		return nil, nil, errServiceShutdown
+	}
+
+	detached, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	runCtx, cancelTimeout := context.WithTimeoutCause(detached, ExecutionTimeout, errExecutionTimeout)
+	stopOnShutdown := context.AfterFunc(s.shutdown, func() { cancel(errServiceShutdown) })
+
+	s.running[executionID] = cancel
+	s.jobs.Add(1)
+
+	return runCtx, func() {
+		stopOnShutdown()
+		cancelTimeout()
+		cancel(context.Canceled)
+
+		s.runningMu.Lock()
+		delete(s.running, executionID)
+		s.runningMu.Unlock()
+		s.jobs.Done()
+	}, nil
+}
+
+// abortExecution records why an execution stopped early. User cancellation is
+// recorded by CancelExecution itself, so only timeouts and shutdowns are written here.
+func (s *service) abortExecution(ctx context.Context, execution *ReportExecution) {
+	cause := context.Cause(ctx)
+	if errors.Is(cause, errExecutionCancelled) {
+		return
+	}
+
+	log.Printf("[%s] report execution %s stopped: %v", requestid.FromContext(ctx), execution.ID, cause)
+
+	now := time.Now()
+	execution.Status = StatusFailed
+	execution.ErrorMessage = cause.Error()
+	execution.CompletedAt = &now
+
+	// The run context is already done, so write with a short detached one
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	s.finishExecution(writeCtx, execution)
+}
+
+func (s *service) Shutdown(ctx context.Context) error {
+	s.runningMu.Lock()
+	s.closed = true
+	s.runningMu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		s.jobs.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		s.stopShutdown()
+		<-finished
+		return ctx.Err()
+	}
 }
 
 // ========== Scheduled Reports ==========
 
 func (s *service) CreateSchedule(ctx context.Context, userID uuid.UUID, req CreateScheduleRequest) (*ReportSchedule, error) {
-	// Verify report exists
-	_, err := s.repo.GetReportDefinition(ctx, req.ReportDefinitionID)
+	report, err := s.repo.GetReportDefinition(ctx, req.ReportDefinitionID)
 	if err != nil {
-		return nil, fmt.Errorf("report not found: %w", err)
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "report not found", err)
+	}
+
+	// Scheduled runs see what the report's owner can see, so only the owner may schedule it
+	if !s.canModifyReport(ctx, report, userID) {
+		return nil, fmt.Errorf("%w: only the report owner can schedule it", ErrAccessDenied)
 	}
 
 	// Validate cron expression
 	if err := validateCronExpression(req.CronExpression); err != nil {
-		return nil, fmt.Errorf("invalid cron expression: %w", err)
+		return nil, apperrors.Wrap(apperrors.ErrValidation, "invalid cron expression", err)
 	}
 
 	deliveryConfigJSON, err := json.Marshal(req.DeliveryConfig)
@@ -440,6 +929,12 @@ func (s *service) CreateSchedule(ctx context.Context, userID uuid.UUID, req Crea
 		schedule.Timezone = "UTC"
 	}
 
+	nextRun, err := nextScheduleRun(schedule.CronExpression, schedule.Timezone, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	schedule.NextRunAt = &nextRun
+
 	if err := s.repo.CreateSchedule(ctx, schedule); err != nil {
 		return nil, fmt.Errorf("failed to create schedule: %w", err)
 	}
@@ -447,18 +942,28 @@ func (s *service) CreateSchedule(ctx context.Context, userID uuid.UUID, req Crea
 	return schedule, nil
 }
 
-func (s *service) GetSchedule(ctx context.Context, scheduleID uuid.UUID) (*ReportSchedule, error) {
-	return s.repo.GetSchedule(ctx, scheduleID)
+func (s *service) GetSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID) (*ReportSchedule, error) {
+	schedule, report, err := s.getTenantSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.canAccessReport(ctx, report, userID) {
+		return nil, fmt.Errorf("%w: no access to the scheduled report", ErrAccessDenied)
+	}
+
+	describeNextRun(schedule, time.Now())
+	return schedule, nil
 }
 
-func (s *service) UpdateSchedule(ctx context.Context, scheduleID uuid.UUID, req CreateScheduleRequest) (*ReportSchedule, error) {
-	schedule, err := s.repo.GetSchedule(ctx, scheduleID)
+func (s *service) UpdateSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID, req CreateScheduleRequest) (*ReportSchedule, error) {
+	schedule, err := s.getOwnedSchedule(ctx, userID, scheduleID)
 	if err != nil {
-		return nil, fmt.Errorf("schedule not found: %w", err)
+		return nil, err
 	}
 
 	if err := validateCronExpression(req.CronExpression); err != nil {
-		return nil, fmt.Errorf("invalid cron expression: %w", err)
+		return nil, apperrors.Wrap(apperrors.ErrValidation, "invalid cron expression", err)
 	}
 
 	deliveryConfigJSON, err := json.Marshal(req.DeliveryConfig)
@@ -477,6 +982,15 @@ func (s *service) UpdateSchedule(ctx context.Context, scheduleID uuid.UUID, req
 	schedule.RecipientEmails = req.RecipientEmails
 	schedule.RecipientUserIDs = req.RecipientUserIDs
 	schedule.WebhookURL = req.WebhookURL
+	if schedule.Timezone == "" {
+		schedule.Timezone = "UTC"
+	}
+
+	nextRun, err := nextScheduleRun(schedule.CronExpression, schedule.Timezone, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	schedule.NextRunAt = &nextRun
 
 	if err := s.repo.UpdateSchedule(ctx, schedule); err != nil {
 		return nil, fmt.Errorf("failed to update schedule: %w", err)
@@ -485,31 +999,205 @@ func (s *service) UpdateSchedule(ctx context.Context, scheduleID uuid.UUID, req
 	return schedule, nil
 }
 
-func (s *service) DeleteSchedule(ctx context.Context, scheduleID uuid.UUID) error {
+func (s *service) DeleteSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID) error {
+	if _, err := s.getOwnedSchedule(ctx, userID, scheduleID); err != nil {
+		return err
+	}
 	return s.repo.DeleteSchedule(ctx, scheduleID)
 }
 
 func (s *service) ListSchedules(ctx context.Context, filter ScheduleFilter) ([]ReportSchedule, int64, error) {
-	return s.repo.ListSchedules(ctx, filter)
+	filter.Tenant = TenantFromContext(ctx)
+	schedules, total, err := s.repo.ListSchedules(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	for i := range schedules {
+		describeNextRun(&schedules[i], now)
+	}
+	return schedules, total, nil
 }
 
-func (s *service) ToggleSchedule(ctx context.Context, scheduleID uuid.UUID, active bool) error {
-	schedule, err := s.repo.GetSchedule(ctx, scheduleID)
+func (s *service) ToggleSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID, active bool) error {
+	schedule, err := s.getOwnedSchedule(ctx, userID, scheduleID)
 	if err != nil {
-		return fmt.Errorf("schedule not found: %w", err)
+		return err
 	}
 
 	schedule.IsActive = active
+	if active {
+		// Re-enabled schedules resume from now rather than catching up on missed runs
+		nextRun, err := nextScheduleRun(schedule.CronExpression, schedule.Timezone, time.Now())
+		if err != nil {
+			return err
+		}
+		schedule.NextRunAt = &nextRun
+	}
 	return s.repo.UpdateSchedule(ctx, schedule)
 }
 
+// MaxScheduleRecipients caps each recipient list on a schedule, matching the create request limit
+const MaxScheduleRecipients = 100
+
+// SubscribeToSchedule adds the caller, and the email from their token if they have one,
+// to an email schedule's recipients. The caller must be able to read the scheduled report.
+func (s *service) SubscribeToSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID, email string) (*ReportSchedule, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: sign in to subscribe", ErrAccessDenied)
+	}
+
+	schedule, report, err := s.getTenantSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canAccessReport(ctx, report, userID) {
+		return nil, fmt.Errorf("%w: no access to the scheduled report", ErrAccessDenied)
+	}
+	if schedule.DeliveryMethod != DeliveryEmail {
+		return nil, apperrors.Conflict("only email schedules accept subscribers")
+	}
+
+	userIDs := schedule.RecipientUserIDs
+	if !containsUUID(userIDs, userID) {
+		userIDs = append(userIDs, userID)
+	}
+	emails := schedule.RecipientEmails
+	if email != "" && !containsEmail(emails, email) {
+		emails = append(emails, email)
+	}
+	if len(userIDs) > MaxScheduleRecipients || len(emails) > MaxScheduleRecipients {
+		return nil, apperrors.Conflict(fmt.Sprintf("schedule already has the maximum of %d recipients", MaxScheduleRecipients))
+	}
+
+	if err := s.repo.UpdateScheduleRecipients(ctx, scheduleID, emails, userIDs); err != nil {
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+	schedule.RecipientUserIDs, schedule.RecipientEmails = userIDs, emails
+	return schedule, nil
+}
+
+// UnsubscribeFromSchedule removes the caller, and the email from their token, from a
+// schedule's recipients. Current recipients may opt out after losing access to the report;
+// anyone else needs access to it.
+func (s *service) UnsubscribeFromSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID, email string) (*ReportSchedule, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: sign in to unsubscribe", ErrAccessDenied)
+	}
+
+	schedule, report, err := s.getTenantSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	recipient := containsUUID(schedule.RecipientUserIDs, userID) || (email != "" && containsEmail(schedule.RecipientEmails, email))
+	if !recipient && !s.canAccessReport(ctx, report, userID) {
+		return nil, fmt.Errorf("%w: no access to the scheduled report", ErrAccessDenied)
+	}
+
+	userIDs := make([]uuid.UUID, 0, len(schedule.RecipientUserIDs))
+	for _, id := range schedule.RecipientUserIDs {
+		if id != userID {
+			userIDs = append(userIDs, id)
+		}
+	}
+	emails := make([]string, 0, len(schedule.RecipientEmails))
+	for _, e := range schedule.RecipientEmails {
+		if email == "" || !strings.EqualFold(e, email) {
+			emails = append(emails, e)
+		}
+	}
+
+	if err := s.repo.UpdateScheduleRecipients(ctx, scheduleID, emails, userIDs); err != nil {
+		return nil, fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+	schedule.RecipientUserIDs, schedule.RecipientEmails = userIDs, emails
+	return schedule, nil
+}
+
+// getTenantSchedule loads a schedule and its report, hiding schedules from other organizations
+func (s *service) getTenantSchedule(ctx context.Context, scheduleID uuid.UUID) (*ReportSchedule, *ReportDefinition, error) {
+	schedule, err := s.repo.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, nil, apperrors.Wrap(apperrors.ErrNotFound, "schedule not found", err)
+	}
+
+	report := schedule.ReportDefinition
+	if report == nil {
+		report, err = s.repo.GetReportDefinition(ctx, schedule.ReportDefinitionID)
+		if err != nil {
+			return nil, nil, apperrors.Wrap(apperrors.ErrNotFound, "schedule not found", err)
+		}
+	}
+	if !TenantFromContext(ctx).canSee(report.OrganizationID) {
+		return nil, nil, apperrors.NotFound("schedule not found")
+	}
+	return schedule, report, nil
+}
+
+// getOwnedSchedule loads a schedule and verifies the caller owns its report
+func (s *service) getOwnedSchedule(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID) (*ReportSchedule, error) {
+	schedule, report, err := s.getTenantSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.canModifyReport(ctx, report, userID) {
+		return nil, fmt.Errorf("%w: only the report owner can change its schedules", ErrAccessDenied)
+	}
+
+	return schedule, nil
+}
+
+// RunSchedule executes a schedule's report synchronously and links the execution to the schedule
+func (s *service) RunSchedule(ctx context.Context, schedule *ReportSchedule) (*ReportExecution, error) {
+	report, err := s.repo.GetReportDefinition(ctx, schedule.ReportDefinitionID)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "report not found", err)
+	}
+
+	var config ReportConfig
+	if err := json.Unmarshal(report.Config, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse report config: %w", err)
+	}
+
+	// Scheduled runs see what the report's owner can see
+	if err := s.scopeToMemberProjects(ctx, &config, ownerOf(report)); err != nil {
+		return nil, err
+	}
+
+	scheduleID := schedule.ID
+	reportID := report.ID
+	execution := &ReportExecution{
+		ID:                 uuid.New(),
+		ReportDefinitionID: &reportID,
+		ScheduleID:         &scheduleID,
+		TriggeredAt:        time.Now(),
+		Status:             StatusProcessing,
+	}
+
+	runCtx, done, err := s.startExecution(ctx, execution.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	if err := s.repo.CreateExecution(ctx, execution); err != nil {
+		return nil, fmt.Errorf("failed to create execution: %w", err)
+	}
+
+	s.processReportExecution(runCtx, execution, config, ExecuteReportRequest{Format: schedule.Format})
+
+	return execution, nil
+}
+
 // ========== Benchmarks ==========
 
 func (s *service) CompareBenchmark(ctx context.Context, req BenchmarkComparisonRequest) (*BenchmarkComparisonResponse, error) {
 	// Get benchmark dataset
 	benchmark, err := s.repo.GetBenchmarkByCategory(ctx, req.Category, req.Methodology, req.Region, req.Year)
 	if err != nil {
-		return nil, fmt.Errorf("benchmark not found: %w", err)
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "benchmark not found", err)
 	}
 
 	// Parse benchmark data
@@ -520,7 +1208,19 @@ func (s *service) CompareBenchmark(ctx context.Context, req BenchmarkComparisonR
 
 	// Get project metrics (this would query actual project data)
 	projectMetrics := s.getProjectMetrics(ctx, req.ProjectID)
+	results, percentileRanks, gaps := compareMetrics(projectMetrics, benchmarkData)
 
+	return &BenchmarkComparisonResponse{
+		ProjectID:      req.ProjectID,
+		ProjectMetrics: projectMetrics,
+		Benchmarks:     results,
+		PercentileRank: percentileRanks,
+		GapAnalysis:    gaps,
+	}, nil
+}
+
+// compareMetrics compares each benchmarked metric the project has a value for
+func compareMetrics(projectMetrics map[string]float64, benchmarkData []BenchmarkData) ([]BenchmarkResult, map[string]float64, []GapAnalysisResult) {
 	// Calculate comparison results
 	results := make([]BenchmarkResult, 0, len(benchmarkData))
 	percentileRanks := make(map[string]float64)
@@ -577,13 +1277,7 @@ func (s *service) CompareBenchmark(ctx context.Context, req BenchmarkComparisonR
 		}
 	}
 
-	return &BenchmarkComparisonResponse{
-		ProjectID:      req.ProjectID,
-		ProjectMetrics: projectMetrics,
-		Benchmarks:     results,
-		PercentileRank: percentileRanks,
-		GapAnalysis:    gaps,
-	}, nil
+	return results, percentileRanks, gaps
 }
 
 func (s *service) ListBenchmarks(ctx context.Context, filter BenchmarkFilter) ([]BenchmarkDataset, error) {
@@ -601,7 +1295,7 @@ func (s *service) CreateBenchmark(ctx context.Context, dataset *BenchmarkDataset
 func (s *service) UpdateBenchmark(ctx context.Context, datasetID uuid.UUID, dataset *BenchmarkDataset) (*BenchmarkDataset, error) {
 	existing, err := s.repo.GetBenchmarkDataset(ctx, datasetID)
 	if err != nil {
-		return nil, fmt.Errorf("benchmark not found: %w", err)
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "benchmark not found", err)
 	}
 
 	existing.Name = dataset.Name
@@ -625,27 +1319,42 @@ func (s *service) UpdateBenchmark(ctx context.Context, datasetID uuid.UUID, data
 // ========== Dashboard ==========
 
 func (s *service) GetDashboardSummary(ctx context.Context, userID *uuid.UUID) (*DashboardSummary, error) {
-	return s.repo.GetDashboardSummary(ctx, userID)
+	return s.repo.GetDashboardSummary(ctx, userID, TenantFromContext(ctx))
 }
 
 func (s *service) GetTimeSeriesData(ctx context.Context, metric string, startTime, endTime time.Time, interval string) ([]TimeSeriesPoint, error) {
-	return s.repo.GetTimeSeriesData(ctx, metric, startTime, endTime, interval)
+	return s.repo.GetTimeSeriesData(ctx, metric, startTime, endTime, interval, TenantFromContext(ctx))
 }
 
 func (s *service) GetWidgets(ctx context.Context, userID uuid.UUID, section string) ([]DashboardWidget, error) {
 	if section != "" {
-		return s.repo.ListWidgetsBySection(ctx, section)
+		return s.repo.ListWidgetsBySection(ctx, userID, section, TenantFromContext(ctx))
 	}
-	return s.repo.ListWidgetsByUser(ctx, userID)
+	return s.repo.ListWidgetsByUser(ctx, userID, TenantFromContext(ctx))
 }
 
-func (s *service) SaveWidget(ctx context.Context, widget *DashboardWidget) (*DashboardWidget, error) {
+// SaveWidget creates a widget owned by userID in the caller's organization, or updates
+// one the caller owns. Owner and organization are never taken from the request.
+func (s *service) SaveWidget(ctx context.Context, userID uuid.UUID, widget *DashboardWidget) (*DashboardWidget, error) {
+	if widget.WidgetType == WidgetSingleValue {
+		if _, err := validateValueWidget(widget.Config); err != nil {
+			return nil, err
+		}
+	}
+
 	if widget.ID == uuid.Nil {
 		widget.ID = uuid.New()
+		widget.UserID = &userID
+		widget.OrganizationID = TenantFromContext(ctx).OrganizationID
 		if err := s.repo.CreateWidget(ctx, widget); err != nil {
 			return nil, fmt.Errorf("failed to create widget: %w", err)
 		}
 	} else {
+		existing, err := s.getOwnedWidget(ctx, userID, widget.ID)
+		if err != nil {
+			return nil, err
+		}
+		widget.UserID, widget.OrganizationID, widget.CreatedAt = existing.UserID, existing.OrganizationID, existing.CreatedAt
 		if err := s.repo.UpdateWidget(ctx, widget); err != nil {
 			return nil, fmt.Errorf("failed to update widget: %w", err)
 		}
@@ -653,81 +1362,316 @@ func (s *service) SaveWidget(ctx context.Context, widget *DashboardWidget) (*Das
 	return widget, nil
 }
 
-func (s *service) DeleteWidget(ctx context.Context, widgetID uuid.UUID) error {
+func (s *service) DeleteWidget(ctx context.Context, userID uuid.UUID, widgetID uuid.UUID) error {
+	if _, err := s.getOwnedWidget(ctx, userID, widgetID); err != nil {
+		return err
+	}
 	return s.repo.DeleteWidget(ctx, widgetID)
 }
 
+// getOwnedWidget loads a widget the caller may change. Other users' widgets, and
+// widgets shared with everyone, are reported as not found.
+func (s *service) getOwnedWidget(ctx context.Context, userID uuid.UUID, widgetID uuid.UUID) (*DashboardWidget, error) {
+	widget, err := s.repo.GetWidget(ctx, widgetID)
+	if err != nil || widget.UserID == nil || *widget.UserID != userID || !TenantFromContext(ctx).canSee(widget.OrganizationID) {
+		return nil, apperrors.NotFound("widget not found")
+	}
+	return widget, nil
+}
+
+// ========== Dashboard Views ==========
+
+// MaxDashboardViews caps how many saved views a single user may keep
+const MaxDashboardViews = 50
+
+func (s *service) ListDashboardViews(ctx context.Context, userID uuid.UUID) ([]DashboardView, error) {
+	views, err := s.repo.ListDashboardViews(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dashboard views: %w", err)
+	}
+	return views, nil
+}
+
+func (s *service) GetDashboardView(ctx context.Context, userID uuid.UUID, viewID uuid.UUID) (*DashboardView, error) {
+	view, err := s.getOwnDashboardView(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+	s.loadViewWidgets(ctx, userID, view)
+	return view, nil
+}
+
+// GetDefaultDashboardView returns the view the dashboard opens with, widgets included
+func (s *service) GetDefaultDashboardView(ctx context.Context, userID uuid.UUID) (*DashboardView, error) {
+	view, err := s.repo.GetDefaultDashboardView(ctx, userID)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "no default dashboard view", err)
+	}
+	s.loadViewWidgets(ctx, userID, view)
+	return view, nil
+}
+
+func (s *service) CreateDashboardView(ctx context.Context, userID uuid.UUID, req SaveDashboardViewRequest) (*DashboardView, error) {
+	existing, err := s.repo.ListDashboardViews(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dashboard views: %w", err)
+	}
+	if len(existing) >= MaxDashboardViews {
+		return nil, apperrors.Conflict(fmt.Sprintf("a user may keep at most %d dashboard views", MaxDashboardViews))
+	}
+
+	view := &DashboardView{ID: uuid.New(), UserID: userID}
+	if err := s.applyDashboardView(ctx, userID, view, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateDashboardView(ctx, view); err != nil {
+		return nil, fmt.Errorf("failed to create dashboard view: %w", err)
+	}
+
+	if req.IsDefault {
+		return s.SetDefaultDashboardView(ctx, userID, view.ID)
+	}
+	return view, nil
+}
+
+func (s *service) UpdateDashboardView(ctx context.Context, userID uuid.UUID, viewID uuid.UUID, req SaveDashboardViewRequest) (*DashboardView, error) {
+	view, err := s.getOwnDashboardView(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyDashboardView(ctx, userID, view, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateDashboardView(ctx, view); err != nil {
+		return nil, fmt.Errorf("failed to update dashboard view: %w", err)
+	}
+
+	if req.IsDefault && !view.IsDefault {
+		return s.SetDefaultDashboardView(ctx, userID, view.ID)
+	}
+	return view, nil
+}
+
+func (s *service) DeleteDashboardView(ctx context.Context, userID uuid.UUID, viewID uuid.UUID) error {
+	if _, err := s.getOwnDashboardView(ctx, userID, viewID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteDashboardView(ctx, viewID); err != nil {
+		return fmt.Errorf("failed to delete dashboard view: %w", err)
+	}
+	return nil
+}
+
+// SetDefaultDashboardView makes viewID the user's default, clearing the previous one
+func (s *service) SetDefaultDashboardView(ctx context.Context, userID uuid.UUID, viewID uuid.UUID) (*DashboardView, error) {
+	if _, err := s.getOwnDashboardView(ctx, userID, viewID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetDefaultDashboardView(ctx, userID, viewID); err != nil {
+		return nil, fmt.Errorf("failed to set default dashboard view: %w", err)
+	}
+	return s.getOwnDashboardView(ctx, userID, viewID)
+}
+
+// getOwnDashboardView loads a view, hiding views of other users as not found
+func (s *service) getOwnDashboardView(ctx context.Context, userID uuid.UUID, viewID uuid.UUID) (*DashboardView, error) {
+	view, err := s.repo.GetDashboardView(ctx, viewID)
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.ErrNotFound, "dashboard view not found", err)
+	}
+	if view.UserID != userID {
+		return nil, apperrors.NotFound("dashboard view not found")
+	}
+	return view, nil
+}
+
+// applyDashboardView validates req and copies it onto view
+func (s *service) applyDashboardView(ctx context.Context, userID uuid.UUID, view *DashboardView, req SaveDashboardViewRequest) error {
+	if userID == uuid.Nil {
+		return apperrors.Validation("a user is required to save dashboard views")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.WidgetIDs))
+	for i, widgetID := range req.WidgetIDs {
+		if seen[widgetID] {
+			return apperrors.Field(fmt.Sprintf("widget_ids[%d]", i), "lists the same widget twice")
+		}
+		seen[widgetID] = true
+
+		widget, err := s.repo.GetWidget(ctx, widgetID)
+		if err != nil || (widget.UserID != nil && *widget.UserID != userID) {
+			return apperrors.Field(fmt.Sprintf("widget_ids[%d]", i), "refers to a missing widget")
+		}
+	}
+
+	if tr := req.TimeRange; tr != nil {
+		if tr.Preset != "" && (tr.Start != nil || tr.End != nil) {
+			return apperrors.Field("time_range", "takes a preset or a start and end, not both")
+		}
+		if tr.Preset == "" && (tr.Start == nil || tr.End == nil || !tr.Start.Before(*tr.End)) {
+			return apperrors.Field("time_range", "needs a start before its end")
+		}
+	}
+
+	filtersJSON, err := json.Marshal(req.Filters)
+	if err != nil {
+		return apperrors.Wrap(apperrors.ErrValidation, "invalid filters", err)
+	}
+	timeRangeJSON, err := json.Marshal(req.TimeRange)
+	if err != nil {
+		return apperrors.Wrap(apperrors.ErrValidation, "invalid time range", err)
+	}
+
+	view.Name = req.Name
+	view.WidgetIDs = req.WidgetIDs
+	view.Filters = filtersJSON
+	view.TimeRange = timeRangeJSON
+	return nil
+}
+
+// loadViewWidgets fills view.Widgets in saved order, skipping widgets deleted since
+func (s *service) loadViewWidgets(ctx context.Context, userID uuid.UUID, view *DashboardView) {
+	view.Widgets = make([]DashboardWidget, 0, len(view.WidgetIDs))
+	for _, widgetID := range view.WidgetIDs {
+		widget, err := s.repo.GetWidget(ctx, widgetID)
+		if err != nil {
+			continue
+		}
+		if widget.UserID != nil && *widget.UserID != userID {
+			continue
+		}
+		view.Widgets = append(view.Widgets, *widget)
+	}
+}
+
 // ========== Datasets ==========
 
 func (s *service) GetAvailableDatasets(ctx context.Context) ([]DatasetMetadata, error) {
-	// Return predefined dataset metadata
-	return []DatasetMetadata{
-		{
-			Name:        "projects",
-			DisplayName: "Projects",
-			Description: "Carbon credit projects including details, status, and metrics",
-			Fields: []FieldMetadata{
-				{Name: "id", DisplayName: "Project ID", DataType: "string", IsFilterable: true, IsGroupable: true},
-				{Name: "name", DisplayName: "Project Name", DataType: "string", IsFilterable: true},
-				{Name: "status", DisplayName: "Status", DataType: "string", IsFilterable: true, IsGroupable: true, AllowedValues: []string{"active", "pending", "completed", "cancelled"}},
-				{Name: "methodology", DisplayName: "Methodology", DataType: "string", IsFilterable: true, IsGroupable: true},
-				{Name: "region", DisplayName: "Region", DataType: "string", IsFilterable: true, IsGroupable: true},
-				{Name: "total_area_hectares", DisplayName: "Total Area (ha)", DataType: "number", IsAggregatable: true},
-				{Name: "estimated_credits", DisplayName: "Estimated Credits", DataType: "number", IsAggregatable: true},
-				{Name: "created_at", DisplayName: "Created Date", DataType: "date", IsFilterable: true, IsGroupable: true},
-			},
-			JoinWith: []string{"carbon_credits", "monitoring_data"},
+	return reportDatasets, nil
+}
+
+// reportDatasets are the only tables reports may read; any other dataset is refused
+var reportDatasets = []DatasetMetadata{
+	{
+		Name:        "projects",
+		DisplayName: "Projects",
+		Description: "Carbon credit projects including details, status, and metrics",
+		Fields: []FieldMetadata{
+			{Name: "id", DisplayName: "Project ID", DataType: "string", IsFilterable: true, IsGroupable: true},
+			{Name: "name", DisplayName: "Project Name", DataType: "string", IsFilterable: true},
+			{Name: "status", DisplayName: "Status", DataType: "string", IsFilterable: true, IsGroupable: true, AllowedValues: []string{"active", "pending", "completed", "cancelled"}},
+			{Name: "methodology", DisplayName: "Methodology", DataType: "string", IsFilterable: true, IsGroupable: true},
+			{Name: "region", DisplayName: "Region", DataType: "string", IsFilterable: true, IsGroupable: true},
+			{Name: "total_area_hectares", DisplayName: "Total Area (ha)", DataType: "number", IsAggregatable: true},
+			{Name: "estimated_credits", DisplayName: "Estimated Credits", DataType: "number", IsAggregatable: true},
+			{Name: "created_at", DisplayName: "Created Date", DataType: "date", IsFilterable: true, IsGroupable: true},
 		},
-		{
-			Name:        "carbon_credits",
-			DisplayName: "Carbon Credits",
-			Description: "Issued and traded carbon credits",
-			Fields: []FieldMetadata{
-				{Name: "id", DisplayName: "Credit ID", DataType: "string", IsFilterable: true},
-				{Name: "project_id", DisplayName: "Project ID", DataType: "string", IsFilterable: true, IsGroupable: true},
-				{Name: "quantity", DisplayName: "Quantity", DataType: "number", IsAggregatable: true},
-				{Name: "vintage_year", DisplayName: "Vintage Year", DataType: "number", IsFilterable: true, IsGroupable: true},
-				{Name: "status", DisplayName: "Status", DataType: "string", IsFilterable: true, IsGroupable: true, AllowedValues: []string{"issued", "retired", "transferred", "pending"}},
-				{Name: "price_per_credit", DisplayName: "Price per Credit", DataType: "number", IsAggregatable: true},
-				{Name: "issued_at", DisplayName: "Issued Date", DataType: "date", IsFilterable: true, IsGroupable: true},
-			},
-			JoinWith: []string{"projects", "transactions"},
+		JoinWith: []string{"carbon_credits", "monitoring_data"},
+		JoinKeys: joinKeysOf("projects"),
+	},
+	{
+		Name:        "carbon_credits",
+		DisplayName: "Carbon Credits",
+		Description: "Issued and traded carbon credits",
+		Fields: []FieldMetadata{
+			{Name: "id", DisplayName: "Credit ID", DataType: "string", IsFilterable: true},
+			{Name: "project_id", DisplayName: "Project ID", DataType: "string", IsFilterable: true, IsGroupable: true},
+			{Name: "quantity", DisplayName: "Quantity", DataType: "number", IsAggregatable: true},
+			{Name: "vintage_year", DisplayName: "Vintage Year", DataType: "number", IsFilterable: true, IsGroupable: true},
+			{Name: "status", DisplayName: "Status", DataType: "string", IsFilterable: true, IsGroupable: true, AllowedValues: []string{"issued", "retired", "transferred", "pending"}},
+			{Name: "price_per_credit", DisplayName: "Price per Credit", DataType: "number", IsAggregatable: true},
+			{Name: "issued_at", DisplayName: "Issued Date", DataType: "date", IsFilterable: true, IsGroupable: true},
 		},
-		{
-			Name:        "transactions",
-			DisplayName: "Transactions",
-			Description: "Financial transactions and revenue",
-			Fields: []FieldMetadata{
-				{Name: "id", DisplayName: "Transaction ID", DataType: "string", IsFilterable: true},
-				{Name: "type", DisplayName: "Type", DataType: "string", IsFilterable: true, IsGroupable: true, AllowedValues: []string{"sale", "purchase", "retirement", "transfer"}},
-				{Name: "amount", DisplayName: "Amount", DataType: "number", IsAggregatable: true},
-				{Name: "currency", DisplayName: "Currency", DataType: "string", IsFilterable: true, IsGroupable: true},
-				{Name: "status", DisplayName: "Status", DataType: "string", IsFilterable: true, IsGroupable: true},
-				{Name: "created_at", DisplayName: "Date", DataType: "date", IsFilterable: true, IsGroupable: true},
-			},
-			JoinWith: []string{"carbon_credits"},
+		JoinWith: []string{"projects", "transactions"},
+		JoinKeys: joinKeysOf("carbon_credits"),
+	},
+	{
+		Name:        "transactions",
+		DisplayName: "Transactions",
+		Description: "Financial transactions and revenue",
+		Fields: []FieldMetadata{
+			{Name: "id", DisplayName: "Transaction ID", DataType: "string", IsFilterable: true},
+			{Name: "type", DisplayName: "Type", DataType: "string", IsFilterable: true, IsGroupable: true, AllowedValues: []string{"sale", "purchase", "retirement", "transfer"}},
+			{Name: "amount", DisplayName: "Amount", DataType: "number", IsAggregatable: true},
+			{Name: "currency", DisplayName: "Currency", DataType: "string", IsFilterable: true, IsGroupable: true},
+			{Name: "status", DisplayName: "Status", DataType: "string", IsFilterable: true, IsGroupable: true},
+			{Name: "created_at", DisplayName: "Date", DataType: "date", IsFilterable: true, IsGroupable: true},
 		},
-		{
-			Name:        "monitoring_data",
-			DisplayName: "Monitoring Data",
-			Description: "Environmental monitoring measurements",
-			Fields: []FieldMetadata{
-				{Name: "id", DisplayName: "Reading ID", DataType: "string", IsFilterable: true},
-				{Name: "project_id", DisplayName: "Project ID", DataType: "string", IsFilterable: true, IsGroupable: true},
-				{Name: "metric_type", DisplayName: "Metric Type", DataType: "string", IsFilterable: true, IsGroupable: true},
-				{Name: "value", DisplayName: "Value", DataType: "number", IsAggregatable: true},
-				{Name: "unit", DisplayName: "Unit", DataType: "string", IsFilterable: true},
-				{Name: "recorded_at", DisplayName: "Recorded Date", DataType: "date", IsFilterable: true, IsGroupable: true},
-			},
-			JoinWith: []string{"projects"},
+		JoinWith: []string{"carbon_credits"},
+		JoinKeys: joinKeysOf("transactions"),
+	},
+	{
+		Name:        "monitoring_data",
+		DisplayName: "Monitoring Data",
+		Description: "Environmental monitoring measurements",
+		Fields: []FieldMetadata{
+			{Name: "id", DisplayName: "Reading ID", DataType: "string", IsFilterable: true},
+			{Name: "project_id", DisplayName: "Project ID", DataType: "string", IsFilterable: true, IsGroupable: true},
+			{Name: "metric_type", DisplayName: "Metric Type", DataType: "string", IsFilterable: true, IsGroupable: true},
+			{Name: "value", DisplayName: "Value", DataType: "number", IsAggregatable: true},
+			{Name: "unit", DisplayName: "Unit", DataType: "string", IsFilterable: true},
+			{Name: "recorded_at", DisplayName: "Recorded Date", DataType: "date", IsFilterable: true, IsGroupable: true},
 		},
-	}, nil
+		JoinWith: []string{"projects"},
+		JoinKeys: joinKeysOf("monitoring_data"),
+	},
+}
+
+// knownDataset reports whether name is one of reportDatasets
+func knownDataset(name string) bool {
+	for _, dataset := range reportDatasets {
+		if dataset.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 // ========== Helper Functions ==========
 
-func (s *service) canAccessReport(report *ReportDefinition, userID uuid.UUID) bool {
-	// Public reports are accessible to everyone
+// ErrAccessDenied is returned when the caller may not read or modify a resource
+var ErrAccessDenied = apperrors.Forbidden("access denied")
+
+// queryProgressPercent converts rows read into a percentage, reserving 100 for completion
+func queryProgressPercent(rowsProcessed, total int64) int {
+	if total <= 0 {
+		return 0
+	}
+	percent := int(rowsProcessed * 100 / total)
+	if percent > 99 {
+		percent = 99
+	}
+	return percent
+}
+
+func containsEmail(emails []string, email string) bool {
+	for _, candidate := range emails {
+		if strings.EqualFold(candidate, email) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsUUID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *service) canAccessReport(ctx context.Context, report *ReportDefinition, userID uuid.UUID) bool {
+	// Reports never leak across organizations, whatever their visibility
+	if !TenantFromContext(ctx).canSee(report.OrganizationID) {
+		return false
+	}
+
+	// Public reports are accessible to everyone in the organization
 	if report.Visibility == VisibilityPublic {
 		return true
 	}
@@ -747,7 +1691,11 @@ func (s *service) canAccessReport(report *ReportDefinition, userID uuid.UUID) bo
 	return false
 }
 
-func (s *service) canModifyReport(report *ReportDefinition, userID uuid.UUID) bool {
+func (s *service) canModifyReport(ctx context.Context, report *ReportDefinition, userID uuid.UUID) bool {
+	if !TenantFromContext(ctx).canSee(report.OrganizationID) {
+		return false
+	}
+
 	// Only owner can modify
 	return report.CreatedBy != nil && *report.CreatedBy == userID
 }
@@ -764,22 +1712,165 @@ func (s *service) getProjectMetrics(ctx context.Context, projectID uuid.UUID) ma
 	}
 }
 
+// Upper bounds on report config lists, so one definition cannot produce an unbounded query
+const (
+	MaxReportFields       = 200
+	MaxReportFilters      = 50
+	MaxReportGroupings    = 10
+	MaxReportSorts        = 10
+	MaxReportJoins        = 3
+	MaxReportCalculations = 50
+)
+
 func validateReportConfig(config ReportConfig) error {
 	if config.Dataset == "" {
-		return fmt.Errorf("dataset is required")
+		return apperrors.Field("dataset", "is required")
+	}
+	if !knownDataset(config.Dataset) {
+		return apperrors.Field("dataset", fmt.Sprintf("unknown dataset %q", config.Dataset))
 	}
 	if len(config.Fields) == 0 {
-		return fmt.Errorf("at least one field is required")
+		return apperrors.Validation("at least one field is required", apperrors.FieldError{Field: "fields", Message: "is required"})
+	}
+
+	visible := false
+	for _, field := range config.Fields {
+		if !field.IsHidden {
+			visible = true
+			break
+		}
+	}
+	if !visible {
+		return apperrors.Field("fields", "at least one field must be visible")
+	}
+
+	limits := []struct {
+		field string
+		count int
+		max   int
+	}{
+		{"fields", len(config.Fields), MaxReportFields},
+		{"joins", len(config.Joins), MaxReportJoins},
+		{"filters", len(config.Filters), MaxReportFilters},
+		{"groupings", len(config.Groupings), MaxReportGroupings},
+		{"sorts", len(config.Sorts), MaxReportSorts},
+		{"calculations", len(config.Calculations), MaxReportCalculations},
+	}
+	for _, l := range limits {
+		if l.count > l.max {
+			return apperrors.Field(l.field, fmt.Sprintf("must have at most %d entries, got %d", l.max, l.count))
+		}
+	}
+
+	// Names and aggregates are written into the query as they are, so only plain
+	// identifiers and the known aggregate functions are stored
+	for i, field := range config.Fields {
+		path := fmt.Sprintf("fields[%d]", i)
+		if !columnPattern.MatchString(field.Name) {
+			return apperrors.Field(path+".name", fmt.Sprintf("%q is not a valid column name", field.Name))
+		}
+		if field.Alias != "" && !namePattern.MatchString(field.Alias) {
+			return apperrors.Field(path+".alias", fmt.Sprintf("alias %q must be a plain identifier", field.Alias))
+		}
+		if field.Aggregate != "" {
+			if _, err := sqlAggregate(field.Aggregate); err != nil {
+				return apperrors.Field(path+".aggregate", err.Error())
+			}
+		}
+	}
+	for i, calc := range config.Calculations {
+		if !namePattern.MatchString(calc.Name) {
+			return apperrors.Field(fmt.Sprintf("calculations[%d].name", i), fmt.Sprintf("calculation name %q must be a plain identifier", calc.Name))
+		}
+	}
+	var columns []struct{ path, name string }
+	for i, filter := range config.Filters {
+		columns = append(columns, struct{ path, name string }{fmt.Sprintf("filters[%d].field", i), filter.Field})
+	}
+	for i, group := range config.Groupings {
+		columns = append(columns, struct{ path, name string }{fmt.Sprintf("groupings[%d].field", i), group.Field})
+	}
+	for i, sort := range config.Sorts {
+		columns = append(columns, struct{ path, name string }{fmt.Sprintf("sorts[%d].field", i), sort.Field})
+	}
+	for _, column := range columns {
+		if !columnPattern.MatchString(column.name) {
+			return apperrors.Field(column.path, fmt.Sprintf("%q is not a valid column name", column.name))
+		}
+	}
+
+	// Time grains are spliced into date_trunc, so only the known ones are stored
+	for i, group := range config.Groupings {
+		if group.TimeGrain != "" && !timeGrains[group.TimeGrain] {
+			return apperrors.Field(fmt.Sprintf("groupings[%d].time_grain", i), fmt.Sprintf("unknown time grain %q", group.TimeGrain))
+		}
+	}
+
+	// Joins without a declared key could pair every row with every other
+	if problems := checkJoins(config.Dataset, config.Joins); len(problems) > 0 {
+		return apperrors.Field(problems[0].Path, problems[0].Message)
+	}
+
+	// Expressions end up in SQL, so one that does not parse is never stored
+	for i, calc := range config.Calculations {
+		if _, err := parseExpression(calc.Expression); err != nil {
+			return apperrors.Field(fmt.Sprintf("calculations[%d].expression", i), err.Error())
+		}
 	}
 	return nil
 }
 
 func validateCronExpression(expr string) error {
-	// Basic validation - in production, use a proper cron parser
 	if expr == "" {
-		return fmt.Errorf("cron expression is required")
+		return apperrors.Field("cron_expression", "is required")
 	}
-	return nil
+	_, err := cron.ParseStandard(expr)
+	return err
+}
+
+// describeNextRun fills in NextRunAt (UTC) and NextRunLocal (the schedule's timezone).
+// A stored NextRunAt that is still in the future is kept; otherwise it is derived from the cron expression.
+func describeNextRun(schedule *ReportSchedule, now time.Time) {
+	loc, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return
+	}
+
+	if schedule.NextRunAt == nil || schedule.NextRunAt.Before(now) {
+		if !schedule.IsActive {
+			return
+		}
+		nextRun, err := nextScheduleRun(schedule.CronExpression, schedule.Timezone, now)
+		if err != nil {
+			return
+		}
+		schedule.NextRunAt = &nextRun
+	}
+
+	utc := schedule.NextRunAt.UTC()
+	schedule.NextRunAt = &utc
+	schedule.NextRunLocal = utc.In(loc).Format(nextRunLocalFormat)
+}
+
+// nextRunLocalFormat renders a next run time with its zone abbreviation, e.g. "Mon, 09 Mar 2026 09:00 EDT"
+const nextRunLocalFormat = "Mon, 02 Jan 2006 15:04 MST"
+
+// nextScheduleRun returns the first run of a cron expression after the given time,
+// evaluated in the schedule's timezone and returned in UTC
+func nextScheduleRun(expr, timezone string, after time.Time) (time.Time, error) {
+	cronSchedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return time.Time{}, apperrors.Wrap(apperrors.ErrValidation, "invalid cron expression", err)
+	}
+
+	loc := time.UTC
+	if timezone != "" {
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return time.Time{}, apperrors.Wrap(apperrors.ErrValidation, fmt.Sprintf("invalid timezone %q", timezone), err)
+		}
+	}
+
+	return cronSchedule.Next(after.In(loc)).UTC(), nil
 }
 
 func calculatePercentileRank(value, median, lowerBound, upperBound float64) float64 {
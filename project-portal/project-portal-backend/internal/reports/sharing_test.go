@@ -0,0 +1,129 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"carbon-scribe/project-portal/project-portal-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func newSharingTestRouter(repo *fakeRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	NewHandler(NewService(repo, nil)).RegisterRoutes(router.Group("/api/v1"), testAuth())
+	return router
+}
+
+// testAuth stands in for the JWT middleware, taking the claims from test headers
+func testAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for header, key := range map[string]string{"X-User-ID": "user_id", "X-Test-Role": "role", "X-Test-Org": "organization_id", "X-Test-Email": "email"} {
+			if value := c.GetHeader(header); value != "" {
+				c.Set(key, value)
+			}
+		}
+		c.Next()
+	}
+}
+
+func doAs(router *gin.Engine, userID uuid.UUID, method, path string, body interface{}) *httptest.ResponseRecorder {
+	var payload bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&payload).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", userID.String())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSharing_SharedUserCanReadUntilRemoved(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+
+	owner, reader := uuid.New(), uuid.New()
+	repo.users[reader] = true
+
+	report, err := NewService(repo, nil).CreateReport(context.Background(), owner, CreateReportRequest{
+		Name:   "Private report",
+		Config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+	reportPath := "/api/v1/reports/" + report.ID.String()
+
+	if w := doAs(router, reader, http.MethodGet, reportPath, nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 before sharing, got %d", w.Code)
+	}
+
+	w := doAs(router, owner, http.MethodPost, reportPath+"/share", ShareReportRequest{UserIDs: []uuid.UUID{reader}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("share failed: %d %s", w.Code, w.Body.String())
+	}
+	var shared ShareReportResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &shared); err != nil {
+		t.Fatalf("invalid share response: %v", err)
+	}
+	if len(shared.SharedWithUsers) != 1 || shared.SharedWithUsers[0] != reader {
+		t.Errorf("expected share list [%s], got %v", reader, shared.SharedWithUsers)
+	}
+
+	if w := doAs(router, reader, http.MethodGet, reportPath, nil); w.Code != http.StatusOK {
+		t.Fatalf("expected shared user to read report, got %d", w.Code)
+	}
+
+	w = doAs(router, owner, http.MethodPost, reportPath+"/unshare", ShareReportRequest{UserIDs: []uuid.UUID{reader}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("unshare failed: %d %s", w.Code, w.Body.String())
+	}
+
+	if w := doAs(router, reader, http.MethodGet, reportPath, nil); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 after unsharing, got %d", w.Code)
+	}
+}
+
+func TestSharing_OwnerOnlyAndUnknownUsers(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+
+	owner, other := uuid.New(), uuid.New()
+	repo.users[other] = true
+
+	report, _ := NewService(repo, nil).CreateReport(context.Background(), owner, CreateReportRequest{
+		Name:       "Public report",
+		Config:     ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+		Visibility: VisibilityPublic,
+	})
+	reportPath := "/api/v1/reports/" + report.ID.String()
+
+	if w := doAs(router, other, http.MethodPost, reportPath+"/share", ShareReportRequest{UserIDs: []uuid.UUID{other}}); w.Code != http.StatusForbidden {
+		t.Errorf("expected non-owner share to be forbidden, got %d", w.Code)
+	}
+	if w := doAs(router, other, http.MethodPut, reportPath+"/visibility", SetVisibilityRequest{Visibility: VisibilityPrivate}); w.Code != http.StatusForbidden {
+		t.Errorf("expected non-owner visibility change to be forbidden, got %d", w.Code)
+	}
+	if w := doAs(router, owner, http.MethodPost, reportPath+"/share", ShareReportRequest{UserIDs: []uuid.UUID{uuid.New()}}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected unknown user to be rejected, got %d", w.Code)
+	}
+	if w := doAs(router, owner, http.MethodPut, reportPath+"/visibility", SetVisibilityRequest{Visibility: "everyone"}); w.Code != http.StatusBadRequest {
+		t.Errorf("expected invalid visibility to be rejected, got %d", w.Code)
+	}
+
+	if w := doAs(router, owner, http.MethodPut, reportPath+"/visibility", SetVisibilityRequest{Visibility: VisibilityPrivate}); w.Code != http.StatusOK {
+		t.Fatalf("visibility change failed: %d %s", w.Code, w.Body.String())
+	}
+	if w := doAs(router, other, http.MethodGet, reportPath, nil); w.Code != http.StatusForbidden {
+		t.Errorf("expected private report to be hidden, got %d", w.Code)
+	}
+}
@@ -0,0 +1,158 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/google/uuid"
+)
+
+func createSubscriptionFixture(t *testing.T, repo *fakeRepository, owner uuid.UUID, method DeliveryMethod) (*ReportDefinition, *ReportSchedule) {
+	t.Helper()
+	svc := NewService(repo, nil)
+
+	report, err := svc.CreateReport(context.Background(), owner, CreateReportRequest{
+		Name:   "Private report",
+		Config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	schedule, err := svc.CreateSchedule(context.Background(), owner, CreateScheduleRequest{
+		ReportDefinitionID: report.ID,
+		Name:               "Weekly",
+		CronExpression:     "0 8 * * 1",
+		Format:             FormatCSV,
+		DeliveryMethod:     method,
+		DeliveryConfig:     map[string]any{},
+		RecipientEmails:    []string{"owner@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("CreateSchedule failed: %v", err)
+	}
+	return report, schedule
+}
+
+// subscribeAs posts to a schedule's subscribe or unsubscribe endpoint as userID,
+// whose token carries email
+func subscribeAs(router *gin.Engine, userID uuid.UUID, email, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", userID.String())
+	if email != "" {
+		req.Header.Set("X-Test-Email", email)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSubscription_UnauthorizedUserCannotSubscribeToPrivateReport(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+	owner, stranger := uuid.New(), uuid.New()
+	_, schedule := createSubscriptionFixture(t, repo, owner, DeliveryEmail)
+
+	w := subscribeAs(router, stranger, "stranger@example.com", "/api/v1/reports/schedules/"+schedule.ID.String()+"/subscribe", "")
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d %s", w.Code, w.Body.String())
+	}
+
+	stored, _ := repo.GetSchedule(context.Background(), schedule.ID)
+	if containsUUID(stored.RecipientUserIDs, stranger) || containsEmail(stored.RecipientEmails, "stranger@example.com") {
+		t.Errorf("unauthorized user was added to recipients: %+v", stored)
+	}
+}
+
+func TestSubscription_SharedUserSubscribesAndUnsubscribes(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+	owner, reader := uuid.New(), uuid.New()
+	repo.users[reader] = true
+	report, schedule := createSubscriptionFixture(t, repo, owner, DeliveryEmail)
+
+	if _, err := NewService(repo, nil).ShareReport(context.Background(), owner, report.ID, []uuid.UUID{reader}); err != nil {
+		t.Fatalf("ShareReport failed: %v", err)
+	}
+
+	path := "/api/v1/reports/schedules/" + schedule.ID.String()
+	w := subscribeAs(router, reader, "reader@example.com", path+"/subscribe", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("subscribe failed: %d %s", w.Code, w.Body.String())
+	}
+	// Subscribing twice is a no-op
+	subscribeAs(router, reader, "READER@example.com", path+"/subscribe", "")
+
+	stored, _ := repo.GetSchedule(context.Background(), schedule.ID)
+	if len(stored.RecipientUserIDs) != 1 || stored.RecipientUserIDs[0] != reader {
+		t.Errorf("expected reader as the only user recipient, got %v", stored.RecipientUserIDs)
+	}
+	if len(stored.RecipientEmails) != 2 || !containsEmail(stored.RecipientEmails, "reader@example.com") {
+		t.Errorf("expected owner and reader emails, got %v", stored.RecipientEmails)
+	}
+
+	// Losing access must not trap the user on the list
+	if _, err := NewService(repo, nil).UnshareReport(context.Background(), owner, report.ID, []uuid.UUID{reader}); err != nil {
+		t.Fatalf("UnshareReport failed: %v", err)
+	}
+	w = subscribeAs(router, reader, "reader@example.com", path+"/unsubscribe", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("unsubscribe failed: %d %s", w.Code, w.Body.String())
+	}
+
+	stored, _ = repo.GetSchedule(context.Background(), schedule.ID)
+	if len(stored.RecipientUserIDs) != 0 || len(stored.RecipientEmails) != 1 || stored.RecipientEmails[0] != "owner@example.com" {
+		t.Errorf("expected only the owner's email to remain, got %v %v", stored.RecipientUserIDs, stored.RecipientEmails)
+	}
+}
+
+func TestSubscription_RejectsNonEmailSchedules(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+	owner := uuid.New()
+	_, webhook := createSubscriptionFixture(t, repo, owner, DeliveryWebhook)
+
+	w := subscribeAs(router, owner, "owner@example.com", "/api/v1/reports/schedules/"+webhook.ID.String()+"/subscribe", "")
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a webhook schedule, got %d", w.Code)
+	}
+}
+
+func TestSubscription_OnlyTheCallersOwnAddressChanges(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+	owner, reader, stranger := uuid.New(), uuid.New(), uuid.New()
+	repo.users[reader] = true
+	report, schedule := createSubscriptionFixture(t, repo, owner, DeliveryEmail)
+	if _, err := NewService(repo, nil).ShareReport(context.Background(), owner, report.ID, []uuid.UUID{reader}); err != nil {
+		t.Fatalf("ShareReport failed: %v", err)
+	}
+	path := "/api/v1/reports/schedules/" + schedule.ID.String()
+
+	// An address in the body is not the caller's to add or remove
+	if w := subscribeAs(router, reader, "reader@example.com", path+"/subscribe", `{"email":"outsider@example.com"}`); w.Code != http.StatusOK {
+		t.Fatalf("subscribe failed: %d %s", w.Code, w.Body.String())
+	}
+	if w := subscribeAs(router, reader, "reader@example.com", path+"/unsubscribe", `{"email":"owner@example.com"}`); w.Code != http.StatusOK {
+		t.Fatalf("unsubscribe failed: %d %s", w.Code, w.Body.String())
+	}
+	stored, _ := repo.GetSchedule(context.Background(), schedule.ID)
+	if containsEmail(stored.RecipientEmails, "outsider@example.com") || !containsEmail(stored.RecipientEmails, "owner@example.com") {
+		t.Errorf("expected only the reader's own address to change, got %v", stored.RecipientEmails)
+	}
+
+	// Someone who is neither a recipient nor able to read the report cannot touch the list
+	if w := subscribeAs(router, stranger, "stranger@example.com", path+"/unsubscribe", ""); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a stranger, got %d", w.Code)
+	}
+	stored, _ = repo.GetSchedule(context.Background(), schedule.ID)
+	if !containsEmail(stored.RecipientEmails, "owner@example.com") {
+		t.Errorf("a stranger removed the owner's address: %v", stored.RecipientEmails)
+	}
+}
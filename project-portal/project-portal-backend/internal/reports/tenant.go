@@ -0,0 +1,42 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RolePlatformAdmin is the only role allowed to opt into cross-organization views
+const RolePlatformAdmin = "platform_admin"
+
+// Tenant identifies the organization a request is scoped to
+type Tenant struct {
+	OrganizationID *uuid.UUID
+	CrossOrg       bool // Set only for platform admins that explicitly opt in
+}
+
+type tenantContextKey struct{}
+
+// WithTenant returns a copy of ctx carrying the given tenant
+func WithTenant(ctx context.Context, tenant Tenant) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant stored in ctx, or an unscoped zero value
+func TenantFromContext(ctx context.Context) Tenant {
+	if tenant, ok := ctx.Value(tenantContextKey{}).(Tenant); ok {
+		return tenant
+	}
+	return Tenant{}
+}
+
+// canSee reports whether data owned by organizationID is visible to the tenant
+func (t Tenant) canSee(organizationID *uuid.UUID) bool {
+	if t.CrossOrg {
+		return true
+	}
+	if t.OrganizationID == nil || organizationID == nil {
+		return t.OrganizationID == nil && organizationID == nil
+	}
+	return *t.OrganizationID == *organizationID
+}
@@ -0,0 +1,389 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestTenant_ReportsDoNotLeakAcrossOrganizations(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+
+	orgA, orgB := uuid.New(), uuid.New()
+	userA, userB := uuid.New(), uuid.New()
+	ctxA := WithTenant(context.Background(), Tenant{OrganizationID: &orgA})
+	ctxB := WithTenant(context.Background(), Tenant{OrganizationID: &orgB})
+
+	report, err := svc.CreateReport(ctxB, userB, CreateReportRequest{
+		Name:       "Org B revenue",
+		Config:     ReportConfig{Dataset: "transactions", Fields: []FieldConfig{{Name: "amount"}}},
+		Visibility: VisibilityPublic,
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+	if report.OrganizationID == nil || *report.OrganizationID != orgB {
+		t.Fatalf("expected report to be owned by org B, got %v", report.OrganizationID)
+	}
+
+	// Even a public report is invisible to another organization
+	if _, err := svc.GetReport(ctxA, userA, report.ID); err == nil {
+		t.Error("expected org A user to be denied org B report")
+	}
+	if _, err := svc.GetReport(ctxB, userA, report.ID); err != nil {
+		t.Errorf("expected public report to be visible within org B: %v", err)
+	}
+
+	listA, err := svc.ListReports(ctxA, userA, ReportFilter{})
+	if err != nil {
+		t.Fatalf("ListReports failed: %v", err)
+	}
+	if listA.Total != 0 {
+		t.Errorf("expected org A to see 0 reports, got %d", listA.Total)
+	}
+	if repo.lastReportFilter.Tenant.OrganizationID == nil || *repo.lastReportFilter.Tenant.OrganizationID != orgA {
+		t.Error("expected list filter to carry org A")
+	}
+
+	summaryA, err := svc.GetDashboardSummary(ctxA, &userA)
+	if err != nil {
+		t.Fatalf("GetDashboardSummary failed: %v", err)
+	}
+	if summaryA.TotalProjects != 0 {
+		t.Errorf("expected org A counts to exclude org B data, got %d", summaryA.TotalProjects)
+	}
+}
+
+func TestTenant_CrossOrgAdminSeesEverything(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+
+	orgB := uuid.New()
+	owner := uuid.New()
+	report, _ := svc.CreateReport(WithTenant(context.Background(), Tenant{OrganizationID: &orgB}), owner, CreateReportRequest{
+		Name:       "Org B report",
+		Config:     ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+		Visibility: VisibilityPublic,
+	})
+
+	admin := WithTenant(context.Background(), Tenant{CrossOrg: true})
+	if _, err := svc.GetReport(admin, uuid.New(), report.ID); err != nil {
+		t.Errorf("expected cross-org admin to read report: %v", err)
+	}
+}
+
+func TestScopeToTenant_SQL(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("failed to open dry-run db: %v", err)
+	}
+
+	orgA := uuid.New()
+	tests := []struct {
+		name     string
+		tenant   Tenant
+		contains string
+		absent   string
+	}{
+		{"scoped", Tenant{OrganizationID: &orgA}, "projects.organization_id = ", ""},
+		{"no organization", Tenant{}, "projects.organization_id IS NULL", ""},
+		{"cross org", Tenant{CrossOrg: true}, "SELECT count(*) FROM \"projects\"", "organization_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				var count int64
+				return scopeToTenant(tx.Table("projects"), tt.tenant, "projects.organization_id").Count(&count)
+			})
+			if !strings.Contains(sql, tt.contains) {
+				t.Errorf("expected %q in %q", tt.contains, sql)
+			}
+			if tt.absent != "" && strings.Contains(sql, tt.absent) {
+				t.Errorf("did not expect %q in %q", tt.absent, sql)
+			}
+		})
+	}
+}
+
+func TestRequestContext_TakesTenantFromClaimsOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	org, other := uuid.New(), uuid.New()
+
+	tenantOf := func(target string, claims map[string]any) Tenant {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		c.Request.Header.Set("X-Organization-ID", other.String())
+		for key, value := range claims {
+			c.Set(key, value)
+		}
+		return TenantFromContext(requestContext(c))
+	}
+
+	if tenant := tenantOf("/", nil); tenant.OrganizationID != nil {
+		t.Errorf("the organization header must be ignored, got %s", tenant.OrganizationID)
+	}
+	if tenant := tenantOf("/", map[string]any{"organization_id": org.String()}); tenant.OrganizationID == nil || *tenant.OrganizationID != org {
+		t.Errorf("expected the organization of the claims, got %v", tenant.OrganizationID)
+	}
+	if tenant := tenantOf("/?all_orgs=true", map[string]any{"role": "analyst"}); tenant.CrossOrg {
+		t.Error("only platform admins may opt into cross-org views")
+	}
+	if tenant := tenantOf("/?all_orgs=true", map[string]any{"role": RolePlatformAdmin}); !tenant.CrossOrg {
+		t.Error("expected a platform admin to opt into cross-org views")
+	}
+}
+
+func TestGetDashboardSummary_ReturnsQueryErrors(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("failed to open dry-run db: %v", err)
+	}
+	// Stands in for a source table or column the database does not have
+	missing := errors.New(`column projects.organization_id does not exist`)
+	db.Callback().Query().Before("gorm:query").Register("test:fail", func(tx *gorm.DB) {
+		tx.AddError(missing)
+	})
+
+	org := uuid.New()
+	summary, err := NewRepository(db).GetDashboardSummary(context.Background(), nil, Tenant{OrganizationID: &org})
+	if !errors.Is(err, missing) {
+		t.Fatalf("expected the query error instead of zero counts, got %v (%+v)", err, summary)
+	}
+}
+
+func TestTenant_SchedulesAndExecutionsDoNotLeakAcrossOrganizations(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+
+	orgA, orgB := uuid.New(), uuid.New()
+	userA, ownerB, readerB := uuid.New(), uuid.New(), uuid.New()
+	ctxA := WithTenant(context.Background(), Tenant{OrganizationID: &orgA})
+	ctxB := WithTenant(context.Background(), Tenant{OrganizationID: &orgB})
+
+	report, err := svc.CreateReport(ctxB, ownerB, CreateReportRequest{
+		Name:       "Org B credits",
+		Config:     ReportConfig{Dataset: "carbon_credits", Fields: []FieldConfig{{Name: "quantity"}}},
+		Visibility: VisibilityPublic,
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+	scheduleReq := CreateScheduleRequest{
+		ReportDefinitionID: report.ID,
+		Name:               "Weekly",
+		CronExpression:     "0 8 * * 1",
+		Format:             FormatCSV,
+		DeliveryMethod:     DeliveryEmail,
+		DeliveryConfig:     map[string]any{},
+		RecipientEmails:    []string{"owner@example.com"},
+	}
+	schedule, err := svc.CreateSchedule(ctxB, ownerB, scheduleReq)
+	if err != nil {
+		t.Fatalf("CreateSchedule failed: %v", err)
+	}
+
+	// Leave the execution pending so it can still be cancelled
+	reportID := report.ID
+	execution := &ReportExecution{ID: uuid.New(), ReportDefinitionID: &reportID, TriggeredBy: &ownerB, Status: StatusPending}
+	repo.CreateExecution(context.Background(), execution)
+
+	foreign := scheduleReq
+	foreign.RecipientEmails = []string{"attacker@example.com"}
+	denied := map[string]func(ctx context.Context, userID uuid.UUID) error{
+		"create schedule": func(ctx context.Context, userID uuid.UUID) error {
+			_, err := svc.CreateSchedule(ctx, userID, foreign)
+			return err
+		},
+		"update schedule": func(ctx context.Context, userID uuid.UUID) error {
+			_, err := svc.UpdateSchedule(ctx, userID, schedule.ID, foreign)
+			return err
+		},
+		"toggle schedule": func(ctx context.Context, userID uuid.UUID) error {
+			return svc.ToggleSchedule(ctx, userID, schedule.ID, false)
+		},
+		"delete schedule": func(ctx context.Context, userID uuid.UUID) error {
+			return svc.DeleteSchedule(ctx, userID, schedule.ID)
+		},
+		"cancel execution": func(ctx context.Context, userID uuid.UUID) error {
+			return svc.CancelExecution(ctx, userID, execution.ID)
+		},
+	}
+	for name, call := range denied {
+		if err := call(ctxA, userA); err == nil {
+			t.Errorf("%s: expected org A user to be denied", name)
+		}
+		// Reading a public report does not make another org B user its owner
+		if err := call(ctxB, readerB); !errors.Is(err, ErrAccessDenied) {
+			t.Errorf("%s: expected access denied for a non-owner in org B, got %v", name, err)
+		}
+	}
+	if _, err := svc.GetSchedule(ctxA, userA, schedule.ID); err == nil {
+		t.Error("expected org A user not to read org B schedule")
+	}
+	if _, err := svc.GetExecution(ctxA, userA, execution.ID); err == nil {
+		t.Error("expected org A user not to read org B execution")
+	}
+
+	stored, _ := repo.GetSchedule(context.Background(), schedule.ID)
+	if !stored.IsActive || len(stored.RecipientEmails) != 1 || stored.RecipientEmails[0] != "owner@example.com" {
+		t.Errorf("expected the schedule to be untouched, got %+v", stored)
+	}
+	if stored, _ := repo.GetExecution(context.Background(), execution.ID); stored.Status != StatusPending {
+		t.Errorf("expected the execution to keep running, got %s", stored.Status)
+	}
+
+	// A public report's schedule and executions are readable within org B
+	if _, err := svc.GetSchedule(ctxB, readerB, schedule.ID); err != nil {
+		t.Errorf("expected org B reader to see the schedule: %v", err)
+	}
+	if _, err := svc.GetExecution(ctxB, readerB, execution.ID); err != nil {
+		t.Errorf("expected org B reader to see the execution: %v", err)
+	}
+	if err := svc.CancelExecution(ctxB, ownerB, execution.ID); err != nil {
+		t.Errorf("expected the owner to cancel the execution: %v", err)
+	}
+}
+
+func TestTenant_OrphanedSchedulesAndExecutionsAreHidden(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+
+	owner := uuid.New()
+	report, _ := svc.CreateReport(context.Background(), owner, CreateReportRequest{
+		Name:   "Soon gone",
+		Config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}}},
+	})
+	schedule, err := svc.CreateSchedule(context.Background(), owner, CreateScheduleRequest{
+		ReportDefinitionID: report.ID,
+		Name:               "Daily",
+		CronExpression:     "0 8 * * *",
+		Format:             FormatCSV,
+		DeliveryMethod:     DeliveryEmail,
+		DeliveryConfig:     map[string]any{},
+	})
+	if err != nil {
+		t.Fatalf("CreateSchedule failed: %v", err)
+	}
+	reportID := report.ID
+	execution := &ReportExecution{ID: uuid.New(), ReportDefinitionID: &reportID, TriggeredBy: &owner, Status: StatusCompleted}
+	repo.CreateExecution(context.Background(), execution)
+
+	// Without the report there is no organization to check against, so nothing is shown
+	delete(repo.reports, report.ID)
+	if _, err := svc.GetSchedule(context.Background(), owner, schedule.ID); err == nil {
+		t.Error("expected a schedule without its report to be hidden")
+	}
+	if _, err := svc.GetExecution(context.Background(), owner, execution.ID); err == nil {
+		t.Error("expected an execution without its report to be hidden")
+	}
+}
+
+func TestTenant_TimeSeriesDoesNotLeakAcrossOrganizations(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+
+	orgA, orgB := uuid.New(), uuid.New()
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo.timeSeries = map[uuid.UUID][]TimeSeriesPoint{
+		orgA: {{Time: day, Value: 10}},
+		orgB: {{Time: day, Value: 500}},
+	}
+
+	ctxA := WithTenant(context.Background(), Tenant{OrganizationID: &orgA})
+	points, err := svc.GetTimeSeriesData(ctxA, "credits", day, day.AddDate(0, 0, 7), "day")
+	if err != nil {
+		t.Fatalf("GetTimeSeriesData failed: %v", err)
+	}
+	if len(points) != 1 || points[0].Value != 10 {
+		t.Errorf("expected only org A's point, got %+v", points)
+	}
+}
+
+func TestGetTimeSeriesData_ScopesToTenant(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("failed to open dry-run db: %v", err)
+	}
+	var sql string
+	db.Callback().Row().After("gorm:row").Register("test:capture", func(tx *gorm.DB) {
+		sql = tx.Statement.SQL.String()
+	})
+
+	org := uuid.New()
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	_, _ = NewRepository(db).GetTimeSeriesData(context.Background(), "revenue", start, start.AddDate(0, 1, 0), "week", Tenant{OrganizationID: &org})
+
+	for _, want := range []string{"FROM \"transactions\"", "transactions.organization_id = ", "date_trunc('week', created_at)"} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("expected %q in %q", want, sql)
+		}
+	}
+}
+
+func TestTenant_WidgetsStayWithTheirOwner(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+
+	orgA, orgB := uuid.New(), uuid.New()
+	userA, userB := uuid.New(), uuid.New()
+	ctxA := WithTenant(context.Background(), Tenant{OrganizationID: &orgA})
+	ctxB := WithTenant(context.Background(), Tenant{OrganizationID: &orgB})
+
+	widgetA, err := svc.SaveWidget(ctxA, userA, &DashboardWidget{Title: "A credits", WidgetType: WidgetChart, DashboardSection: "overview"})
+	if err != nil {
+		t.Fatalf("SaveWidget failed: %v", err)
+	}
+	if _, err := svc.SaveWidget(ctxB, userB, &DashboardWidget{Title: "B credits", WidgetType: WidgetChart, DashboardSection: "overview"}); err != nil {
+		t.Fatalf("SaveWidget failed: %v", err)
+	}
+	if widgetA.OrganizationID == nil || *widgetA.OrganizationID != orgA {
+		t.Fatalf("expected widget to be owned by org A, got %v", widgetA.OrganizationID)
+	}
+
+	for _, section := range []string{"overview", ""} {
+		widgets, err := svc.GetWidgets(ctxB, userB, section)
+		if err != nil {
+			t.Fatalf("GetWidgets failed: %v", err)
+		}
+		if len(widgets) != 1 || widgets[0].Title != "B credits" {
+			t.Errorf("section %q: expected only user B's widget, got %+v", section, widgets)
+		}
+	}
+
+	// Another user cannot overwrite or delete the widget, even by claiming its owner
+	_, err = svc.SaveWidget(ctxB, userB, &DashboardWidget{ID: widgetA.ID, UserID: &userA, Title: "hijacked", WidgetType: WidgetChart})
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("expected update of another user's widget to be not found, got %v", err)
+	}
+	if err := svc.DeleteWidget(ctxB, userB, widgetA.ID); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("expected delete of another user's widget to be not found, got %v", err)
+	}
+	if stored := repo.widgets[widgetA.ID]; stored == nil || stored.Title != "A credits" {
+		t.Fatalf("expected widget A untouched, got %+v", stored)
+	}
+
+	// The owner can, and the owner and organization survive the update
+	updated, err := svc.SaveWidget(ctxA, userA, &DashboardWidget{ID: widgetA.ID, UserID: &userB, Title: "A revenue", WidgetType: WidgetChart})
+	if err != nil {
+		t.Fatalf("owner update failed: %v", err)
+	}
+	if *updated.UserID != userA || *updated.OrganizationID != orgA {
+		t.Errorf("expected owner and organization to be kept, got %v %v", updated.UserID, updated.OrganizationID)
+	}
+	if err := svc.DeleteWidget(ctxA, userA, widgetA.ID); err != nil {
+		t.Errorf("owner delete failed: %v", err)
+	}
+}
@@ -0,0 +1,338 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+)
+
+// Problem codes returned by ValidateReportConfig
+const (
+	ProblemInvalidConfig        = "invalid_config"
+	ProblemMissingDataset       = "missing_dataset"
+	ProblemUnknownDataset       = "unknown_dataset"
+	ProblemInvalidIdentifier    = "invalid_identifier"
+	ProblemUnknownField         = "unknown_field"
+	ProblemIllegalJoin          = "illegal_join"
+	ProblemMissingJoinCondition = "missing_join_condition"
+	ProblemUnknownAggregate     = "unknown_aggregate"
+	ProblemIllegalAggregate     = "illegal_aggregate"
+	ProblemMissingGrouping      = "missing_grouping"
+	ProblemNotFilterable        = "not_filterable"
+	ProblemUnknownOperator      = "unknown_operator"
+	ProblemInvalidValue         = "invalid_value"
+	ProblemNotGroupable         = "not_groupable"
+	ProblemInvalidTimeGrain     = "invalid_time_grain"
+	ProblemInvalidSort          = "invalid_sort"
+	ProblemInvalidExpression    = "invalid_expression"
+	ProblemInvalidLimit         = "invalid_limit"
+)
+
+// ConfigProblem is one issue found in a report config
+type ConfigProblem struct {
+	Path    string `json:"path"` // JSON path into the config, e.g. fields[2].aggregate
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidateConfigResponse is the result of a dry-run validation
+type ValidateConfigResponse struct {
+	Valid    bool            `json:"valid"`
+	Problems []ConfigProblem `json:"problems"`
+}
+
+var (
+	columnPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+	namePattern   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	filterOperators = map[string]bool{
+		"eq": true, "ne": true, "gt": true, "gte": true, "lt": true, "lte": true,
+		"like": true, "in": true, "between": true, "is_null": true, "is_not_null": true,
+	}
+	timeGrains = map[string]bool{"hour": true, "day": true, "week": true, "month": true, "quarter": true, "year": true}
+)
+
+// ValidateReportConfig checks a config against the dataset metadata without executing it
+func (s *service) ValidateReportConfig(ctx context.Context, config ReportConfig) (*ValidateConfigResponse, error) {
+	datasets, err := s.GetAvailableDatasets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load datasets: %w", err)
+	}
+
+	problems := checkReportConfig(config, datasets)
+	return &ValidateConfigResponse{Valid: len(problems) == 0, Problems: problems}, nil
+}
+
+// configChecker collects problems while walking one config
+type configChecker struct {
+	datasets map[string]DatasetMetadata
+	dataset  DatasetMetadata
+	joined   map[string]bool
+	problems []ConfigProblem
+}
+
+func (c *configChecker) add(path, code, format string, args ...any) {
+	c.problems = append(c.problems, ConfigProblem{Path: path, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// checkReportConfig returns every problem found, rather than stopping at the first
+func checkReportConfig(config ReportConfig, datasets []DatasetMetadata) []ConfigProblem {
+	c := &configChecker{datasets: make(map[string]DatasetMetadata, len(datasets))}
+	for _, d := range datasets {
+		c.datasets[d.Name] = d
+	}
+
+	// The structural checks shared with create and update
+	if err := validateReportConfig(config); err != nil {
+		if fields := apperrors.Fields(err); len(fields) > 0 {
+			for _, f := range fields {
+				c.add(f.Field, ProblemInvalidConfig, "%s", f.Message)
+			}
+		} else {
+			c.add("", ProblemInvalidConfig, "%s", err.Error())
+		}
+	}
+
+	if config.Dataset == "" {
+		c.add("dataset", ProblemMissingDataset, "dataset is required")
+		return c.problems
+	}
+	dataset, ok := c.datasets[config.Dataset]
+	if !ok {
+		c.add("dataset", ProblemUnknownDataset, "unknown dataset %q", config.Dataset)
+		return c.problems
+	}
+	c.dataset = dataset
+
+	c.joined = map[string]bool{dataset.Name: true}
+	c.problems = append(c.problems, checkJoins(dataset.Name, config.Joins)...)
+	for _, join := range config.Joins {
+		c.joined[join.Dataset] = true
+	}
+
+	// Names that sorts and calculations may refer to besides dataset columns, and
+	// which of them hold numbers
+	outputs := make(map[string]bool)
+	numeric := make(map[string]bool)
+	aggregated := false
+	for i, field := range config.Fields {
+		path := fmt.Sprintf("fields[%d]", i)
+		meta, found := c.resolveField(path+".name", field.Name)
+
+		if field.Alias != "" {
+			if !namePattern.MatchString(field.Alias) {
+				c.add(path+".alias", ProblemInvalidIdentifier, "alias %q must be a plain identifier", field.Alias)
+			} else {
+				outputs[field.Alias] = true
+				numeric[field.Alias] = isNumericOutput(field, meta, found)
+			}
+		}
+
+		if field.Aggregate != "" {
+			aggregated = true
+			c.checkAggregate(path+".aggregate", field, meta, found)
+		}
+	}
+
+	for i, calc := range config.Calculations {
+		path := fmt.Sprintf("calculations[%d]", i)
+		if !namePattern.MatchString(calc.Name) {
+			c.add(path+".name", ProblemInvalidIdentifier, "calculation name %q must be a plain identifier", calc.Name)
+		} else {
+			outputs[calc.Name] = true
+			numeric[calc.Name] = true
+		}
+		c.checkExpression(path+".expression", calc.Expression, numeric)
+	}
+
+	for i, filter := range config.Filters {
+		c.checkFilter(fmt.Sprintf("filters[%d]", i), filter)
+	}
+
+	grouped := make(map[string]bool)
+	for i, group := range config.Groupings {
+		path := fmt.Sprintf("groupings[%d]", i)
+		meta, found := c.resolveField(path+".field", group.Field)
+		grouped[group.Field] = true
+		if !found {
+			continue
+		}
+		if !meta.IsGroupable {
+			c.add(path+".field", ProblemNotGroupable, "field %q cannot be grouped", group.Field)
+		}
+		if group.TimeGrain != "" {
+			if !timeGrains[group.TimeGrain] {
+				c.add(path+".time_grain", ProblemInvalidTimeGrain, "time grain %q is not one of hour, day, week, month, quarter, year", group.TimeGrain)
+			} else if meta.DataType != "date" {
+				c.add(path+".time_grain", ProblemInvalidTimeGrain, "time grain needs a date field, %q is %s", group.Field, meta.DataType)
+			}
+		}
+	}
+
+	// Mixing aggregates with plain columns only works when the plain columns are grouped
+	if aggregated {
+		for i, field := range config.Fields {
+			if field.Aggregate == "" && !grouped[field.Name] {
+				c.add(fmt.Sprintf("fields[%d]", i), ProblemMissingGrouping, "field %q must be grouped or aggregated", field.Name)
+			}
+		}
+	}
+
+	for i, sort := range config.Sorts {
+		path := fmt.Sprintf("sorts[%d]", i)
+		if !outputs[sort.Field] {
+			c.resolveField(path+".field", sort.Field)
+		}
+		if sort.Direction != "" && sort.Direction != "asc" && sort.Direction != "desc" {
+			c.add(path+".direction", ProblemInvalidSort, "direction must be asc or desc")
+		}
+	}
+
+	if config.Limit < 0 {
+		c.add("limit", ProblemInvalidLimit, "limit cannot be negative")
+	}
+
+	return c.problems
+}
+
+// resolveField finds a column in the report's dataset or, for a qualified name, in a joined dataset
+func (c *configChecker) resolveField(path, name string) (FieldMetadata, bool) {
+	if !columnPattern.MatchString(name) {
+		c.add(path, ProblemInvalidIdentifier, "%q is not a valid column name", name)
+		return FieldMetadata{}, false
+	}
+
+	dataset := c.dataset
+	column := name
+	if table, col, qualified := strings.Cut(name, "."); qualified {
+		column = col
+		if !c.joined[table] {
+			c.addUnjoined(path, table)
+			return FieldMetadata{}, false
+		}
+		dataset = c.datasets[table]
+	}
+
+	for _, field := range dataset.Fields {
+		if field.Name == column {
+			return field, true
+		}
+	}
+	c.add(path, ProblemUnknownField, "dataset %q has no field %q", dataset.Name, column)
+	return FieldMetadata{}, false
+}
+
+// addUnjoined reports a column of a dataset that is not joined, telling apart
+// datasets that only lack a join from those that cannot be joined at all
+func (c *configChecker) addUnjoined(path, table string) {
+	for name := range c.joined {
+		if joinable(name, table) {
+			c.add(path, ProblemMissingJoinCondition, "dataset %q is not joined; add it to joins with an on condition", table)
+			return
+		}
+	}
+	c.add(path, ProblemIllegalJoin, "dataset %q cannot be joined with %q", c.dataset.Name, table)
+}
+
+func (c *configChecker) checkAggregate(path string, field FieldConfig, meta FieldMetadata, found bool) {
+	switch strings.ToUpper(string(field.Aggregate)) {
+	case string(AggregateCount):
+		// Any column can be counted
+	case string(AggregateSum), string(AggregateAvg):
+		if found && !meta.IsAggregatable {
+			c.add(path, ProblemIllegalAggregate, "%s is not allowed on %q", field.Aggregate, field.Name)
+		}
+	case string(AggregateMin), string(AggregateMax):
+		if found && meta.DataType != "number" && meta.DataType != "date" {
+			c.add(path, ProblemIllegalAggregate, "%s needs a number or date field, %q is %s", field.Aggregate, field.Name, meta.DataType)
+		}
+	default:
+		c.add(path, ProblemUnknownAggregate, "unknown aggregate %q", field.Aggregate)
+	}
+}
+
+func (c *configChecker) checkFilter(path string, filter FilterConfig) {
+	meta, found := c.resolveField(path+".field", filter.Field)
+	if found && !meta.IsFilterable {
+		c.add(path+".field", ProblemNotFilterable, "field %q cannot be filtered", filter.Field)
+	}
+
+	if !filterOperators[filter.Operator] {
+		c.add(path+".operator", ProblemUnknownOperator, "unknown operator %q", filter.Operator)
+		return
+	}
+
+	switch filter.Operator {
+	case "is_null", "is_not_null":
+		return
+	case "in":
+		if n, ok := sliceLen(filter.Value); !ok || n == 0 {
+			c.add(path+".value", ProblemInvalidValue, "in needs a non-empty list")
+		}
+		return
+	case "between":
+		if n, ok := sliceLen(filter.Value); !ok || n != 2 {
+			c.add(path+".value", ProblemInvalidValue, "between needs a list of two values")
+		}
+		return
+	}
+
+	if filter.Value == nil {
+		c.add(path+".value", ProblemInvalidValue, "%s needs a value", filter.Operator)
+		return
+	}
+	if value, isString := filter.Value.(string); isString && found && len(meta.AllowedValues) > 0 &&
+		(filter.Operator == "eq" || filter.Operator == "ne") && !containsString(meta.AllowedValues, value) {
+		c.add(path+".value", ProblemInvalidValue, "%q is not one of %s", value, strings.Join(meta.AllowedValues, ", "))
+	}
+}
+
+// checkExpression allows arithmetic over numeric columns and earlier numeric outputs only
+func (c *configChecker) checkExpression(path, expression string, numeric map[string]bool) {
+	node, err := parseExpression(expression)
+	if err != nil {
+		c.add(path, ProblemInvalidExpression, "expression may only use numeric fields, numbers, + - * / and parentheses: %v", err)
+		return
+	}
+	for _, name := range expressionFields(node) {
+		if isNumeric, isOutput := numeric[name]; isOutput {
+			if !isNumeric {
+				c.add(path, ProblemInvalidExpression, "%q is not numeric", name)
+			}
+			continue
+		}
+		if meta, found := c.resolveField(path, name); found && meta.DataType != "number" {
+			c.add(path, ProblemInvalidExpression, "field %q is %s, not a number", name, meta.DataType)
+		}
+	}
+}
+
+// isNumericOutput reports whether a selected field yields a number
+func isNumericOutput(field FieldConfig, meta FieldMetadata, found bool) bool {
+	switch strings.ToUpper(string(field.Aggregate)) {
+	case string(AggregateCount), string(AggregateSum), string(AggregateAvg):
+		return true
+	}
+	return found && meta.DataType == "number"
+}
+
+func sliceLen(v any) (int, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return 0, false
+	}
+	return rv.Len(), true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func validateConfig(t *testing.T, config ReportConfig) *ValidateConfigResponse {
+	t.Helper()
+	response, err := NewService(newFakeRepository(), nil).ValidateReportConfig(context.Background(), config)
+	if err != nil {
+		t.Fatalf("ValidateReportConfig: %v", err)
+	}
+	return response
+}
+
+func hasProblem(problems []ConfigProblem, path, code string) bool {
+	for _, p := range problems {
+		if p.Path == path && p.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidateReportConfigAcceptsValidConfig(t *testing.T) {
+	response := validateConfig(t, ReportConfig{
+		Dataset: "carbon_credits",
+		Joins:   []JoinConfig{{Dataset: "projects", On: &JoinCondition{Left: "carbon_credits.project_id", Right: "projects.id"}}},
+		Fields: []FieldConfig{
+			{Name: "status"},
+			{Name: "quantity", Aggregate: AggregateSum, Alias: "total"},
+			{Name: "projects.region"},
+		},
+		Filters:      []FilterConfig{{Field: "vintage_year", Operator: "between", Value: []any{2020, 2025}}},
+		Groupings:    []GroupConfig{{Field: "status"}, {Field: "projects.region"}},
+		Sorts:        []SortConfig{{Field: "total", Direction: "desc"}},
+		Calculations: []CalculationConfig{{Name: "value", Expression: "total * 12.5"}},
+	})
+
+	if !response.Valid || len(response.Problems) != 0 {
+		t.Fatalf("expected a valid config, got %+v", response.Problems)
+	}
+}
+
+func TestValidateReportConfigReportsProblems(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ReportConfig
+		path   string
+		code   string
+	}{
+		{
+			name:   "missing dataset",
+			config: ReportConfig{Fields: []FieldConfig{{Name: "name"}}},
+			path:   "dataset", code: ProblemMissingDataset,
+		},
+		{
+			name:   "unknown dataset",
+			config: ReportConfig{Dataset: "users", Fields: []FieldConfig{{Name: "email"}}},
+			path:   "dataset", code: ProblemUnknownDataset,
+		},
+		{
+			name:   "unknown field",
+			config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "budget"}}},
+			path:   "fields[0].name", code: ProblemUnknownField,
+		},
+		{
+			name:   "injection in field name",
+			config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name; DROP TABLE projects"}}},
+			path:   "fields[0].name", code: ProblemInvalidIdentifier,
+		},
+		{
+			name:   "illegal aggregate",
+			config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name", Aggregate: AggregateSum}}},
+			path:   "fields[0].aggregate", code: ProblemIllegalAggregate,
+		},
+		{
+			name:   "unknown aggregate",
+			config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "estimated_credits", Aggregate: "MEDIAN"}}},
+			path:   "fields[0].aggregate", code: ProblemUnknownAggregate,
+		},
+		{
+			name:   "dataset not joinable",
+			config: ReportConfig{Dataset: "monitoring_data", Fields: []FieldConfig{{Name: "transactions.amount"}}},
+			path:   "fields[0].name", code: ProblemIllegalJoin,
+		},
+		{
+			name: "ungrouped column next to aggregate",
+			config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{
+				{Name: "region"}, {Name: "estimated_credits", Aggregate: AggregateSum},
+			}},
+			path: "fields[0]", code: ProblemMissingGrouping,
+		},
+		{
+			name: "disallowed filter value",
+			config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}},
+				Filters: []FilterConfig{{Field: "status", Operator: "eq", Value: "archived"}}},
+			path: "filters[0].value", code: ProblemInvalidValue,
+		},
+		{
+			name: "unknown operator",
+			config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}},
+				Filters: []FilterConfig{{Field: "status", Operator: "regex", Value: ".*"}}},
+			path: "filters[0].operator", code: ProblemUnknownOperator,
+		},
+		{
+			name: "time grain on text field",
+			config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "region"}},
+				Groupings: []GroupConfig{{Field: "region", TimeGrain: "month"}}},
+			path: "groupings[0].time_grain", code: ProblemInvalidTimeGrain,
+		},
+		{
+			name: "expression with SQL",
+			config: ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "name"}},
+				Calculations: []CalculationConfig{{Name: "x", Expression: "(SELECT password FROM users)"}}},
+			path: "calculations[0].expression", code: ProblemInvalidExpression,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := validateConfig(t, tt.config)
+			if response.Valid {
+				t.Fatal("expected config to be invalid")
+			}
+			if !hasProblem(response.Problems, tt.path, tt.code) {
+				t.Errorf("expected %s at %s, got %+v", tt.code, tt.path, response.Problems)
+			}
+		})
+	}
+}
+
+func TestValidateReportConfigCollectsEveryProblem(t *testing.T) {
+	response := validateConfig(t, ReportConfig{
+		Dataset: "projects",
+		Fields:  []FieldConfig{{Name: "budget"}, {Name: "name", Aggregate: AggregateAvg}},
+		Sorts:   []SortConfig{{Field: "name", Direction: "sideways"}},
+		Limit:   -1,
+	})
+
+	for _, want := range []struct{ path, code string }{
+		{"fields[0].name", ProblemUnknownField},
+		{"fields[1].aggregate", ProblemIllegalAggregate},
+		{"sorts[0].direction", ProblemInvalidSort},
+		{"limit", ProblemInvalidLimit},
+	} {
+		if !hasProblem(response.Problems, want.path, want.code) {
+			t.Errorf("expected %s at %s, got %+v", want.code, want.path, response.Problems)
+		}
+	}
+}
+
+func TestValidateEndpointDoesNotExecute(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+
+	w := doAs(router, uuid.New(), http.MethodPost, "/api/v1/reports/validate",
+		ReportConfig{Dataset: "projects", Fields: []FieldConfig{{Name: "budget"}}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", w.Code, w.Body.String())
+	}
+
+	var response ValidateConfigResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if response.Valid || !hasProblem(response.Problems, "fields[0].name", ProblemUnknownField) {
+		t.Errorf("unexpected response: %+v", response)
+	}
+	if len(repo.executions) != 0 {
+		t.Error("validation must not create executions")
+	}
+}
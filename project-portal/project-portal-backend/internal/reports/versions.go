@@ -0,0 +1,82 @@
+package reports
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ConfigChangeType describes how a config value differs between two versions
+type ConfigChangeType string
+
+const (
+	ChangeAdded    ConfigChangeType = "added"
+	ChangeRemoved  ConfigChangeType = "removed"
+	ChangeModified ConfigChangeType = "modified"
+)
+
+// ConfigChange is a single leaf-level difference between two report configs
+type ConfigChange struct {
+	Path string           `json:"path"` // e.g. "fields[1].aggregate"
+	Type ConfigChangeType `json:"type"`
+	From interface{}      `json:"from,omitempty"`
+	To   interface{}      `json:"to,omitempty"`
+}
+
+// diffConfigs compares two JSON report configs and returns their differences sorted by path
+func diffConfigs(from, to []byte) ([]ConfigChange, error) {
+	var fromValue, toValue interface{}
+	if err := json.Unmarshal(from, &fromValue); err != nil {
+		return nil, fmt.Errorf("invalid base config: %w", err)
+	}
+	if err := json.Unmarshal(to, &toValue); err != nil {
+		return nil, fmt.Errorf("invalid compared config: %w", err)
+	}
+
+	fromLeaves := make(map[string]interface{})
+	toLeaves := make(map[string]interface{})
+	flattenConfig("", fromValue, fromLeaves)
+	flattenConfig("", toValue, toLeaves)
+
+	changes := []ConfigChange{}
+	for path, fromLeaf := range fromLeaves {
+		toLeaf, ok := toLeaves[path]
+		switch {
+		case !ok:
+			changes = append(changes, ConfigChange{Path: path, Type: ChangeRemoved, From: fromLeaf})
+		case !reflect.DeepEqual(fromLeaf, toLeaf):
+			changes = append(changes, ConfigChange{Path: path, Type: ChangeModified, From: fromLeaf, To: toLeaf})
+		}
+	}
+	for path, toLeaf := range toLeaves {
+		if _, ok := fromLeaves[path]; !ok {
+			changes = append(changes, ConfigChange{Path: path, Type: ChangeAdded, To: toLeaf})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// flattenConfig records every scalar in value under a dotted/indexed path
+func flattenConfig(prefix string, value interface{}, leaves map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flattenConfig(path, child, leaves)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenConfig(fmt.Sprintf("%s[%d]", prefix, i), child, leaves)
+		}
+	default:
+		if v != nil {
+			leaves[prefix] = v
+		}
+	}
+}
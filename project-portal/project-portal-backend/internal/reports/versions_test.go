@@ -0,0 +1,112 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	from := []byte(`{"dataset":"projects","fields":[{"name":"name"},{"name":"area","aggregate":"SUM"}],"limit":10}`)
+	to := []byte(`{"dataset":"projects","fields":[{"name":"name"},{"name":"area","aggregate":"AVG"},{"name":"status"}],"sorts":[{"field":"name"}]}`)
+
+	changes, err := diffConfigs(from, to)
+	if err != nil {
+		t.Fatalf("diffConfigs failed: %v", err)
+	}
+
+	want := []ConfigChange{
+		{Path: "fields[1].aggregate", Type: ChangeModified, From: "SUM", To: "AVG"},
+		{Path: "fields[2].name", Type: ChangeAdded, To: "status"},
+		{Path: "limit", Type: ChangeRemoved, From: float64(10)},
+		{Path: "sorts[0].field", Type: ChangeAdded, To: "name"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("unexpected diff:\n got  %+v\n want %+v", changes, want)
+	}
+
+	same, _ := diffConfigs(from, from)
+	if len(same) != 0 {
+		t.Errorf("expected no changes for identical configs, got %+v", same)
+	}
+}
+
+func TestReportVersions_DiffAndRevert(t *testing.T) {
+	repo := newFakeRepository()
+	svc := NewService(repo, nil)
+	ctx := context.Background()
+	owner := uuid.New()
+
+	report, err := svc.CreateReport(ctx, owner, CreateReportRequest{
+		Name:   "Credits",
+		Config: ReportConfig{Dataset: "carbon_credits", Fields: []FieldConfig{{Name: "vintage"}}},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport failed: %v", err)
+	}
+
+	v2 := ReportConfig{Dataset: "carbon_credits", Fields: []FieldConfig{{Name: "vintage"}, {Name: "quantity", Aggregate: AggregateSum}}}
+	if _, err := svc.UpdateReport(ctx, owner, report.ID, UpdateReportRequest{Config: &v2}); err != nil {
+		t.Fatalf("UpdateReport failed: %v", err)
+	}
+	v3 := ReportConfig{Dataset: "transactions", Fields: []FieldConfig{{Name: "amount"}}}
+	if _, err := svc.UpdateReport(ctx, owner, report.ID, UpdateReportRequest{Name: "Revenue", Config: &v3}); err != nil {
+		t.Fatalf("UpdateReport failed: %v", err)
+	}
+
+	versions, err := svc.ListReportVersions(ctx, owner, report.ID)
+	if err != nil {
+		t.Fatalf("ListReportVersions failed: %v", err)
+	}
+	if len(versions) != 3 || versions[0].Version != 3 || versions[2].Version != 1 {
+		t.Fatalf("expected versions 3..1, got %+v", versions)
+	}
+
+	diff, err := svc.DiffReportVersions(ctx, owner, report.ID, 1, 2)
+	if err != nil {
+		t.Fatalf("DiffReportVersions failed: %v", err)
+	}
+	want := []ConfigChange{
+		{Path: "fields[1].aggregate", Type: ChangeAdded, To: "SUM"},
+		{Path: "fields[1].name", Type: ChangeAdded, To: "quantity"},
+	}
+	if !reflect.DeepEqual(diff.Changes, want) {
+		t.Errorf("unexpected diff 1->2: %+v", diff.Changes)
+	}
+
+	reverted, err := svc.RevertReport(ctx, owner, report.ID, 1)
+	if err != nil {
+		t.Fatalf("RevertReport failed: %v", err)
+	}
+	if reverted.Version != 4 {
+		t.Errorf("expected revert to create version 4, got %d", reverted.Version)
+	}
+	if reverted.Name != "Credits" {
+		t.Errorf("expected name restored from version 1, got %q", reverted.Name)
+	}
+
+	var config ReportConfig
+	json.Unmarshal(reverted.Config, &config)
+	if config.Dataset != "carbon_credits" || len(config.Fields) != 1 {
+		t.Errorf("expected version 1 config to be restored, got %+v", config)
+	}
+
+	diff, _ = svc.DiffReportVersions(ctx, owner, report.ID, 1, 4)
+	if len(diff.Changes) != 0 {
+		t.Errorf("expected reverted version to match version 1, got %+v", diff.Changes)
+	}
+
+	// History is preserved: version 3 is still there
+	versions, _ = svc.ListReportVersions(ctx, owner, report.ID)
+	if len(versions) != 4 {
+		t.Errorf("expected 4 versions after revert, got %d", len(versions))
+	}
+
+	if _, err := svc.RevertReport(ctx, uuid.New(), report.ID, 2); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("expected non-owner revert to be denied, got %v", err)
+	}
+}
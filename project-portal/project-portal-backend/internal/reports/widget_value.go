@@ -0,0 +1,143 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
+	"github.com/google/uuid"
+)
+
+// valueAggregations are the aggregates a single-value widget may apply
+var valueAggregations = map[AggregateFunction]bool{
+	AggregateSum: true, AggregateAvg: true, AggregateCount: true, AggregateMin: true, AggregateMax: true,
+}
+
+// valueWindows are the calendar periods a single-value widget may cover
+var valueWindows = map[string]bool{"day": true, "week": true, "month": true, "quarter": true, "year": true}
+
+// WidgetValueResponse is the current value of a single-value widget. The previous
+// period covers as much of the period before as has elapsed of the current one,
+// so a month to date is compared with the same days of last month.
+type WidgetValueResponse struct {
+	WidgetID      uuid.UUID         `json:"widget_id"`
+	Metric        string            `json:"metric"`
+	Aggregation   AggregateFunction `json:"aggregation"`
+	Window        string            `json:"window"`
+	Value         float64           `json:"value"`
+	PreviousValue float64           `json:"previous_value"`
+	Change        float64           `json:"change"`
+	ChangePercent *float64          `json:"change_percent"` // nil when the previous value is 0
+	Trend         string            `json:"trend"`          // up, down, stable
+	PeriodStart   time.Time         `json:"period_start"`
+	PeriodEnd     time.Time         `json:"period_end"`
+	PreviousStart time.Time         `json:"previous_start"`
+	PreviousEnd   time.Time         `json:"previous_end"`
+}
+
+// validateValueWidget checks the config of a single-value widget
+func validateValueWidget(raw []byte) (WidgetConfig, error) {
+	var config WidgetConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return config, apperrors.Wrap(apperrors.ErrValidation, "invalid widget config", err)
+	}
+	if _, ok := dashboardMetrics[config.Metric]; !ok {
+		return config, apperrors.Field("config.metric", "must be one of credits, revenue, projects")
+	}
+	if !valueAggregations[config.Aggregation] {
+		return config, apperrors.Field("config.aggregation", "must be one of SUM, AVG, COUNT, MIN, MAX")
+	}
+	if !valueWindows[config.Window] {
+		return config, apperrors.Field("config.window", "must be one of day, week, month, quarter, year")
+	}
+	return config, nil
+}
+
+// valuePeriods returns the current period of window up to now and the matching
+// stretch of the period before it
+func valuePeriods(window string, now time.Time) (start, previousStart, previousEnd time.Time) {
+	year, month, day := now.Date()
+	switch window {
+	case "day":
+		start = time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+		previousStart = start.AddDate(0, 0, -1)
+	case "week":
+		// Weeks start on Monday, as date_trunc('week') does
+		offset := (int(now.Weekday()) + 6) % 7
+		start = time.Date(year, month, day-offset, 0, 0, 0, 0, now.Location())
+		previousStart = start.AddDate(0, 0, -7)
+	case "month":
+		start = time.Date(year, month, 1, 0, 0, 0, 0, now.Location())
+		previousStart = start.AddDate(0, -1, 0)
+	case "quarter":
+		start = time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, now.Location())
+		previousStart = start.AddDate(0, -3, 0)
+	case "year":
+		start = time.Date(year, 1, 1, 0, 0, 0, 0, now.Location())
+		previousStart = start.AddDate(-1, 0, 0)
+	}
+
+	// A short previous month cannot run into the current one
+	previousEnd = previousStart.Add(now.Sub(start))
+	if previousEnd.After(start) {
+		previousEnd = start
+	}
+	return start, previousStart, previousEnd
+}
+
+// GetWidgetValue computes a single-value widget's metric for the current period and its delta
+func (s *service) GetWidgetValue(ctx context.Context, userID uuid.UUID, widgetID uuid.UUID) (*WidgetValueResponse, error) {
+	widget, err := s.repo.GetWidget(ctx, widgetID)
+	if err != nil || (widget.UserID != nil && *widget.UserID != userID) || !TenantFromContext(ctx).canSee(widget.OrganizationID) {
+		return nil, apperrors.NotFound("widget not found")
+	}
+	if widget.WidgetType != WidgetSingleValue {
+		return nil, apperrors.Validation(fmt.Sprintf("widget is a %s widget, not %s", widget.WidgetType, WidgetSingleValue))
+	}
+	config, err := validateValueWidget(widget.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	start, previousStart, previousEnd := valuePeriods(config.Window, now)
+	tenant := TenantFromContext(ctx)
+
+	value, err := s.repo.AggregateMetric(ctx, config.Metric, config.Aggregation, start, now, tenant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute widget value: %w", err)
+	}
+	previous, err := s.repo.AggregateMetric(ctx, config.Metric, config.Aggregation, previousStart, previousEnd, tenant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute previous widget value: %w", err)
+	}
+
+	response := &WidgetValueResponse{
+		WidgetID:      widget.ID,
+		Metric:        config.Metric,
+		Aggregation:   config.Aggregation,
+		Window:        config.Window,
+		Value:         value,
+		PreviousValue: previous,
+		Change:        value - previous,
+		Trend:         "stable",
+		PeriodStart:   start,
+		PeriodEnd:     now,
+		PreviousStart: previousStart,
+		PreviousEnd:   previousEnd,
+	}
+	if previous != 0 {
+		percent := math.Round(response.Change/math.Abs(previous)*10000) / 100
+		response.ChangePercent = &percent
+	}
+	if response.Change > 0 {
+		response.Trend = "up"
+	} else if response.Change < 0 {
+		response.Trend = "down"
+	}
+	return response, nil
+}
@@ -0,0 +1,128 @@
+package reports
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+func TestValuePeriods(t *testing.T) {
+	// A Tuesday afternoon at the end of March
+	now := time.Date(2026, 3, 31, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		window                            string
+		start, previousStart, previousEnd string
+	}{
+		{"day", "2026-03-31T00:00", "2026-03-30T00:00", "2026-03-30T15:00"},
+		{"week", "2026-03-30T00:00", "2026-03-23T00:00", "2026-03-24T15:00"},
+		// February is shorter than the 30 days of March elapsed, so all of it is compared
+		{"month", "2026-03-01T00:00", "2026-02-01T00:00", "2026-03-01T00:00"},
+		{"quarter", "2026-01-01T00:00", "2025-10-01T00:00", "2025-12-29T15:00"},
+		{"year", "2026-01-01T00:00", "2025-01-01T00:00", "2025-03-31T15:00"},
+	}
+	const layout = "2006-01-02T15:04"
+	for _, tt := range tests {
+		start, previousStart, previousEnd := valuePeriods(tt.window, now)
+		got := [3]string{start.Format(layout), previousStart.Format(layout), previousEnd.Format(layout)}
+		if want := [3]string{tt.start, tt.previousStart, tt.previousEnd}; got != want {
+			t.Errorf("%s: got %v, want %v", tt.window, got, want)
+		}
+	}
+}
+
+func TestGetWidgetValue_ComputesValueAndDelta(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+	user := uuid.New()
+
+	var calls []string
+	repo.metricFunc = func(metric string, aggregate AggregateFunction, start, end time.Time) float64 {
+		calls = append(calls, metric+" "+string(aggregate))
+		if start.Day() == 1 && start.Month() == time.Now().UTC().Month() {
+			return 150 // this month
+		}
+		return 120 // same days of last month
+	}
+
+	config, _ := json.Marshal(WidgetConfig{Metric: "credits", Aggregation: AggregateSum, Window: "month"})
+	w := doAs(router, user, http.MethodPost, "/api/v1/reports/dashboard/widgets", DashboardWidget{
+		Title: "Credits this month", WidgetType: WidgetSingleValue, Config: datatypes.JSON(config),
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create widget failed: %d %s", w.Code, w.Body.String())
+	}
+	var widget DashboardWidget
+	if err := json.Unmarshal(w.Body.Bytes(), &widget); err != nil {
+		t.Fatalf("invalid widget response: %v", err)
+	}
+
+	w = doAs(router, user, http.MethodGet, "/api/v1/reports/dashboard/widgets/"+widget.ID.String()+"/value", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var value WidgetValueResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &value); err != nil {
+		t.Fatalf("invalid value response: %v", err)
+	}
+
+	if value.Value != 150 || value.PreviousValue != 120 || value.Change != 30 || value.Trend != "up" {
+		t.Errorf("unexpected value: %+v", value)
+	}
+	if value.ChangePercent == nil || *value.ChangePercent != 25 {
+		t.Errorf("expected a 25%% change, got %v", value.ChangePercent)
+	}
+	if len(calls) != 2 || calls[0] != "credits SUM" {
+		t.Errorf("expected the metric to be aggregated for both periods, got %v", calls)
+	}
+
+	t.Run("no previous value", func(t *testing.T) {
+		repo.metricFunc = func(metric string, aggregate AggregateFunction, start, end time.Time) float64 {
+			if start.Day() == 1 && start.Month() == time.Now().UTC().Month() {
+				return 5
+			}
+			return 0
+		}
+		w := doAs(router, user, http.MethodGet, "/api/v1/reports/dashboard/widgets/"+widget.ID.String()+"/value", nil)
+		var value WidgetValueResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &value); err != nil {
+			t.Fatalf("invalid value response: %v", err)
+		}
+		if value.Change != 5 || value.ChangePercent != nil {
+			t.Errorf("expected no percentage against zero, got %+v", value)
+		}
+	})
+
+	t.Run("other user's widget", func(t *testing.T) {
+		w := doAs(router, uuid.New(), http.MethodGet, "/api/v1/reports/dashboard/widgets/"+widget.ID.String()+"/value", nil)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", w.Code)
+		}
+	})
+}
+
+func TestSaveWidget_ValidatesSingleValueConfig(t *testing.T) {
+	repo := newFakeRepository()
+	router := newSharingTestRouter(repo)
+
+	for name, config := range map[string]WidgetConfig{
+		"unknown metric":      {Metric: "emissions", Aggregation: AggregateSum, Window: "month"},
+		"unknown aggregation": {Metric: "credits", Aggregation: "MEDIAN", Window: "month"},
+		"unknown window":      {Metric: "credits", Aggregation: AggregateSum, Window: "fortnight"},
+	} {
+		raw, _ := json.Marshal(config)
+		w := doAs(router, uuid.New(), http.MethodPost, "/api/v1/reports/dashboard/widgets", DashboardWidget{
+			Title: name, WidgetType: WidgetSingleValue, Config: datatypes.JSON(raw),
+		})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", name, w.Code)
+		}
+	}
+	if len(repo.widgets) != 0 {
+		t.Error("invalid widgets must not be saved")
+	}
+}
@@ -0,0 +1,107 @@
+// Package retention purges records that have outlived their table's retention window.
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// PurgeFunc removes records created before cutoff that are not on legal hold and
+// returns how many it removed. With dryRun set it only counts what it would remove.
+type PurgeFunc func(ctx context.Context, before time.Time, dryRun bool) (int64, error)
+
+// Result is the outcome of purging one table
+type Result struct {
+	Table     string        `json:"table"`
+	Retention time.Duration `json:"retention"`
+	Cutoff    time.Time     `json:"cutoff"`
+	Records   int64         `json:"records"` // Deleted, or that would be deleted in a dry run
+	DryRun    bool          `json:"dry_run"`
+}
+
+type table struct {
+	name  string
+	purge PurgeFunc
+}
+
+// Job purges every registered table whose retention window is set
+type Job struct {
+	windows map[string]time.Duration
+	tables  []table
+	dryRun  bool
+	now     func() time.Time
+}
+
+// NewJob creates a job using windows per table name. Tables without a positive
+// window are kept forever. A dry-run job never deletes anything.
+func NewJob(windows map[string]time.Duration, dryRun bool) *Job {
+	return &Job{windows: windows, dryRun: dryRun, now: time.Now}
+}
+
+// Register adds a table the job may purge
+func (j *Job) Register(name string, purge PurgeFunc) {
+	j.tables = append(j.tables, table{name: name, purge: purge})
+}
+
+// Run purges each table once. A failing table does not stop the others; their
+// errors are returned together with the results of the tables that succeeded.
+func (j *Job) Run(ctx context.Context) ([]Result, error) {
+	now := j.now()
+
+	var results []Result
+	var errs []error
+	for _, t := range j.tables {
+		window := j.windows[t.name]
+		if window <= 0 {
+			continue
+		}
+
+		cutoff := now.Add(-window)
+		records, err := t.purge(ctx, cutoff, j.dryRun)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to purge %s: %w", t.name, err))
+			continue
+		}
+		results = append(results, Result{
+			Table:     t.name,
+			Retention: window,
+			Cutoff:    cutoff,
+			Records:   records,
+			DryRun:    j.dryRun,
+		})
+	}
+	return results, errors.Join(errs...)
+}
+
+// Start runs the job now and then every interval until ctx is cancelled
+func (j *Job) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			j.runAndLog(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (j *Job) runAndLog(ctx context.Context) {
+	results, err := j.Run(ctx)
+	for _, r := range results {
+		if r.DryRun {
+			log.Printf("retention (dry run): would delete %d %s records created before %s", r.Records, r.Table, r.Cutoff.UTC().Format(time.RFC3339))
+		} else if r.Records > 0 {
+			log.Printf("retention: deleted %d %s records created before %s", r.Records, r.Table, r.Cutoff.UTC().Format(time.RFC3339))
+		}
+	}
+	if err != nil {
+		log.Printf("retention: %v", err)
+	}
+}
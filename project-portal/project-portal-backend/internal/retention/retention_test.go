@@ -0,0 +1,88 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordedPurge struct {
+	before time.Time
+	dryRun bool
+}
+
+func recorder(calls *[]recordedPurge, records int64, err error) PurgeFunc {
+	return func(ctx context.Context, before time.Time, dryRun bool) (int64, error) {
+		*calls = append(*calls, recordedPurge{before: before, dryRun: dryRun})
+		return records, err
+	}
+}
+
+func TestJobRun_UsesEachTablesWindow(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	job := NewJob(map[string]time.Duration{
+		"report_executions":  90 * 24 * time.Hour,
+		"webhook_deliveries": 30 * 24 * time.Hour,
+	}, false)
+	job.now = func() time.Time { return now }
+
+	var executions, deliveries, unconfigured []recordedPurge
+	job.Register("report_executions", recorder(&executions, 3, nil))
+	job.Register("webhook_deliveries", recorder(&deliveries, 7, nil))
+	job.Register("audit_logs", recorder(&unconfigured, 0, nil))
+
+	results, err := job.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(executions) != 1 || !executions[0].before.Equal(now.Add(-90*24*time.Hour)) || executions[0].dryRun {
+		t.Errorf("unexpected report_executions purge: %+v", executions)
+	}
+	if len(deliveries) != 1 || !deliveries[0].before.Equal(now.Add(-30*24*time.Hour)) {
+		t.Errorf("unexpected webhook_deliveries purge: %+v", deliveries)
+	}
+	if len(unconfigured) != 0 {
+		t.Error("tables without a window must be kept forever")
+	}
+
+	if len(results) != 2 || results[0].Records != 3 || results[1].Records != 7 {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
+
+func TestJobRun_DryRun(t *testing.T) {
+	job := NewJob(map[string]time.Duration{"report_executions": time.Hour}, true)
+
+	var calls []recordedPurge
+	job.Register("report_executions", recorder(&calls, 4, nil))
+
+	results, err := job.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(calls) != 1 || !calls[0].dryRun {
+		t.Fatalf("expected a dry-run purge, got %+v", calls)
+	}
+	if len(results) != 1 || !results[0].DryRun || results[0].Records != 4 {
+		t.Errorf("expected the dry run to report 4 records, got %+v", results)
+	}
+}
+
+func TestJobRun_ContinuesPastFailingTable(t *testing.T) {
+	job := NewJob(map[string]time.Duration{"a": time.Hour, "b": time.Hour}, false)
+
+	var a, b []recordedPurge
+	job.Register("a", recorder(&a, 0, errors.New("connection reset")))
+	job.Register("b", recorder(&b, 2, nil))
+
+	results, err := job.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to purge a") {
+		t.Fatalf("expected the failure of table a, got %v", err)
+	}
+	if len(b) != 1 || len(results) != 1 || results[0].Table != "b" {
+		t.Errorf("expected table b to be purged anyway, got %+v", results)
+	}
+}
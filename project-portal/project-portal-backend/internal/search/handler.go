@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"carbon-scribe/project-portal/project-portal-backend/pkg/apperrors"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -36,19 +38,19 @@ func (h *Handler) SearchNearby(c *gin.Context) {
 	dist := c.DefaultQuery("dist", "50km")
 
 	if latStr == "" || lonStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "lat and lon parameters are required"})
+		c.Error(apperrors.Validation("lat and lon parameters are required"))
 		return
 	}
 
 	lat, err := strconv.ParseFloat(latStr, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lat parameter"})
+		c.Error(apperrors.Field("lat", "must be a number"))
 		return
 	}
 
 	lon, err := strconv.ParseFloat(lonStr, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid lon parameter"})
+		c.Error(apperrors.Field("lon", "must be a number"))
 		return
 	}
 
@@ -64,7 +66,7 @@ func (h *Handler) SearchNearby(c *gin.Context) {
 
 	resp, err := h.service.SearchNearby(c.Request.Context(), req, lat, lon, dist)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -114,7 +116,7 @@ func (h *Handler) Search(c *gin.Context) {
 	// Execute search
 	resp, err := h.service.SearchProjects(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -124,7 +126,7 @@ func (h *Handler) Search(c *gin.Context) {
 // SyncIndex handles index sync requests
 func (h *Handler) SyncIndex(c *gin.Context) {
 	if err := h.service.SyncIndex(c.Request.Context()); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
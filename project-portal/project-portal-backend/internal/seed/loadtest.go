@@ -0,0 +1,79 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// loadTestTables are the tables a load-test schema holds, in creation order
+var loadTestTables = []string{"projects", "monitoring_areas", "carbon_credits", "transactions", "monitoring_data", "benchmark_datasets"}
+
+var schemaNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// LoadTestOptions returns a data set for benchmarking the report query builder.
+// Each step of scale adds 50 projects with 12 credit batches, 36 transactions and a
+// year of daily readings each, so scale 10 yields about 200,000 rows.
+func LoadTestOptions(scale int) Options {
+	opts := DefaultOptions()
+	opts.Projects = 50 * scale
+	opts.CreditsPerProject = 12
+	opts.TransactionsPerCredit = 3
+	opts.ReadingsPerProject = 365
+	return opts
+}
+
+// validateSchemaName only accepts plain lower-case names that are not one of the
+// schemas the application or postgres itself use
+func validateSchemaName(schema string) error {
+	if !schemaNamePattern.MatchString(schema) || schema == "public" || strings.HasPrefix(schema, "pg_") {
+		return fmt.Errorf("invalid load-test schema name %q", schema)
+	}
+	return nil
+}
+
+// PrepareSchema creates schema with empty copies of the reporting tables in public,
+// so a load test never writes to the application's own tables. The public tables
+// must have been migrated first. Foreign keys are not copied.
+func PrepareSchema(ctx context.Context, db *gorm.DB, schema string) error {
+	if err := validateSchemaName(schema); err != nil {
+		return err
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", schema)).Error; err != nil {
+			return fmt.Errorf("failed to create schema %s: %w", schema, err)
+		}
+		for _, table := range loadTestTables {
+			statement := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (LIKE public.%s INCLUDING DEFAULTS INCLUDING INDEXES)", schema, table, table)
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("failed to create %s.%s: %w", schema, table, err)
+			}
+		}
+		return nil
+	})
+}
+
+// SchemaDSN returns databaseURL with its search path set to schema, so every
+// connection of the pool reads and writes the load-test tables
+func SchemaDSN(databaseURL, schema string) (string, error) {
+	if err := validateSchemaName(schema); err != nil {
+		return "", err
+	}
+
+	if strings.Contains(databaseURL, "://") {
+		u, err := url.Parse(databaseURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid database URL: %w", err)
+		}
+		query := u.Query()
+		query.Set("search_path", schema)
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+	return strings.TrimSpace(databaseURL) + " search_path=" + schema, nil
+}
@@ -0,0 +1,91 @@
+// Package apperrors defines the error kinds shared by the service layer so the
+// HTTP layer can map them to status codes and machine-readable codes.
+package apperrors
+
+import (
+	"errors"
+)
+
+// Error kinds. Match them with errors.Is.
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrValidation = errors.New("validation failed")
+	ErrConflict   = errors.New("conflict")
+	ErrForbidden  = errors.New("forbidden")
+
+	ErrNotAcceptable = errors.New("not acceptable")
+	ErrUnauthorized  = errors.New("unauthorized")
+)
+
+// FieldError describes a problem with a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is an error of a given kind with a client-facing message
+type Error struct {
+	Kind    error
+	Message string
+	Fields  []FieldError
+	Err     error // Underlying cause, if any
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap exposes both the kind and the cause to errors.Is and errors.As
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+// New returns an error of the given kind
+func New(kind error, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// Wrap returns an error of the given kind caused by err, keeping any field details err carries
+func Wrap(kind error, message string, err error) *Error {
+	return &Error{Kind: kind, Message: message, Fields: Fields(err), Err: err}
+}
+
+// NotFound returns an ErrNotFound error
+func NotFound(message string) *Error { return New(ErrNotFound, message) }
+
+// Conflict returns an ErrConflict error
+func Conflict(message string) *Error { return New(ErrConflict, message) }
+
+// Forbidden returns an ErrForbidden error
+func Forbidden(message string) *Error { return New(ErrForbidden, message) }
+
+// NotAcceptable returns an ErrNotAcceptable error
+func NotAcceptable(message string) *Error { return New(ErrNotAcceptable, message) }
+
+// Unauthorized returns an ErrUnauthorized error
+func Unauthorized(message string) *Error { return New(ErrUnauthorized, message) }
+
+// Validation returns an ErrValidation error with optional field details
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{Kind: ErrValidation, Message: message, Fields: fields}
+}
+
+// Field returns a validation error for a single field
+func Field(field, message string) *Error {
+	return Validation(field+" "+message, FieldError{Field: field, Message: message})
+}
+
+// Fields returns the field details carried by err, if any
+func Fields(err error) []FieldError {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Fields
+	}
+	return nil
+}
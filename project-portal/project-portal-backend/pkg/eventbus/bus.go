@@ -0,0 +1,150 @@
+package eventbus
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultQueueSize is the per-subscriber buffer used by NewBus when size <= 0
+const DefaultQueueSize = 256
+
+// Event is a domain event published by a producer module
+type Event struct {
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`   // e.g. "collaboration.task_created"
+	Source     string         `json:"source"` // producing module
+	OccurredAt time.Time      `json:"occurred_at"`
+	Data       map[string]any `json:"data"`
+}
+
+// Handler processes one event for a subscriber
+type Handler func(ctx context.Context, event Event) error
+
+// Publisher is the producer-side view of the bus
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// Bus fans events out to subscribers. Each subscriber has its own queue and
+// goroutine, so a slow or failing subscriber never delays the others or the publisher.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []*subscriber
+	queueSize   int
+	closed      bool
+	wg          sync.WaitGroup
+}
+
+type subscriber struct {
+	name    string
+	types   map[string]bool // empty means all event types
+	handler Handler
+	queue   chan queued
+}
+
+type queued struct {
+	ctx   context.Context
+	event Event
+}
+
+// NewBus creates a bus whose subscribers buffer up to queueSize pending events each
+func NewBus(queueSize int) *Bus {
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	return &Bus{queueSize: queueSize}
+}
+
+// Subscribe registers a handler for the given event types (all types if none are given)
+func (b *Bus) Subscribe(name string, handler Handler, eventTypes ...string) {
+	sub := &subscriber{
+		name:    name,
+		types:   make(map[string]bool, len(eventTypes)),
+		handler: handler,
+		queue:   make(chan queued, b.queueSize),
+	}
+	for _, t := range eventTypes {
+		sub.types[t] = true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.subscribers = append(b.subscribers, sub)
+
+	b.wg.Add(1)
+	go b.run(sub)
+}
+
+// Publish enqueues the event for every interested subscriber without blocking.
+// If a subscriber's queue is full the event is dropped for that subscriber only.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	// Handlers run after the request that produced the event has finished
+	ctx = context.WithoutCancel(ctx)
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+
+	for _, sub := range b.subscribers {
+		if len(sub.types) > 0 && !sub.types[event.Type] {
+			continue
+		}
+		select {
+		case sub.queue <- queued{ctx: ctx, event: event}:
+		default:
+			log.Printf("eventbus: subscriber %s is falling behind, dropped event %s (%s)", sub.name, event.ID, event.Type)
+		}
+	}
+}
+
+// Close stops accepting events and waits for subscribers to drain their queues
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	for _, sub := range b.subscribers {
+		close(sub.queue)
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+func (b *Bus) run(sub *subscriber) {
+	defer b.wg.Done()
+	for item := range sub.queue {
+		b.deliver(sub, item)
+	}
+}
+
+// deliver invokes the handler, containing panics so one bad event cannot kill the subscriber
+func (b *Bus) deliver(sub *subscriber, item queued) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("eventbus: subscriber %s panicked on event %s: %v", sub.name, item.event.ID, r)
+		}
+	}()
+
+	if err := sub.handler(item.ctx, item.event); err != nil {
+		log.Printf("eventbus: subscriber %s failed on event %s (%s): %v", sub.name, item.event.ID, item.event.Type, err)
+	}
+}
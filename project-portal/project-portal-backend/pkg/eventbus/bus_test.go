@@ -0,0 +1,114 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func waitFor(t *testing.T, ch <-chan Event, timeout time.Duration) Event {
+	t.Helper()
+	select {
+	case event := <-ch:
+		return event
+	case <-time.After(timeout):
+		t.Fatal("timed out waiting for event")
+		return Event{}
+	}
+}
+
+func TestBus_FanOut(t *testing.T) {
+	bus := NewBus(8)
+	defer bus.Close()
+
+	notifications := make(chan Event, 4)
+	integrations := make(chan Event, 4)
+	payments := make(chan Event, 4)
+
+	bus.Subscribe("notifications", func(ctx context.Context, e Event) error { notifications <- e; return nil })
+	bus.Subscribe("integrations", func(ctx context.Context, e Event) error { integrations <- e; return nil })
+	bus.Subscribe("payments", func(ctx context.Context, e Event) error { payments <- e; return nil }, "payment.completed")
+
+	bus.Publish(context.Background(), Event{Type: "collaboration.task_created", Data: map[string]any{"title": "Survey"}})
+
+	for _, ch := range []chan Event{notifications, integrations} {
+		event := waitFor(t, ch, time.Second)
+		if event.Type != "collaboration.task_created" || event.ID == "" || event.OccurredAt.IsZero() {
+			t.Errorf("unexpected event %+v", event)
+		}
+	}
+
+	select {
+	case event := <-payments:
+		t.Errorf("filtered subscriber received %s", event.Type)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	bus.Publish(context.Background(), Event{Type: "payment.completed"})
+	waitFor(t, payments, time.Second)
+}
+
+func TestBus_SlowSubscriberIsolation(t *testing.T) {
+	bus := NewBus(1)
+
+	release := make(chan struct{})
+	bus.Subscribe("slow", func(ctx context.Context, e Event) error {
+		<-release
+		return nil
+	})
+
+	fast := make(chan Event, 10)
+	bus.Subscribe("fast", func(ctx context.Context, e Event) error { fast <- e; return nil })
+
+	bus.Subscribe("panicky", func(ctx context.Context, e Event) error { panic("boom") })
+
+	// "slow" is stuck and its one-slot queue overflows, yet publishing never blocks
+	// and "fast" still receives every event
+	for i := 0; i < 5; i++ {
+		published := make(chan struct{})
+		go func() {
+			bus.Publish(context.Background(), Event{Type: "monitoring.alert"})
+			close(published)
+		}()
+		select {
+		case <-published:
+		case <-time.After(time.Second):
+			t.Fatal("publish blocked on a slow subscriber")
+		}
+		waitFor(t, fast, time.Second)
+	}
+
+	close(release)
+	bus.Close()
+}
+
+func TestBus_HandlerContextOutlivesRequest(t *testing.T) {
+	bus := NewBus(4)
+	defer bus.Close()
+
+	var mu sync.Mutex
+	var handlerErr error
+	received := make(chan Event, 1)
+	proceed := make(chan struct{})
+	bus.Subscribe("integrations", func(ctx context.Context, e Event) error {
+		<-proceed
+		mu.Lock()
+		handlerErr = ctx.Err()
+		mu.Unlock()
+		received <- e
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bus.Publish(ctx, Event{Type: "collaboration.comment_added"})
+	cancel()
+	close(proceed)
+
+	waitFor(t, received, time.Second)
+	mu.Lock()
+	defer mu.Unlock()
+	if handlerErr != nil {
+		t.Errorf("expected handler context to survive request cancellation, got %v", handlerErr)
+	}
+}